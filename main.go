@@ -58,8 +58,12 @@ func run() int {
 	}()
 
 	if _, err := driftctlCmd.ExecuteC(); err != nil {
-		if _, isNotInSync := err.(cmderrors.InfrastructureNotInSync); isNotInSync {
-			return 1
+		if notInSync, isNotInSync := err.(cmderrors.InfrastructureNotInSync); isNotInSync {
+			return notInSync.ExitCode()
+		}
+		if lintIssues, hasLintIssues := err.(cmderrors.DriftIgnoreLintIssues); hasLintIssues {
+			_, _ = fmt.Fprintln(os.Stderr, lintIssues)
+			return lintIssues.ExitCode()
 		}
 		if cmd.IsReportingEnabled(&driftctlCmd.Command) {
 			sentry.CaptureException(err)
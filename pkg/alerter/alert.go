@@ -1,6 +1,9 @@
 package alerter
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"reflect"
+)
 
 type Alerts map[string][]Alert
 
@@ -27,7 +30,9 @@ type SerializableAlert struct {
 }
 
 type SerializedAlert struct {
-	Msg string `json:"message"`
+	Msg            string `json:"message"`
+	Type           string `json:"type,omitempty"`
+	IgnoreResource bool   `json:"ignore_resource,omitempty"`
 }
 
 func (u *SerializedAlert) Message() string {
@@ -35,7 +40,7 @@ func (u *SerializedAlert) Message() string {
 }
 
 func (u *SerializedAlert) ShouldIgnoreResource() bool {
-	return false
+	return u.IgnoreResource
 }
 
 func (s *SerializableAlert) UnmarshalJSON(bytes []byte) error {
@@ -49,5 +54,22 @@ func (s *SerializableAlert) UnmarshalJSON(bytes []byte) error {
 }
 
 func (s *SerializableAlert) MarshalJSON() ([]byte, error) {
-	return json.Marshal(SerializedAlert{Msg: s.Message()})
+	return json.Marshal(SerializedAlert{
+		Msg:            s.Message(),
+		Type:           alertType(s.Alert),
+		IgnoreResource: s.ShouldIgnoreResource(),
+	})
+}
+
+// alertType returns the name of the go type of the alert, e.g. RemoteAccessDeniedAlert.
+// Alerts read from a previous scan result keep their original type.
+func alertType(alert Alert) string {
+	if serialized, ok := alert.(*SerializedAlert); ok {
+		return serialized.Type
+	}
+	t := reflect.TypeOf(alert)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
 }
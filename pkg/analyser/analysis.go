@@ -16,7 +16,7 @@ import (
 type Change struct {
 	diff.Change
 	Computed   bool `json:"computed"`
-	JsonString bool `json:"-"`
+	JsonString bool `json:"json_string,omitempty"`
 }
 
 type Changelog []Change
@@ -41,6 +41,8 @@ type Analysis struct {
 	differences     []Difference
 	summary         Summary
 	alerts          alerter.Alerts
+	baseline        *Baseline
+	severities      map[string]Severity
 	Duration        time.Duration
 	Date            time.Time
 	ProviderName    string
@@ -50,18 +52,46 @@ type Analysis struct {
 type serializableDifference struct {
 	Res       resource.SerializableResource `json:"res"`
 	Changelog Changelog                     `json:"changelog"`
+	Severity  Severity                      `json:"severity,omitempty"`
 }
 
+// serializableDrift is an unmanaged or missing resource, with the severity of its drift
+type serializableDrift struct {
+	resource.SerializableResource
+	Severity Severity `json:"severity,omitempty"`
+}
+
+type serializableDrifts struct {
+	Unmanaged   []serializableDrift      `json:"unmanaged"`
+	Deleted     []serializableDrift      `json:"missing"`
+	Differences []serializableDifference `json:"differences"`
+}
+
+type serializableBaseline struct {
+	New        serializableDrifts `json:"new"`
+	Persisting serializableDrifts `json:"persisting"`
+	Resolved   serializableDrifts `json:"resolved"`
+}
+
+// SchemaVersion is the version of the JSON representation of an analysis.
+// It must be bumped on every breaking change of the format, see docs/scan-output.schema.json
+const SchemaVersion = 1
+
 type serializableAnalysis struct {
+	SchemaVersion   int                                    `json:"schema_version"`
 	Summary         Summary                                `json:"summary"`
 	Managed         []resource.SerializableResource        `json:"managed"`
-	Unmanaged       []resource.SerializableResource        `json:"unmanaged"`
-	Deleted         []resource.SerializableResource        `json:"missing"`
+	Unmanaged       []serializableDrift                    `json:"unmanaged"`
+	Deleted         []serializableDrift                    `json:"missing"`
 	Differences     []serializableDifference               `json:"differences"`
 	Coverage        int                                    `json:"coverage"`
 	Alerts          map[string][]alerter.SerializableAlert `json:"alerts"`
 	ProviderName    string                                 `json:"provider_name"`
 	ProviderVersion string                                 `json:"provider_version"`
+	Accounts        map[string]Summary                     `json:"accounts,omitempty"`
+	Baseline        *serializableBaseline                  `json:"baseline,omitempty"`
+	Date            *time.Time                             `json:"date,omitempty"`
+	Duration        time.Duration                          `json:"scan_duration,omitempty"`
 }
 
 type GenDriftIgnoreOptions struct {
@@ -72,23 +102,28 @@ type GenDriftIgnoreOptions struct {
 	OutputPath       string
 }
 
+// MarshalJSON writes the analysis without the attributes of resources, as they may hold secrets
 func (a Analysis) MarshalJSON() ([]byte, error) {
-	bla := serializableAnalysis{}
-	for _, m := range a.managed {
-		bla.Managed = append(bla.Managed, *resource.NewSerializableResource(m))
-	}
-	for _, u := range a.unmanaged {
-		bla.Unmanaged = append(bla.Unmanaged, *resource.NewSerializableResource(u))
-	}
-	for _, d := range a.deleted {
-		bla.Deleted = append(bla.Deleted, *resource.NewSerializableResource(d))
+	return a.marshalJSON(false)
+}
+
+// MarshalJSONWithAttributes writes the analysis with the attributes of resources,
+// the values of attributes marked as sensitive in the provider schema are masked
+func (a Analysis) MarshalJSONWithAttributes() ([]byte, error) {
+	return a.marshalJSON(true)
+}
+
+func (a Analysis) marshalJSON(attributes bool) ([]byte, error) {
+	bla := serializableAnalysis{
+		SchemaVersion: SchemaVersion,
 	}
-	for _, di := range a.differences {
-		bla.Differences = append(bla.Differences, serializableDifference{
-			Res:       *resource.NewSerializableResource(di.Res),
-			Changelog: di.Changelog,
-		})
+	for _, m := range a.managed {
+		bla.Managed = append(bla.Managed, newSerializableResource(m, attributes))
 	}
+	drifts := a.newSerializableDrifts(a.Drifts(), attributes)
+	bla.Unmanaged = drifts.Unmanaged
+	bla.Deleted = drifts.Deleted
+	bla.Differences = drifts.Differences
 	if len(a.alerts) > 0 {
 		bla.Alerts = make(map[string][]alerter.SerializableAlert)
 		for k, v := range a.alerts {
@@ -101,6 +136,19 @@ func (a Analysis) MarshalJSON() ([]byte, error) {
 	bla.Coverage = a.Coverage()
 	bla.ProviderName = a.ProviderName
 	bla.ProviderVersion = a.ProviderVersion
+	bla.Accounts = a.AccountSummaries()
+	if a.baseline != nil {
+		bla.Baseline = &serializableBaseline{
+			New:        a.newSerializableDrifts(a.baseline.New, attributes),
+			Persisting: a.newSerializableDrifts(a.baseline.Persisting, attributes),
+			Resolved:   a.newSerializableDrifts(a.baseline.Resolved, attributes),
+		}
+	}
+	if !a.Date.IsZero() {
+		date := a.Date
+		bla.Date = &date
+	}
+	bla.Duration = a.Duration
 
 	return json.Marshal(bla)
 }
@@ -110,48 +158,100 @@ func (a *Analysis) UnmarshalJSON(bytes []byte) error {
 	if err := json.Unmarshal(bytes, &bla); err != nil {
 		return err
 	}
-	for _, u := range bla.Unmanaged {
-		a.AddUnmanaged(&resource.Resource{
-			Id:   u.Id,
-			Type: u.Type,
-		})
-	}
-	for _, d := range bla.Deleted {
-		a.AddDeleted(&resource.Resource{
-			Id:   d.Id,
-			Type: d.Type,
-		})
+	// Results written before the format was versioned don't carry any version
+	if bla.SchemaVersion > SchemaVersion {
+		return fmt.Errorf("unsupported scan result version %d, latest supported version is %d", bla.SchemaVersion, SchemaVersion)
 	}
 	for _, m := range bla.Managed {
-		a.AddManaged(&resource.Resource{
-			Id:   m.Id,
-			Type: m.Type,
-		})
-	}
-	for _, di := range bla.Differences {
-		a.AddDifference(Difference{
-			Res: &resource.Resource{
-				Id:   di.Res.Id,
-				Type: di.Res.Type,
-			},
-			Changelog: di.Changelog,
-		})
+		a.AddManaged(m.Resource())
 	}
+	drifts := a.readSerializableDrifts(serializableDrifts{
+		Unmanaged:   bla.Unmanaged,
+		Deleted:     bla.Deleted,
+		Differences: bla.Differences,
+	})
+	a.AddUnmanaged(drifts.Unmanaged...)
+	a.AddDeleted(drifts.Deleted...)
+	a.AddDifference(drifts.Differences...)
 	if len(bla.Alerts) > 0 {
 		a.alerts = make(alerter.Alerts)
 		for k, v := range bla.Alerts {
 			for _, al := range v {
-				a.alerts[k] = append(a.alerts[k], &alerter.SerializedAlert{
-					Msg: al.Message(),
-				})
+				a.alerts[k] = append(a.alerts[k], al.Alert)
 			}
 		}
 	}
 	a.ProviderName = bla.ProviderName
 	a.ProviderVersion = bla.ProviderVersion
+	if bla.Baseline != nil {
+		a.baseline = &Baseline{
+			New:        a.readSerializableDrifts(bla.Baseline.New),
+			Persisting: a.readSerializableDrifts(bla.Baseline.Persisting),
+			Resolved:   a.readSerializableDrifts(bla.Baseline.Resolved),
+		}
+	}
+	if bla.Date != nil {
+		a.Date = *bla.Date
+	}
+	a.Duration = bla.Duration
 	return nil
 }
 
+func newSerializableResource(res *resource.Resource, attributes bool) resource.SerializableResource {
+	if attributes {
+		return *resource.NewSerializableResourceWithAttributes(res)
+	}
+	return *resource.NewSerializableResource(res)
+}
+
+func (a Analysis) newSerializableDrifts(drifts Drifts, attributes bool) serializableDrifts {
+	result := serializableDrifts{}
+	for _, u := range drifts.Unmanaged {
+		result.Unmanaged = append(result.Unmanaged, serializableDrift{
+			SerializableResource: newSerializableResource(u, attributes),
+			Severity:             a.Severity(u),
+		})
+	}
+	for _, d := range drifts.Deleted {
+		result.Deleted = append(result.Deleted, serializableDrift{
+			SerializableResource: newSerializableResource(d, attributes),
+			Severity:             a.Severity(d),
+		})
+	}
+	for _, di := range drifts.Differences {
+		result.Differences = append(result.Differences, serializableDifference{
+			Res:       newSerializableResource(di.Res, attributes),
+			Changelog: di.Changelog,
+			Severity:  a.Severity(di.Res),
+		})
+	}
+	return result
+}
+
+// readSerializableDrifts returns the drifts of a serialized analysis, keeping track of their severity
+func (a *Analysis) readSerializableDrifts(s serializableDrifts) Drifts {
+	result := Drifts{}
+	for _, u := range s.Unmanaged {
+		res := u.Resource()
+		a.SetSeverity(res, u.Severity)
+		result.Unmanaged = append(result.Unmanaged, res)
+	}
+	for _, d := range s.Deleted {
+		res := d.Resource()
+		a.SetSeverity(res, d.Severity)
+		result.Deleted = append(result.Deleted, res)
+	}
+	for _, di := range s.Differences {
+		res := di.Res.Resource()
+		a.SetSeverity(res, di.Severity)
+		result.Differences = append(result.Differences, Difference{
+			Res:       res,
+			Changelog: di.Changelog,
+		})
+	}
+	return result
+}
+
 func (a *Analysis) IsSync() bool {
 	return a.summary.TotalDrifted == 0 && a.summary.TotalUnmanaged == 0 && a.summary.TotalDeleted == 0
 }
@@ -210,6 +310,127 @@ func (a *Analysis) Summary() Summary {
 	return a.summary
 }
 
+// Drifts returns every unmanaged, missing and changed resource of the analysis
+func (a *Analysis) Drifts() Drifts {
+	return Drifts{
+		Unmanaged:   a.unmanaged,
+		Deleted:     a.deleted,
+		Differences: a.differences,
+	}
+}
+
+// SetSeverity sets the severity of the drift of a resource, unset severities are ignored
+func (a *Analysis) SetSeverity(res *resource.Resource, severity Severity) {
+	if severity == 0 {
+		return
+	}
+	if a.severities == nil {
+		a.severities = make(map[string]Severity)
+	}
+	a.severities[severityKey(res)] = severity
+}
+
+// Severity returns the severity of the drift of a resource, zero when no severity policy was applied
+func (a *Analysis) Severity(res *resource.Resource) Severity {
+	return a.severities[severityKey(res)]
+}
+
+// HighestSeverity returns the highest severity of the given drifts, drifts without severity being info.
+// It returns zero when there is no drift.
+func (a *Analysis) HighestSeverity(drifts Drifts) Severity {
+	var highest Severity
+	update := func(res *resource.Resource) {
+		severity := a.Severity(res)
+		if severity == 0 {
+			severity = SeverityInfo
+		}
+		if severity > highest {
+			highest = severity
+		}
+	}
+	for _, res := range drifts.Unmanaged {
+		update(res)
+	}
+	for _, res := range drifts.Deleted {
+		update(res)
+	}
+	for _, difference := range drifts.Differences {
+		update(difference.Res)
+	}
+	return highest
+}
+
+// AccountSummaries returns a summary for each scanned account.
+// It is empty when resources are not bound to any account, i.e. when a single account is scanned.
+// Missing resources without ARN are never bound to an account, so they are not part of any summary.
+func (a *Analysis) AccountSummaries() map[string]Summary {
+	return a.summariesBy(func(res *resource.Resource) string {
+		return res.Account
+	})
+}
+
+// TypeSummaries returns a summary for each resource type found during the scan
+func (a *Analysis) TypeSummaries() map[string]Summary {
+	return a.summariesBy(func(res *resource.Resource) string {
+		return res.ResourceType()
+	})
+}
+
+// summariesBy groups resources with the given key function and computes a summary for each group.
+// Resources with an empty key are ignored.
+func (a *Analysis) summariesBy(key func(res *resource.Resource) string) map[string]Summary {
+	summaries := make(map[string]Summary)
+
+	update := func(res *resource.Resource, fn func(summary *Summary)) {
+		k := key(res)
+		if k == "" {
+			return
+		}
+		summary := summaries[k]
+		fn(&summary)
+		summaries[k] = summary
+	}
+
+	for _, res := range a.managed {
+		update(res, func(summary *Summary) {
+			summary.TotalResources++
+			summary.TotalManaged++
+		})
+	}
+	for _, res := range a.unmanaged {
+		update(res, func(summary *Summary) {
+			summary.TotalResources++
+			summary.TotalUnmanaged++
+		})
+	}
+	for _, res := range a.deleted {
+		update(res, func(summary *Summary) {
+			summary.TotalResources++
+			summary.TotalDeleted++
+		})
+	}
+	for _, difference := range a.differences {
+		update(difference.Res, func(summary *Summary) {
+			summary.TotalDrifted++
+		})
+	}
+
+	if len(summaries) == 0 {
+		return nil
+	}
+	return summaries
+}
+
+// SetBaseline compares the drift of this analysis with the given previous analysis
+func (a *Analysis) SetBaseline(previous *Analysis) {
+	a.baseline = NewBaseline(previous, a)
+}
+
+// Baseline returns the comparison with a previous analysis, or nil when no baseline was set
+func (a *Analysis) Baseline() *Baseline {
+	return a.baseline
+}
+
 func (a *Analysis) Alerts() alerter.Alerts {
 	return a.alerts
 }
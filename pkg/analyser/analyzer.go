@@ -52,6 +52,17 @@ func NewAnalyzer(alerter *alerter.Alerter, options AnalyzerOptions, filter filte
 }
 
 func (a Analyzer) Analyze(remoteResources, resourcesFromState []*resource.Resource) (Analysis, error) {
+	analysis, err := a.AnalyzePartial(remoteResources, resourcesFromState)
+	if err != nil {
+		return analysis, err
+	}
+	a.Complete(&analysis)
+	return analysis, nil
+}
+
+// AnalyzePartial analyzes a subset of the resources, like the ones of a group of types.
+// Alerts are not set on partial analyses, Complete sets them once every resource was analyzed.
+func (a Analyzer) AnalyzePartial(remoteResources, resourcesFromState []*resource.Resource) (Analysis, error) {
 	analysis := Analysis{}
 
 	// Iterate on remote resources and filter ignored resources
@@ -63,21 +74,20 @@ func (a Analyzer) Analyze(remoteResources, resourcesFromState []*resource.Resour
 		filteredRemoteResource = append(filteredRemoteResource, remoteRes)
 	}
 
-	haveComputedDiff := false
+	index := newResourceIndex(filteredRemoteResource)
 	for _, stateRes := range resourcesFromState {
-		i, remoteRes, found := findCorrespondingRes(filteredRemoteResource, stateRes)
-
 		if a.filter.IsResourceIgnored(stateRes) || a.alerter.IsResourceIgnored(stateRes) {
 			continue
 		}
 
-		if !found {
+		// Remove managed resources from the index, so it will remain only unmanaged ones
+		remoteRes := index.pop(stateRes, isSameResource)
+		if remoteRes == nil {
 			analysis.AddDeleted(stateRes)
 			continue
 		}
 
-		// Remove managed resources, so it will remain only unmanaged ones
-		filteredRemoteResource = removeResourceByIndex(i, filteredRemoteResource)
+		adoptLocation(stateRes, remoteRes)
 		analysis.AddManaged(stateRes)
 
 		// Stop there if we are not in deep mode, we do not want to compute diffs
@@ -90,6 +100,12 @@ func (a Analyzer) Analyze(remoteResources, resourcesFromState []*resource.Resour
 			continue
 		}
 
+		// CloudFormation and Pulumi stacks only tell which resources they manage, there are no attributes to compare
+		switch stateRes.Source.(type) {
+		case *resource.CloudformationSource, *resource.PulumiSource:
+			continue
+		}
+
 		var delta diff.Changelog
 		delta, _ = diff.Diff(stateRes.Attributes(), remoteRes.Attributes())
 
@@ -99,7 +115,7 @@ func (a Analyzer) Analyze(remoteResources, resourcesFromState []*resource.Resour
 
 		changelog := make([]Change, 0, len(delta))
 		for _, change := range delta {
-			if a.filter.IsFieldIgnored(stateRes, change.Path) {
+			if a.filter.IsFieldIgnored(stateRes, change.Path) || a.filter.IsChangeIgnored(stateRes, change) {
 				continue
 			}
 			c := Change{Change: change}
@@ -108,9 +124,6 @@ func (a Analyzer) Analyze(remoteResources, resourcesFromState []*resource.Resour
 				c.Computed = resSchema.IsComputedField(c.Path)
 				c.JsonString = resSchema.IsJsonStringField(c.Path)
 			}
-			if c.Computed {
-				haveComputedDiff = true
-			}
 			changelog = append(changelog, c)
 		}
 		if len(changelog) > 0 {
@@ -121,40 +134,55 @@ func (a Analyzer) Analyze(remoteResources, resourcesFromState []*resource.Resour
 		}
 	}
 
-	if a.hasUnmanagedSecurityGroupRules(filteredRemoteResource) {
+	unmanagedResources := index.remaining(filteredRemoteResource)
+	if a.hasUnmanagedSecurityGroupRules(unmanagedResources) {
 		a.alerter.SendAlert("", newUnmanagedSecurityGroupRulesAlert())
 	}
 
-	if haveComputedDiff {
-		a.alerter.SendAlert("", NewComputedDiffAlert())
-	}
-
 	// Add remaining unmanaged resources
-	analysis.AddUnmanaged(filteredRemoteResource...)
+	analysis.AddUnmanaged(unmanagedResources...)
 
 	// Sort resources by Terraform Id
 	// The purpose is to have a predictable output
 	analysis.SortResources()
 
-	analysis.SetAlerts(a.alerter.Retrieve())
-
 	return analysis, nil
 }
 
-func findCorrespondingRes(resources []*resource.Resource, res *resource.Resource) (int, *resource.Resource, bool) {
-	for i, r := range resources {
-		if res.Equal(r) {
-			return i, r, true
+// Complete sets the alerts raised during the scan on the analysis, no resource can be analyzed afterwards
+func (a Analyzer) Complete(analysis *Analysis) {
+	if hasComputedDiff(analysis.Differences()) {
+		a.alerter.SendAlert("", NewComputedDiffAlert())
+	}
+	analysis.SetAlerts(a.alerter.Retrieve())
+}
+
+func hasComputedDiff(differences []Difference) bool {
+	for _, difference := range differences {
+		for _, change := range difference.Changelog {
+			if change.Computed {
+				return true
+			}
 		}
 	}
-	return -1, nil, false
+	return false
 }
 
-func removeResourceByIndex(i int, resources []*resource.Resource) []*resource.Resource {
-	if i == len(resources)-1 {
-		return resources[:len(resources)-1]
+// isSameResource matches a state resource with a remote one, resources sharing a type and id
+// being told apart by their regions, accounts and the discriminant function of their schema
+func isSameResource(remoteRes, stateRes *resource.Resource) bool {
+	return stateRes.Equal(remoteRes)
+}
+
+// adoptLocation copies the location of the remote resource on the matching state resource,
+// as states only know it for resources having an ARN
+func adoptLocation(stateRes, remoteRes *resource.Resource) {
+	if remoteRes.Region != "" {
+		stateRes.Region = remoteRes.Region
+	}
+	if remoteRes.Account != "" {
+		stateRes.Account = remoteRes.Account
 	}
-	return append(resources[:i], resources[i+1:]...)
 }
 
 // hasUnmanagedSecurityGroupRules returns true if we find at least one unmanaged
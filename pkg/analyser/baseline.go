@@ -0,0 +1,117 @@
+package analyser
+
+import (
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+type BaselineStatus string
+
+const (
+	BaselineStatusNew        BaselineStatus = "new"
+	BaselineStatusPersisting BaselineStatus = "persisting"
+	BaselineStatusResolved   BaselineStatus = "resolved"
+)
+
+// Drifts groups the unmanaged, missing and changed resources of a scan
+type Drifts struct {
+	Unmanaged   []*resource.Resource
+	Deleted     []*resource.Resource
+	Differences []Difference
+}
+
+func (d Drifts) Count() int {
+	return len(d.Unmanaged) + len(d.Deleted) + len(d.Differences)
+}
+
+// Baseline classifies the drift of a scan against the result of a previous scan
+type Baseline struct {
+	New        Drifts
+	Persisting Drifts
+	Resolved   Drifts
+
+	persisting map[*resource.Resource]struct{}
+}
+
+// NewBaseline compares the drift of the current analysis with the drift of the previous one.
+// Drift found in both analyses is persisting, drift only found in the current analysis is new
+// and drift only found in the previous analysis is resolved.
+func NewBaseline(previous, current *Analysis) *Baseline {
+	baseline := &Baseline{}
+
+	previousUnmanaged := newResourceIndex(previous.Unmanaged())
+	for _, res := range current.Unmanaged() {
+		if previousUnmanaged.pop(res, sameLocation) != nil {
+			baseline.Persisting.Unmanaged = append(baseline.Persisting.Unmanaged, res)
+			continue
+		}
+		baseline.New.Unmanaged = append(baseline.New.Unmanaged, res)
+	}
+	baseline.Resolved.Unmanaged = previousUnmanaged.remaining(previous.Unmanaged())
+
+	previousDeleted := newResourceIndex(previous.Deleted())
+	for _, res := range current.Deleted() {
+		if previousDeleted.pop(res, sameLocation) != nil {
+			baseline.Persisting.Deleted = append(baseline.Persisting.Deleted, res)
+			continue
+		}
+		baseline.New.Deleted = append(baseline.New.Deleted, res)
+	}
+	baseline.Resolved.Deleted = previousDeleted.remaining(previous.Deleted())
+
+	previousChanged := make([]*resource.Resource, 0, len(previous.Differences()))
+	for _, difference := range previous.Differences() {
+		previousChanged = append(previousChanged, difference.Res)
+	}
+	previousDifferences := newResourceIndex(previousChanged)
+	for _, difference := range current.Differences() {
+		if previousDifferences.pop(difference.Res, sameLocation) != nil {
+			baseline.Persisting.Differences = append(baseline.Persisting.Differences, difference)
+			continue
+		}
+		baseline.New.Differences = append(baseline.New.Differences, difference)
+	}
+	for _, difference := range previous.Differences() {
+		if previousDifferences.contains(difference.Res) {
+			baseline.Resolved.Differences = append(baseline.Resolved.Differences, difference)
+		}
+	}
+
+	return baseline
+}
+
+// HasNewDrift returns true when drift not found in the previous scan was detected
+func (b *Baseline) HasNewDrift() bool {
+	return b.New.Count() > 0
+}
+
+// Status returns the baseline status of a drifted resource of the current scan
+func (b *Baseline) Status(res *resource.Resource) BaselineStatus {
+	if b.persisting == nil {
+		b.persisting = make(map[*resource.Resource]struct{}, b.Persisting.Count())
+		for _, r := range b.Persisting.Unmanaged {
+			b.persisting[r] = struct{}{}
+		}
+		for _, r := range b.Persisting.Deleted {
+			b.persisting[r] = struct{}{}
+		}
+		for _, d := range b.Persisting.Differences {
+			b.persisting[d.Res] = struct{}{}
+		}
+	}
+	if _, exist := b.persisting[res]; exist {
+		return BaselineStatusPersisting
+	}
+	return BaselineStatusNew
+}
+
+// sameLocation matches resources across scans. Resources coming from a previous scan result
+// don't carry attributes, so we can't rely on Resource.Equal.
+func sameLocation(a, b *resource.Resource) bool {
+	if a.Region != "" && b.Region != "" && a.Region != b.Region {
+		return false
+	}
+	if a.Account != "" && b.Account != "" && a.Account != b.Account {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,153 @@
+package analyser
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+func TestNewBaseline(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous func() *Analysis
+		current  func() *Analysis
+		expected *Baseline
+	}{
+		{
+			name:     "no drift",
+			previous: func() *Analysis { return &Analysis{} },
+			current:  func() *Analysis { return &Analysis{} },
+			expected: &Baseline{},
+		},
+		{
+			name:     "everything is new without previous drift",
+			previous: func() *Analysis { return &Analysis{} },
+			current: func() *Analysis {
+				a := &Analysis{}
+				a.AddUnmanaged(&resource.Resource{Id: "bucket", Type: "aws_s3_bucket"})
+				a.AddDeleted(&resource.Resource{Id: "user", Type: "aws_iam_user"})
+				a.AddDifference(Difference{Res: &resource.Resource{Id: "queue", Type: "aws_sqs_queue"}})
+				return a
+			},
+			expected: &Baseline{
+				New: Drifts{
+					Unmanaged:   []*resource.Resource{{Id: "bucket", Type: "aws_s3_bucket"}},
+					Deleted:     []*resource.Resource{{Id: "user", Type: "aws_iam_user"}},
+					Differences: []Difference{{Res: &resource.Resource{Id: "queue", Type: "aws_sqs_queue"}}},
+				},
+			},
+		},
+		{
+			name: "new, persisting and resolved drift",
+			previous: func() *Analysis {
+				a := &Analysis{}
+				a.AddUnmanaged(
+					&resource.Resource{Id: "bucket", Type: "aws_s3_bucket"},
+					&resource.Resource{Id: "old-bucket", Type: "aws_s3_bucket"},
+				)
+				a.AddDeleted(&resource.Resource{Id: "user", Type: "aws_iam_user"})
+				a.AddDifference(Difference{Res: &resource.Resource{Id: "topic", Type: "aws_sns_topic"}})
+				return a
+			},
+			current: func() *Analysis {
+				a := &Analysis{}
+				a.AddUnmanaged(
+					&resource.Resource{Id: "bucket", Type: "aws_s3_bucket"},
+					&resource.Resource{Id: "new-bucket", Type: "aws_s3_bucket"},
+				)
+				a.AddDeleted(&resource.Resource{Id: "user", Type: "aws_iam_user"})
+				a.AddDifference(Difference{Res: &resource.Resource{Id: "queue", Type: "aws_sqs_queue"}})
+				return a
+			},
+			expected: &Baseline{
+				New: Drifts{
+					Unmanaged:   []*resource.Resource{{Id: "new-bucket", Type: "aws_s3_bucket"}},
+					Differences: []Difference{{Res: &resource.Resource{Id: "queue", Type: "aws_sqs_queue"}}},
+				},
+				Persisting: Drifts{
+					Unmanaged: []*resource.Resource{{Id: "bucket", Type: "aws_s3_bucket"}},
+					Deleted:   []*resource.Resource{{Id: "user", Type: "aws_iam_user"}},
+				},
+				Resolved: Drifts{
+					Unmanaged:   []*resource.Resource{{Id: "old-bucket", Type: "aws_s3_bucket"}},
+					Differences: []Difference{{Res: &resource.Resource{Id: "topic", Type: "aws_sns_topic"}}},
+				},
+			},
+		},
+		{
+			name: "resources in different regions are not the same drift",
+			previous: func() *Analysis {
+				a := &Analysis{}
+				a.AddUnmanaged(&resource.Resource{Id: "queue", Type: "aws_sqs_queue", Region: "us-east-1"})
+				return a
+			},
+			current: func() *Analysis {
+				a := &Analysis{}
+				a.AddUnmanaged(&resource.Resource{Id: "queue", Type: "aws_sqs_queue", Region: "eu-west-3"})
+				return a
+			},
+			expected: &Baseline{
+				New: Drifts{
+					Unmanaged: []*resource.Resource{{Id: "queue", Type: "aws_sqs_queue", Region: "eu-west-3"}},
+				},
+				Resolved: Drifts{
+					Unmanaged: []*resource.Resource{{Id: "queue", Type: "aws_sqs_queue", Region: "us-east-1"}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewBaseline(tt.previous(), tt.current())
+			assert.Equal(t, tt.expected, got)
+			assert.Equal(t, tt.expected.New.Count() > 0, got.HasNewDrift())
+		})
+	}
+}
+
+func TestBaseline_Status(t *testing.T) {
+	persisting := &resource.Resource{Id: "bucket", Type: "aws_s3_bucket"}
+	added := &resource.Resource{Id: "new-bucket", Type: "aws_s3_bucket"}
+
+	previous := &Analysis{}
+	previous.AddUnmanaged(&resource.Resource{Id: "bucket", Type: "aws_s3_bucket"})
+
+	current := &Analysis{}
+	current.AddUnmanaged(persisting, added)
+	current.SetBaseline(previous)
+
+	assert.Equal(t, BaselineStatusPersisting, current.Baseline().Status(persisting))
+	assert.Equal(t, BaselineStatusNew, current.Baseline().Status(added))
+}
+
+func TestAnalysis_MarshalJSONWithBaseline(t *testing.T) {
+	previous := &Analysis{}
+	previous.AddUnmanaged(
+		&resource.Resource{Id: "bucket", Type: "aws_s3_bucket"},
+		&resource.Resource{Id: "old-bucket", Type: "aws_s3_bucket"},
+	)
+
+	analysis := &Analysis{}
+	analysis.AddUnmanaged(
+		&resource.Resource{Id: "bucket", Type: "aws_s3_bucket"},
+		&resource.Resource{Id: "new-bucket", Type: "aws_s3_bucket"},
+	)
+	analysis.SetBaseline(previous)
+
+	input, err := json.Marshal(analysis)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := &Analysis{}
+	if err := json.Unmarshal(input, got); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, analysis.Baseline().New, got.Baseline().New)
+	assert.Equal(t, analysis.Baseline().Persisting, got.Baseline().Persisting)
+	assert.Equal(t, analysis.Baseline().Resolved, got.Baseline().Resolved)
+}
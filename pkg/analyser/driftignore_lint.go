@@ -0,0 +1,79 @@
+package analyser
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudskiff/driftctl/pkg/filter"
+)
+
+// Kinds of issues found in driftignore rules
+const (
+	DriftIgnoreIssueMalformed = "malformed"
+	DriftIgnoreIssueExpired   = "expired"
+	DriftIgnoreIssueDuplicate = "duplicate"
+	DriftIgnoreIssueUnused    = "unused"
+)
+
+type DriftIgnoreIssue struct {
+	Rule    *filter.DriftIgnoreRule
+	Kind    string
+	Message string
+}
+
+// LintDriftIgnore reports malformed, expired and duplicate rules, and rules matching none of the drifts of the analysis.
+// The analysis must come from a scan that did not use these rules, otherwise the ignored drifts are not part of it.
+func LintDriftIgnore(rules []*filter.DriftIgnoreRule, analysis *Analysis, now time.Time) []DriftIgnoreIssue {
+	var issues []DriftIgnoreIssue
+	firstLines := make(map[string]int, len(rules))
+
+	for _, rule := range rules {
+		if rule.Err != nil {
+			issues = append(issues, DriftIgnoreIssue{rule, DriftIgnoreIssueMalformed, rule.Err.Error()})
+			continue
+		}
+
+		key := strings.TrimSpace(rule.Text)
+		if line, exist := firstLines[key]; exist {
+			issues = append(issues, DriftIgnoreIssue{rule, DriftIgnoreIssueDuplicate, fmt.Sprintf("rule '%s' duplicates line %d", rule.Text, line)})
+			continue
+		}
+		firstLines[key] = rule.Line
+
+		if rule.Expired(now) {
+			issues = append(issues, DriftIgnoreIssue{rule, DriftIgnoreIssueExpired, fmt.Sprintf("rule %s expired on %s", rule.Description(), rule.Expires.Format(filter.RuleExpiryLayout))})
+			continue
+		}
+
+		// Negated rules include back resources, they never ignore anything by themselves
+		if !rule.IsNegated() && !matchesAnyDrift(rule, analysis) {
+			issues = append(issues, DriftIgnoreIssue{rule, DriftIgnoreIssueUnused, fmt.Sprintf("rule %s does not match any drift", rule.Description())})
+		}
+	}
+	return issues
+}
+
+func matchesAnyDrift(rule *filter.DriftIgnoreRule, analysis *Analysis) bool {
+	for _, res := range analysis.Unmanaged() {
+		if rule.MatchesResource(res) {
+			return true
+		}
+	}
+	for _, res := range analysis.Deleted() {
+		if rule.MatchesResource(res) {
+			return true
+		}
+	}
+	for _, difference := range analysis.Differences() {
+		if rule.MatchesResource(difference.Res) {
+			return true
+		}
+		for _, change := range difference.Changelog {
+			if rule.MatchesChange(difference.Res, change.Change) {
+				return true
+			}
+		}
+	}
+	return false
+}
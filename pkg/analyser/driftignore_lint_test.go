@@ -0,0 +1,47 @@
+package analyser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/r3labs/diff/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+func TestLintDriftIgnore(t *testing.T) {
+	rules, err := filter.ReadDriftIgnoreRules("testdata/driftignore_lint/.driftignore")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bucket := &resource.Resource{Id: "bucket", Type: "aws_s3_bucket"}
+	analysis := &Analysis{}
+	analysis.AddUnmanaged(&resource.Resource{Id: "driftctl", Type: "aws_iam_user"})
+	analysis.AddDeleted(&resource.Resource{Id: "queue", Type: "aws_sqs_queue"})
+	analysis.AddDifference(Difference{Res: bucket, Changelog: Changelog{
+		{Change: diff.Change{Type: diff.UPDATE, Path: []string{"tags", "owner"}, From: "john", To: "team-net"}},
+	}})
+
+	got := LintDriftIgnore(rules, analysis, time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	type issue struct {
+		line    int
+		kind    string
+		message string
+	}
+	expected := []issue{
+		{2, DriftIgnoreIssueExpired, "rule 'aws_s3_bucket.expired' (owner=team-net) expired on 2020-01-01"},
+		{3, DriftIgnoreIssueDuplicate, "rule 'aws_iam_user.driftctl' duplicates line 1"},
+		{4, DriftIgnoreIssueUnused, "rule 'aws_s3_bucket.unknown' does not match any drift"},
+		{5, DriftIgnoreIssueUnused, "rule 'aws_s3_bucket.*.tags.env regex(^dev)' (owner=team-web) does not match any drift"},
+		{8, DriftIgnoreIssueMalformed, "invalid expiry date 'soon', expected YYYY-MM-DD"},
+	}
+	var issues []issue
+	for _, i := range got {
+		issues = append(issues, issue{i.Rule.Line, i.Kind, i.Message})
+	}
+	assert.Equal(t, expected, issues)
+}
@@ -0,0 +1,51 @@
+package analyser
+
+import (
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+// resourceIndex indexes resources by type and id, so finding the one corresponding to another resource
+// does not require scanning every resource
+type resourceIndex map[string][]*resource.Resource
+
+func newResourceIndex(resources []*resource.Resource) resourceIndex {
+	index := make(resourceIndex, len(resources))
+	for _, res := range resources {
+		key := res.ResourceType() + "." + res.ResourceId()
+		index[key] = append(index[key], res)
+	}
+	return index
+}
+
+// pop removes and returns the first indexed resource with the same type and id as the given one
+// for which match returns true, or nil if there is none
+func (i resourceIndex) pop(res *resource.Resource, match func(candidate, res *resource.Resource) bool) *resource.Resource {
+	key := res.ResourceType() + "." + res.ResourceId()
+	for n, candidate := range i[key] {
+		if match(candidate, res) {
+			i[key] = append(i[key][:n], i[key][n+1:]...)
+			return candidate
+		}
+	}
+	return nil
+}
+
+func (i resourceIndex) contains(res *resource.Resource) bool {
+	for _, candidate := range i[res.ResourceType()+"."+res.ResourceId()] {
+		if candidate == res {
+			return true
+		}
+	}
+	return false
+}
+
+// remaining returns the given resources that were not popped from the index, preserving their order
+func (i resourceIndex) remaining(resources []*resource.Resource) []*resource.Resource {
+	var results []*resource.Resource
+	for _, res := range resources {
+		if i.contains(res) {
+			results = append(results, res)
+		}
+	}
+	return results
+}
@@ -0,0 +1,159 @@
+package analyser
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/cloudskiff/driftctl/pkg/alerter"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// jsonSchemaOverrides maps go types that implement their own JSON marshalling to the type they are serialized as
+var jsonSchemaOverrides = map[reflect.Type]reflect.Type{
+	reflect.TypeOf(alerter.SerializableAlert{}): reflect.TypeOf(alerter.SerializedAlert{}),
+}
+
+type jsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Type                 interface{}            `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Const                interface{}            `json:"const,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties interface{}            `json:"additionalProperties,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	Defs                 map[string]*jsonSchema `json:"$defs,omitempty"`
+}
+
+// JSONSchema returns the JSON Schema document describing the JSON representation of an analysis.
+// It is generated from the go types used to serialize an analysis, so it can't get out of sync with the output.
+func JSONSchema() ([]byte, error) {
+	defs := make(map[string]*jsonSchema)
+	root := buildJSONSchema(reflect.TypeOf(serializableAnalysis{}), defs)
+	root.Schema = jsonSchemaDraft
+	root.Title = "driftctl scan result"
+	root.Description = fmt.Sprintf("Scan result written by driftctl JSON output, version %d", SchemaVersion)
+	root.Properties["schema_version"].Const = SchemaVersion
+	root.Defs = defs
+
+	return json.MarshalIndent(root, "", "  ")
+}
+
+func buildJSONSchema(t reflect.Type, defs map[string]*jsonSchema) *jsonSchema {
+	if override, exist := jsonSchemaOverrides[t]; exist {
+		t = override
+	}
+
+	switch t {
+	case reflect.TypeOf(time.Time{}):
+		return &jsonSchema{Type: "string", Format: "date-time"}
+	case reflect.TypeOf(time.Duration(0)):
+		return &jsonSchema{Type: "integer", Description: "Duration in nanoseconds"}
+	case reflect.TypeOf(Severity(0)):
+		return &jsonSchema{Type: "string", Enum: SupportedSeverities()}
+	case reflect.TypeOf(resource.SourceKind("")):
+		kinds := make([]string, 0, len(resource.SourceKinds))
+		for _, kind := range resource.SourceKinds {
+			kinds = append(kinds, string(kind))
+		}
+		return &jsonSchema{Type: "string", Enum: kinds}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return buildJSONSchema(t.Elem(), defs)
+	case reflect.String:
+		return &jsonSchema{Type: "string"}
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &jsonSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &jsonSchema{Type: "number"}
+	// nil slices and maps are serialized as null
+	case reflect.Slice, reflect.Array:
+		return &jsonSchema{Type: []string{"array", "null"}, Items: buildJSONSchema(t.Elem(), defs)}
+	case reflect.Map:
+		return &jsonSchema{Type: []string{"object", "null"}, AdditionalProperties: buildJSONSchema(t.Elem(), defs)}
+	case reflect.Interface:
+		// Any JSON value
+		return &jsonSchema{}
+	case reflect.Struct:
+		// The root document is inlined, other structs are referenced
+		if t == reflect.TypeOf(serializableAnalysis{}) {
+			return buildJSONSchemaObject(t, defs)
+		}
+		name := jsonSchemaDefName(t)
+		if _, exist := defs[name]; !exist {
+			// Register the definition before building it to support recursive types
+			defs[name] = &jsonSchema{}
+			*defs[name] = *buildJSONSchemaObject(t, defs)
+		}
+		return &jsonSchema{Ref: "#/$defs/" + name}
+	}
+
+	panic(fmt.Sprintf("unsupported type %s in JSON schema", t))
+}
+
+func buildJSONSchemaObject(t reflect.Type, defs map[string]*jsonSchema) *jsonSchema {
+	schema := &jsonSchema{
+		Type:                 "object",
+		Properties:           make(map[string]*jsonSchema),
+		AdditionalProperties: false,
+	}
+	addJSONSchemaProperties(schema, t, defs)
+	return schema
+}
+
+func addJSONSchemaProperties(schema *jsonSchema, t reflect.Type, defs map[string]*jsonSchema) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts := tag, ""
+		if i := strings.Index(tag, ","); i >= 0 {
+			name, opts = tag[:i], tag[i+1:]
+		}
+
+		// Fields of embedded structs are promoted to the parent object
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			addJSONSchemaProperties(schema, embedded, defs)
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema.Properties[name] = buildJSONSchema(field.Type, defs)
+		if !strings.Contains(opts, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+func jsonSchemaDefName(t reflect.Type) string {
+	name := t.Name()
+	for _, prefix := range []string{"serializable", "Serializable", "Serialized"} {
+		name = strings.TrimPrefix(name, prefix)
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
@@ -0,0 +1,78 @@
+package analyser
+
+import (
+	"strings"
+
+	"github.com/cloudskiff/driftctl/pkg/alerter"
+)
+
+// Merge combines the results of several scans into a single analysis.
+// Summary and coverage are computed again from the merged resources.
+func Merge(analyses ...*Analysis) *Analysis {
+	result := &Analysis{}
+
+	var providerNames, providerVersions []string
+	for _, analysis := range analyses {
+		result.AddManaged(analysis.Managed()...)
+		result.AddUnmanaged(analysis.Unmanaged()...)
+		result.AddDeleted(analysis.Deleted()...)
+		result.AddDifference(analysis.Differences()...)
+
+		for key, severity := range analysis.severities {
+			if result.severities == nil {
+				result.severities = make(map[string]Severity)
+			}
+			result.severities[key] = severity
+		}
+
+		for key, alerts := range analysis.Alerts() {
+			if result.alerts == nil {
+				result.alerts = make(alerter.Alerts)
+			}
+			result.alerts[key] = append(result.alerts[key], alerts...)
+		}
+
+		if baseline := analysis.Baseline(); baseline != nil {
+			if result.baseline == nil {
+				result.baseline = &Baseline{}
+			}
+			result.baseline.New = mergeDrifts(result.baseline.New, baseline.New)
+			result.baseline.Persisting = mergeDrifts(result.baseline.Persisting, baseline.Persisting)
+			result.baseline.Resolved = mergeDrifts(result.baseline.Resolved, baseline.Resolved)
+		}
+
+		if analysis.Date.After(result.Date) {
+			result.Date = analysis.Date
+		}
+		result.Duration += analysis.Duration
+
+		providerNames = appendDistinct(providerNames, analysis.ProviderName)
+		providerVersions = appendDistinct(providerVersions, analysis.ProviderVersion)
+	}
+
+	result.ProviderName = strings.Join(providerNames, ", ")
+	result.ProviderVersion = strings.Join(providerVersions, ", ")
+	result.SortResources()
+
+	return result
+}
+
+func mergeDrifts(a, b Drifts) Drifts {
+	return Drifts{
+		Unmanaged:   append(a.Unmanaged, b.Unmanaged...),
+		Deleted:     append(a.Deleted, b.Deleted...),
+		Differences: append(a.Differences, b.Differences...),
+	}
+}
+
+func appendDistinct(values []string, value string) []string {
+	if value == "" {
+		return values
+	}
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
@@ -0,0 +1,88 @@
+package analyser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudskiff/driftctl/pkg/alerter"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+func TestMerge(t *testing.T) {
+	aws := &Analysis{}
+	aws.AddManaged(
+		&resource.Resource{Id: "bucket", Type: "aws_s3_bucket"},
+		&resource.Resource{Id: "queue", Type: "aws_sqs_queue"},
+	)
+	aws.AddUnmanaged(&resource.Resource{Id: "user", Type: "aws_iam_user"})
+	aws.AddDifference(Difference{Res: &resource.Resource{Id: "queue", Type: "aws_sqs_queue"}})
+	aws.SetAlerts(alerter.Alerts{
+		"aws_iam_user": {&alerter.FakeAlert{Msg: "aws alert"}},
+	})
+	aws.Date = time.Date(2021, 6, 10, 0, 0, 0, 0, time.UTC)
+	aws.Duration = 30 * time.Second
+	aws.ProviderName = "AWS"
+	aws.ProviderVersion = "3.19.0"
+
+	github := &Analysis{}
+	github.AddManaged(&resource.Resource{Id: "repo", Type: "github_repository"})
+	github.AddDeleted(&resource.Resource{Id: "team", Type: "github_team"})
+	github.SetAlerts(alerter.Alerts{
+		"aws_iam_user": {&alerter.FakeAlert{Msg: "github alert"}},
+	})
+	github.Date = time.Date(2021, 6, 11, 0, 0, 0, 0, time.UTC)
+	github.Duration = 10 * time.Second
+	github.ProviderName = "Github"
+	github.ProviderVersion = "4.4.0"
+
+	got := Merge(aws, github)
+
+	assert.Equal(t, Summary{
+		TotalResources: 5,
+		TotalDrifted:   1,
+		TotalUnmanaged: 1,
+		TotalDeleted:   1,
+		TotalManaged:   3,
+	}, got.Summary())
+	assert.Equal(t, 60, got.Coverage())
+	assert.Len(t, got.Managed(), 3)
+	assert.Equal(t, aws.Unmanaged(), got.Unmanaged())
+	assert.Equal(t, github.Deleted(), got.Deleted())
+	assert.Equal(t, aws.Differences(), got.Differences())
+	assert.Equal(t, alerter.Alerts{
+		"aws_iam_user": {
+			&alerter.FakeAlert{Msg: "aws alert"},
+			&alerter.FakeAlert{Msg: "github alert"},
+		},
+	}, got.Alerts())
+	assert.Equal(t, github.Date, got.Date)
+	assert.Equal(t, 40*time.Second, got.Duration)
+	assert.Equal(t, "AWS, Github", got.ProviderName)
+	assert.Equal(t, "3.19.0, 4.4.0", got.ProviderVersion)
+	assert.Nil(t, got.Baseline())
+}
+
+func TestMerge_WithBaseline(t *testing.T) {
+	previous := &Analysis{}
+	previous.AddUnmanaged(&resource.Resource{Id: "old", Type: "aws_iam_user"})
+
+	first := &Analysis{}
+	first.AddUnmanaged(&resource.Resource{Id: "new", Type: "aws_iam_user"})
+	first.SetBaseline(previous)
+
+	second := &Analysis{}
+	second.AddDeleted(&resource.Resource{Id: "team", Type: "github_team"})
+
+	got := Merge(first, second)
+
+	assert.Equal(t, &Baseline{
+		New: Drifts{
+			Unmanaged: []*resource.Resource{{Id: "new", Type: "aws_iam_user"}},
+		},
+		Resolved: Drifts{
+			Unmanaged: []*resource.Resource{{Id: "old", Type: "aws_iam_user"}},
+		},
+	}, got.Baseline())
+}
@@ -0,0 +1,176 @@
+package analyser
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+// Severity ranks drifts. Drifts have no severity unless a severity policy is applied.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota + 1
+	SeverityLow
+	SeverityHigh
+	SeverityCritical
+)
+
+var severityNames = []string{"info", "low", "high", "critical"}
+
+// Kinds of drift severity rules apply to
+const (
+	DriftKindUnmanaged = "unmanaged"
+	DriftKindMissing   = "missing"
+	DriftKindChanged   = "changed"
+)
+
+func ParseSeverity(name string) (Severity, error) {
+	for i, n := range severityNames {
+		if n == strings.ToLower(name) {
+			return Severity(i + 1), nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported severity '%s', supported severities are: %s", name, strings.Join(severityNames, ","))
+}
+
+func SupportedSeverities() []string {
+	return severityNames
+}
+
+func (s Severity) String() string {
+	if s < SeverityInfo || s > SeverityCritical {
+		return ""
+	}
+	return severityNames[s-1]
+}
+
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *Severity) UnmarshalText(text []byte) error {
+	severity, err := ParseSeverity(string(text))
+	if err != nil {
+		return err
+	}
+	*s = severity
+	return nil
+}
+
+// SeverityPolicy assigns a severity to every drift, the highest severity of the matching rules
+type SeverityPolicy struct {
+	// Default is the severity of drifts not matching any rule, info when not set
+	Default Severity       `json:"default,omitempty"`
+	Rules   []SeverityRule `json:"rules"`
+}
+
+// SeverityRule matches drifts by resource type, kind of drift and changed attribute
+type SeverityRule struct {
+	// Type is a resource type pattern like aws_iam_*, any type matches when not set
+	Type string `json:"type,omitempty"`
+	// Kind is either unmanaged, missing or changed, any kind matches when not set
+	Kind string `json:"kind,omitempty"`
+	// Path is a dot separated attribute path like ingress.*.cidr_blocks, matching changes of the attribute
+	// and of its children. Rules with a path only match changed resources.
+	Path     string   `json:"path,omitempty"`
+	Severity Severity `json:"severity"`
+}
+
+// ReadSeverityPolicy reads a YAML, or JSON, severity policy file
+func ReadSeverityPolicy(file string) (*SeverityPolicy, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &SeverityPolicy{}
+	if err := yaml.Unmarshal(content, policy); err != nil {
+		return nil, err
+	}
+	for i, rule := range policy.Rules {
+		if rule.Severity == 0 {
+			return nil, fmt.Errorf("rule %d has no severity", i+1)
+		}
+		if rule.Kind != "" && rule.Kind != DriftKindUnmanaged && rule.Kind != DriftKindMissing && rule.Kind != DriftKindChanged {
+			return nil, fmt.Errorf("rule %d has an unsupported kind '%s', supported kinds are: %s,%s,%s", i+1, rule.Kind, DriftKindUnmanaged, DriftKindMissing, DriftKindChanged)
+		}
+		if _, err := path.Match(rule.Type, ""); err != nil {
+			return nil, fmt.Errorf("rule %d has an invalid type pattern '%s'", i+1, rule.Type)
+		}
+	}
+	return policy, nil
+}
+
+// Apply sets the severity of every drift of the analysis
+func (p *SeverityPolicy) Apply(analysis *Analysis) {
+	for _, res := range analysis.Unmanaged() {
+		analysis.SetSeverity(res, p.severity(DriftKindUnmanaged, res, nil))
+	}
+	for _, res := range analysis.Deleted() {
+		analysis.SetSeverity(res, p.severity(DriftKindMissing, res, nil))
+	}
+	for _, difference := range analysis.Differences() {
+		analysis.SetSeverity(difference.Res, p.severity(DriftKindChanged, difference.Res, difference.Changelog))
+	}
+}
+
+func (p *SeverityPolicy) severity(kind string, res *resource.Resource, changelog Changelog) Severity {
+	var severity Severity
+	for _, rule := range p.Rules {
+		if rule.Severity > severity && rule.matches(kind, res, changelog) {
+			severity = rule.Severity
+		}
+	}
+	if severity == 0 {
+		severity = p.Default
+	}
+	if severity == 0 {
+		severity = SeverityInfo
+	}
+	return severity
+}
+
+func (r SeverityRule) matches(kind string, res *resource.Resource, changelog Changelog) bool {
+	if r.Kind != "" && r.Kind != kind {
+		return false
+	}
+	if r.Type != "" {
+		if match, _ := path.Match(r.Type, res.ResourceType()); !match {
+			return false
+		}
+	}
+	if r.Path == "" {
+		return true
+	}
+
+	pattern := strings.Split(r.Path, ".")
+	for _, change := range changelog {
+		if matchesAttributePath(pattern, change.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAttributePath(pattern, path []string) bool {
+	if len(path) < len(pattern) {
+		return false
+	}
+	for i, key := range pattern {
+		if key != "*" && key != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// severityKey identifies the drift of a resource, a resource only drifting once per scan
+func severityKey(res *resource.Resource) string {
+	return strings.Join([]string{res.ResourceType(), res.ResourceId(), res.Region, res.Account}, "/")
+}
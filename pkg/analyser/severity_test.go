@@ -0,0 +1,108 @@
+package analyser
+
+import (
+	"testing"
+
+	"github.com/r3labs/diff/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+func TestReadSeverityPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected *SeverityPolicy
+		err      string
+	}{
+		{
+			name: "valid policy",
+			path: "testdata/severity/policy.yml",
+			expected: &SeverityPolicy{
+				Default: SeverityLow,
+				Rules: []SeverityRule{
+					{Type: "aws_iam_*", Severity: SeverityHigh},
+					{Type: "aws_security_group", Kind: DriftKindChanged, Path: "ingress.*.cidr_blocks", Severity: SeverityCritical},
+					{Kind: DriftKindMissing, Severity: SeverityHigh},
+				},
+			},
+		},
+		{
+			name: "unsupported kind",
+			path: "testdata/severity/invalid_kind.yml",
+			err:  "rule 1 has an unsupported kind 'deleted', supported kinds are: unmanaged,missing,changed",
+		},
+		{
+			name: "unsupported severity",
+			path: "testdata/severity/invalid_severity.yml",
+			err:  "error unmarshaling JSON: unsupported severity 'urgent', supported severities are: info,low,high,critical",
+		},
+		{
+			name: "missing severity",
+			path: "testdata/severity/missing_severity.yml",
+			err:  "rule 1 has no severity",
+		},
+		{
+			name: "missing file",
+			path: "testdata/severity/missing.yml",
+			err:  "open testdata/severity/missing.yml: no such file or directory",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadSeverityPolicy(tt.path)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestSeverityPolicy_Apply(t *testing.T) {
+	policy, err := ReadSeverityPolicy("testdata/severity/policy.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	role := &resource.Resource{Id: "role", Type: "aws_iam_role"}
+	bucket := &resource.Resource{Id: "bucket", Type: "aws_s3_bucket"}
+	queue := &resource.Resource{Id: "queue", Type: "aws_sqs_queue"}
+	openedGroup := &resource.Resource{Id: "sg-1", Type: "aws_security_group"}
+	taggedGroup := &resource.Resource{Id: "sg-2", Type: "aws_security_group"}
+
+	analysis := &Analysis{}
+	analysis.AddUnmanaged(role, bucket)
+	analysis.AddDeleted(queue)
+	analysis.AddDifference(
+		Difference{Res: openedGroup, Changelog: Changelog{
+			{Change: diff.Change{Type: diff.UPDATE, Path: []string{"ingress", "0", "cidr_blocks", "0"}}},
+		}},
+		Difference{Res: taggedGroup, Changelog: Changelog{
+			{Change: diff.Change{Type: diff.UPDATE, Path: []string{"tags", "env"}}},
+		}},
+	)
+
+	policy.Apply(analysis)
+
+	assert.Equal(t, SeverityHigh, analysis.Severity(role))
+	assert.Equal(t, SeverityLow, analysis.Severity(bucket))
+	assert.Equal(t, SeverityHigh, analysis.Severity(queue))
+	assert.Equal(t, SeverityCritical, analysis.Severity(openedGroup))
+	assert.Equal(t, SeverityLow, analysis.Severity(taggedGroup))
+
+	assert.Equal(t, SeverityCritical, analysis.HighestSeverity(analysis.Drifts()))
+	assert.Equal(t, SeverityHigh, analysis.HighestSeverity(Drifts{Unmanaged: []*resource.Resource{role, bucket}}))
+	assert.Equal(t, Severity(0), analysis.HighestSeverity(Drifts{}))
+}
+
+func TestAnalysis_HighestSeverityWithoutPolicy(t *testing.T) {
+	analysis := &Analysis{}
+	analysis.AddUnmanaged(&resource.Resource{Id: "bucket", Type: "aws_s3_bucket"})
+
+	assert.Equal(t, Severity(0), analysis.Severity(analysis.Unmanaged()[0]))
+	assert.Equal(t, SeverityInfo, analysis.HighestSeverity(analysis.Drifts()))
+}
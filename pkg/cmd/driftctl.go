@@ -68,6 +68,8 @@ func NewDriftctlCmd(build build.BuildInterface) *DriftctlCmd {
 
 	cmd.AddCommand(NewScanCmd(&pkg.ScanOptions{}))
 	cmd.AddCommand(NewGenDriftIgnoreCmd())
+	cmd.AddCommand(NewDriftIgnoreCmd())
+	cmd.AddCommand(NewReportCmd())
 
 	return cmd
 }
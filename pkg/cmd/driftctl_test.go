@@ -99,7 +99,7 @@ func TestDriftctlCmd_Scan(t *testing.T) {
 			env: map[string]string{
 				"DCTL_FROM": "test",
 			},
-			err: fmt.Errorf("Unable to parse from flag 'test': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+http://,tfstate+https://,tfstate+tfcloud://"),
+			err: fmt.Errorf("Unable to parse from flag 'test': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+gs://,tfstate+azurerm://,tfstate+consul://,tfstate+pg://,tfstate+kubernetes://,tfstate+http://,tfstate+https://,tfstate+tfcloud://,tfdir://,tfplan://,cfn://,pulumi://,terragrunt://"),
 		},
 		{
 			env: map[string]string{
@@ -111,7 +111,7 @@ func TestDriftctlCmd_Scan(t *testing.T) {
 			env: map[string]string{
 				"DCTL_OUTPUT": "test",
 			},
-			err: fmt.Errorf("Unable to parse output flag 'test': \nAccepted formats are: console://,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,plan://PATH/TO/FILE.json"),
+			err: fmt.Errorf("Unable to parse output flag 'test': \nAccepted formats are: console://,csv://PATH/TO/FILE.csv,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,junit://PATH/TO/FILE.xml,markdown://PATH/TO/FILE.md,openmetrics://PATH/TO/FILE.prom,plan://PATH/TO/FILE.json,sarif://PATH/TO/FILE.sarif"),
 		},
 		{
 			env: map[string]string{
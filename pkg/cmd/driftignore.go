@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewDriftIgnoreCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "driftignore",
+		Short: "Work with driftignore files",
+		Long:  "Work with the .driftignore files used by the scan command to ignore drifts",
+		Args:  cobra.NoArgs,
+	}
+
+	cmd.AddCommand(NewDriftIgnoreLintCmd())
+
+	return cmd
+}
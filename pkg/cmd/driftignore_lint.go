@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/cloudskiff/driftctl/pkg/analyser"
+	cmderrors "github.com/cloudskiff/driftctl/pkg/cmd/errors"
+	"github.com/cloudskiff/driftctl/pkg/filter"
+)
+
+func NewDriftIgnoreLintCmd() *cobra.Command {
+	var driftignorePath, inputPath string
+
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Report expired, duplicate, malformed and unused driftignore rules",
+		Long: "This command checks the rules of a .driftignore file against a scan result.\n" +
+			"The scan result must be generated without the .driftignore file, so ignored drifts are part of it.\n" +
+			"The exit code is 6 when issues are found.\n\n" +
+			"Example: driftctl scan --driftignore /dev/null -o json://stdout | driftctl driftignore lint",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return driftIgnoreLint(cmd.OutOrStdout(), driftignorePath, inputPath, time.Now())
+		},
+	}
+
+	fl := cmd.Flags()
+	fl.StringVar(&driftignorePath, "driftignore", ".driftignore", "Path to the driftignore file")
+	fl.StringVarP(&inputPath, "input", "i", "-", "Scan result the rules are checked against. Defaults to stdin.")
+
+	return cmd
+}
+
+func driftIgnoreLint(out io.Writer, driftignorePath, inputPath string, now time.Time) error {
+	rules, err := filter.ReadDriftIgnoreRules(driftignorePath)
+	if err != nil {
+		return errors.Wrapf(err, "unable to read driftignore '%s'", driftignorePath)
+	}
+
+	analysis, err := readLintInput(inputPath)
+	if err != nil {
+		return errors.Wrapf(err, "unable to read scan result '%s'", inputPath)
+	}
+
+	issues := analyser.LintDriftIgnore(rules, analysis, now)
+	for _, issue := range issues {
+		fmt.Fprintf(out, "%s:%d: %s: %s\n", driftignorePath, issue.Rule.Line, issue.Kind, issue.Message)
+	}
+	if len(issues) > 0 {
+		return cmderrors.DriftIgnoreLintIssues{Path: driftignorePath, Count: len(issues)}
+	}
+	return nil
+}
+
+func readLintInput(path string) (*analyser.Analysis, error) {
+	if path != "-" {
+		return readAnalysis(path)
+	}
+	analysis := &analyser.Analysis{}
+	if err := json.NewDecoder(os.Stdin).Decode(analysis); err != nil {
+		return nil, err
+	}
+	return analysis, nil
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	cmderrors "github.com/cloudskiff/driftctl/pkg/cmd/errors"
+	"github.com/cloudskiff/driftctl/test"
+)
+
+func TestDriftIgnoreLintCmd(t *testing.T) {
+	cases := []struct {
+		name   string
+		args   []string
+		output []string
+		issues int
+		err    string
+	}{
+		{
+			name: "valid rules",
+			args: []string{"--driftignore", "./testdata/lint_valid.driftignore", "-i", "./testdata/input_stdin_valid.json"},
+		},
+		{
+			name: "invalid rules",
+			args: []string{"--driftignore", "./testdata/lint.driftignore", "-i", "./testdata/input_stdin_valid.json"},
+			output: []string{
+				"./testdata/lint.driftignore:2: duplicate: rule 'aws_iam_user.driftctl' duplicates line 1\n",
+				"./testdata/lint.driftignore:3: expired: rule 'aws_iam_role.*' (owner=team-sec) expired on 2020-01-01\n",
+				"./testdata/lint.driftignore:4: unused: rule 'aws_s3_bucket.*.Tags.test regex(^prod)' does not match any drift\n",
+				"./testdata/lint.driftignore:5: unused: rule 'aws_lambda_function.*' does not match any drift\n",
+			},
+			issues: 4,
+			err:    "4 issue(s) found in ./testdata/lint.driftignore",
+		},
+		{
+			name: "driftignore not found",
+			args: []string{"--driftignore", "doesnotexist", "-i", "./testdata/input_stdin_valid.json"},
+			err:  "unable to read driftignore 'doesnotexist': open doesnotexist: no such file or directory",
+		},
+		{
+			name: "invalid scan result",
+			args: []string{"--driftignore", "./testdata/lint.driftignore", "-i", "./testdata/input_stdin_invalid.json"},
+			err:  "unable to read scan result './testdata/input_stdin_invalid.json': invalid character 'i' looking for beginning of value",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rootCmd := &cobra.Command{Use: "root"}
+			rootCmd.AddCommand(NewDriftIgnoreCmd())
+
+			output, err := test.Execute(rootCmd, append([]string{"driftignore", "lint"}, c.args...)...)
+			if c.err != "" {
+				assert.EqualError(t, err, c.err)
+				// Issues are reported as such, not as a failure of the command
+				lintIssues, isLintIssues := err.(cmderrors.DriftIgnoreLintIssues)
+				assert.Equal(t, c.issues > 0, isLintIssues)
+				assert.Equal(t, c.issues, lintIssues.Count)
+			} else {
+				assert.NoError(t, err)
+				assert.Empty(t, output)
+			}
+			for _, line := range c.output {
+				assert.Contains(t, output, line)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package errors
+
+import "fmt"
+
+// DriftIgnoreLintIssues is returned when linting a driftignore file found issues, the issues being already reported
+type DriftIgnoreLintIssues struct {
+	Path  string
+	Count int
+}
+
+func (i DriftIgnoreLintIssues) Error() string {
+	return fmt.Sprintf("%d issue(s) found in %s", i.Count, i.Path)
+}
+
+// ExitCode returns 6, following the exit codes of drifts so every exit code has a single meaning
+func (i DriftIgnoreLintIssues) ExitCode() int {
+	return 6
+}
@@ -0,0 +1,14 @@
+package errors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDriftIgnoreLintIssues(t *testing.T) {
+	err := DriftIgnoreLintIssues{Path: ".driftignore", Count: 2}
+
+	assert.EqualError(t, err, "2 issue(s) found in .driftignore")
+	assert.Equal(t, 6, err.ExitCode())
+}
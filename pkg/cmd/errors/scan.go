@@ -1,7 +1,26 @@
 package errors
 
-type InfrastructureNotInSync struct{}
+import "github.com/cloudskiff/driftctl/pkg/analyser"
+
+// InfrastructureNotInSync is returned when a scan found drifts, Severity being the highest severity of these drifts
+type InfrastructureNotInSync struct {
+	Severity analyser.Severity
+}
 
 func (i InfrastructureNotInSync) Error() string {
 	return "Infrastructure is not in sync"
 }
+
+// ExitCode returns the exit code reflecting the severity of the drift.
+// 2 is skipped as it is the exit code of a crash.
+func (i InfrastructureNotInSync) ExitCode() int {
+	switch i.Severity {
+	case analyser.SeverityLow:
+		return 3
+	case analyser.SeverityHigh:
+		return 4
+	case analyser.SeverityCritical:
+		return 5
+	}
+	return 1
+}
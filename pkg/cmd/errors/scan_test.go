@@ -0,0 +1,27 @@
+package errors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudskiff/driftctl/pkg/analyser"
+)
+
+func TestInfrastructureNotInSync_ExitCode(t *testing.T) {
+	tests := []struct {
+		severity analyser.Severity
+		expected int
+	}{
+		{severity: 0, expected: 1},
+		{severity: analyser.SeverityInfo, expected: 1},
+		{severity: analyser.SeverityLow, expected: 3},
+		{severity: analyser.SeverityHigh, expected: 4},
+		{severity: analyser.SeverityCritical, expected: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.severity.String(), func(t *testing.T) {
+			assert.Equal(t, tt.expected, InfrastructureNotInSync{Severity: tt.severity}.ExitCode())
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewReportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Work with scan results",
+		Long:  "Work with JSON scan results written by the scan command",
+		Args:  cobra.NoArgs,
+	}
+
+	cmd.AddCommand(NewReportMergeCmd())
+
+	return cmd
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/cloudskiff/driftctl/pkg/analyser"
+	"github.com/cloudskiff/driftctl/pkg/cmd/scan/output"
+)
+
+func NewReportMergeCmd() *cobra.Command {
+	var outputs []output.OutputConfig
+
+	cmd := &cobra.Command{
+		Use:   "merge <result.json>...",
+		Short: "Merge several scan results into a single report",
+		Long:  "This command combines JSON scan results, e.g. from scans of different cloud providers or accounts, into a single report\n\nExample: driftctl report merge aws.json github.json -o html://report.html",
+		Args:  cobra.MinimumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			outputFlag, _ := cmd.Flags().GetStringSlice("output")
+
+			out, err := parseOutputFlags(outputFlag)
+			if err != nil {
+				return err
+			}
+			outputs = out
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return reportMerge(args, outputs)
+		},
+	}
+
+	fl := cmd.Flags()
+	fl.StringSliceP(
+		"output",
+		"o",
+		[]string{output.Example(output.ConsoleOutputType)},
+		"Output format, by default it will write to the console\n"+
+			"Accepted formats are: "+strings.Join(output.SupportedOutputsExample(), ",")+"\n",
+	)
+
+	return cmd
+}
+
+func reportMerge(paths []string, outputs []output.OutputConfig) error {
+	analyses := make([]*analyser.Analysis, 0, len(paths))
+	for _, path := range paths {
+		analysis, err := readAnalysis(path)
+		if err != nil {
+			return errors.Wrapf(err, "unable to read scan result '%s'", path)
+		}
+		analyses = append(analyses, analysis)
+	}
+
+	analysis := analyser.Merge(analyses...)
+
+	for _, o := range outputs {
+		if err := output.GetOutput(o).Write(analysis); err != nil {
+			logrus.Errorf("Error writing to output %s: %v", o.String(), err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudskiff/driftctl/test"
+	"github.com/cloudskiff/driftctl/test/goldenfile"
+)
+
+func TestReportMergeCmd(t *testing.T) {
+	cases := []struct {
+		name       string
+		args       []string
+		goldenfile string
+		err        error
+	}{
+		{
+			name:       "merge scan results",
+			args:       []string{"./testdata/report_aws.json", "./testdata/report_github.json"},
+			goldenfile: "report_merged.json",
+		},
+		{
+			name: "missing argument",
+			args: []string{},
+			err:  errors.New("requires at least 1 arg(s), only received 0"),
+		},
+		{
+			name: "scan result not found",
+			args: []string{"./testdata/report_aws.json", "doesnotexist"},
+			err:  errors.New("unable to read scan result 'doesnotexist': open doesnotexist: no such file or directory"),
+		},
+		{
+			name: "invalid scan result",
+			args: []string{"./testdata/input_stdin_invalid.json"},
+			err:  errors.New("unable to read scan result './testdata/input_stdin_invalid.json': invalid character 'i' looking for beginning of value"),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rootCmd := &cobra.Command{Use: "root"}
+			rootCmd.AddCommand(NewReportCmd())
+
+			outputFile := path.Join(t.TempDir(), "merged.json")
+			args := append([]string{"report", "merge", "-o", "json://" + outputFile}, c.args...)
+
+			_, err := test.Execute(rootCmd, args...)
+			if c.err != nil {
+				assert.EqualError(t, err, c.err.Error())
+				return
+			}
+			require.Nil(t, err)
+
+			got, err := os.ReadFile(outputFile)
+			require.Nil(t, err)
+
+			expectedFilePath := path.Join("./testdata", c.goldenfile)
+			if *goldenfile.Update == c.goldenfile {
+				require.Nil(t, os.WriteFile(expectedFilePath, got, 0600))
+			}
+			expected, err := os.ReadFile(expectedFilePath)
+			require.Nil(t, err)
+			assert.Equal(t, string(expected), string(got))
+		})
+	}
+}
+
+func TestReportMergeCmd_InvalidOutput(t *testing.T) {
+	rootCmd := &cobra.Command{Use: "root"}
+	rootCmd.AddCommand(NewReportCmd())
+
+	_, err := test.Execute(rootCmd, "report", "merge", "-o", "foobar://", "./testdata/report_aws.json")
+	assert.EqualError(t, err, "Unsupported output 'foobar': \nValid formats are: console://,csv://PATH/TO/FILE.csv,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,junit://PATH/TO/FILE.xml,markdown://PATH/TO/FILE.md,openmetrics://PATH/TO/FILE.prom,plan://PATH/TO/FILE.json,sarif://PATH/TO/FILE.sarif")
+}
@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
 	"os/signal"
 	"regexp"
@@ -61,6 +63,12 @@ func NewScanCmd(opts *pkg.ScanOptions) *cobra.Command {
 				)
 			}
 
+			for _, flag := range []string{"regions", "assume-role", "assume-role-external-id", "organization-role"} {
+				if cmd.Flags().Changed(flag) && to != common.RemoteAWSTerraform {
+					return errors.Errorf("--%s is only supported with the %s cloud provider", flag, common.RemoteAWSTerraform)
+				}
+			}
+
 			outputFlag, _ := cmd.Flags().GetStringSlice("output")
 
 			out, err := parseOutputFlags(outputFlag)
@@ -69,6 +77,14 @@ func NewScanCmd(opts *pkg.ScanOptions) *cobra.Command {
 			}
 			opts.Output = out
 
+			if opts.Stream {
+				for _, o := range opts.Output {
+					if _, ok := output.GetOutput(o).(output.StreamOutput); !ok {
+						return errors.Errorf("--stream is not supported by the %s output, only outputs written incrementally like csv can be used", o.Key)
+					}
+				}
+			}
+
 			filterFlag, _ := cmd.Flags().GetStringArray("filter")
 
 			if len(filterFlag) > 1 {
@@ -103,6 +119,30 @@ func NewScanCmd(opts *pkg.ScanOptions) *cobra.Command {
 				}
 			}
 
+			baselinePath, _ := cmd.Flags().GetString("baseline")
+			if baselinePath != "" {
+				baseline, err := readAnalysis(baselinePath)
+				if err != nil {
+					return errors.Wrapf(err, "unable to read baseline '%s'", baselinePath)
+				}
+				opts.Baseline = baseline
+			}
+
+			severityPolicyPath, _ := cmd.Flags().GetString("severity-policy")
+			if severityPolicyPath != "" {
+				policy, err := analyser.ReadSeverityPolicy(severityPolicyPath)
+				if err != nil {
+					return errors.Wrapf(err, "unable to read severity policy '%s'", severityPolicyPath)
+				}
+				opts.SeverityPolicy = policy
+			}
+
+			failOn, _ := cmd.Flags().GetString("fail-on")
+			opts.FailOn, err = analyser.ParseSeverity(failOn)
+			if err != nil {
+				return cmderrors.NewUsageError(err.Error())
+			}
+
 			opts.Quiet, _ = cmd.Flags().GetBool("quiet")
 			opts.DisableTelemetry, _ = cmd.Flags().GetBool("disable-telemetry")
 
@@ -137,14 +177,25 @@ func NewScanCmd(opts *pkg.ScanOptions) *cobra.Command {
 		"o",
 		[]string{output.Example(output.ConsoleOutputType)},
 		"Output format, by default it will write to the console\n"+
-			"Accepted formats are: "+strings.Join(output.SupportedOutputsExample(), ",")+"\n",
+			"Accepted formats are: "+strings.Join(output.SupportedOutputsExample(), ",")+"\n"+
+			fmt.Sprintf("Markdown output is truncated to %d bytes by default, use the max-size option to change it (e.g. markdown://PATH/TO/FILE.md?max-size=10000, 0 disables the limit)\n", output.MarkdownDefaultMaxSize)+
+			"OpenMetrics output can be pushed to a Pushgateway with the pushgateway and job options (e.g. openmetrics://PATH/TO/FILE.prom?pushgateway=http://localhost:9091&job=driftctl, the path can be omitted)\n"+
+			"CSV output columns can be selected with the columns option (e.g. csv://PATH/TO/FILE.csv?columns=status,type,id)\n"+
+			"JSON output includes the attributes of resources with the attributes option, masking sensitive values (e.g. json://PATH/TO/FILE.json?attributes=true)\n",
 	)
 	fl.StringSliceP(
 		"from",
 		"f",
 		[]string{"tfstate://terraform.tfstate"},
 		"IaC sources, by default try to find local terraform.tfstate file\n"+
-			"Accepted schemes are: "+strings.Join(supplier.GetSupportedSchemes(), ",")+"\n",
+			"Accepted schemes are: "+strings.Join(supplier.GetSupportedSchemes(), ",")+"\n"+
+			"Terraform working directories read the state of their backend configuration, workspace and backend-config options are supported (e.g. tfdir://PATH/TO/PROJECT?workspace=prod&backend-config=prod.tfbackend, use workspace=* to read every workspace)\n"+
+			"S3 states can be selected by terraform workspace (e.g. tfstate+s3://BUCKET/KEY?workspaces=prod-*,default&exclude_workspaces=prod-legacy, workspace_key_prefix option defaults to env:)\n"+
+			"Terraform Cloud workspaces can be read by organization, filtered by name and tags (e.g. tfstate+tfcloud://ORGANIZATION/prod-*?tags=aws,network)\n"+
+			"Terraform plans generated with terraform show -json are compared as if they were applied (e.g. tfplan://plan.json)\n"+
+			"CloudFormation stacks can be read by name, or by glob pattern to read several stacks (e.g. cfn://prod-*?region=eu-west-3)\n"+
+			"Pulumi stacks using Terraform bridged providers are read from their export (e.g. pulumi://stack-export.json, generated with pulumi stack export)\n"+
+			"Terragrunt stacks read the remote state of every terragrunt.hcl file of a directory tree (e.g. terragrunt://PATH/TO/STACK)\n",
 	)
 	supportedRemotes := remote.GetSupportedRemotes()
 	fl.StringVarP(
@@ -155,6 +206,31 @@ func NewScanCmd(opts *pkg.ScanOptions) *cobra.Command {
 		"Cloud provider source\n"+
 			"Accepted values are: "+strings.Join(supportedRemotes, ",")+"\n",
 	)
+	fl.StringSliceVar(&opts.Regions,
+		"regions",
+		[]string{},
+		"AWS regions to scan, by default only the region of your AWS profile is scanned\n"+
+			"Use 'all' to scan every region enabled on your account\n"+
+			"Only used with aws+tf cloud provider.\n",
+	)
+	fl.StringSliceVar(&opts.AssumeRoleARNs,
+		"assume-role",
+		[]string{},
+		"IAM role ARNs to assume, one account is scanned per role\n"+
+			"Only used with aws+tf cloud provider.\n",
+	)
+	fl.StringVar(&opts.AssumeRoleExternalID,
+		"assume-role-external-id",
+		"",
+		"External ID used when assuming roles.\n"+
+			"Only used with aws+tf cloud provider.\n",
+	)
+	fl.StringVar(&opts.OrganizationRoleName,
+		"organization-role",
+		"",
+		"Name of the IAM role to assume in every active account of your AWS Organization\n"+
+			"Only used with aws+tf cloud provider.\n",
+	)
 	fl.StringToStringVarP(&opts.BackendOptions.Headers,
 		"headers",
 		"H",
@@ -190,11 +266,35 @@ func NewScanCmd(opts *pkg.ScanOptions) *cobra.Command {
 		fmt.Sprintf("%s Enable deep mode\n", warn("EXPERIMENTAL:"))+
 			"You should check the documentation for more details: https://docs.driftctl.com/deep-mode\n",
 	)
+	fl.BoolVar(&opts.Stream,
+		"stream",
+		false,
+		fmt.Sprintf("%s Analyze resources type by type as soon as they are scanned\n", warn("EXPERIMENTAL:"))+
+			"Only outputs written incrementally, like csv, are supported. It does not reduce memory usage, as the whole analysis is still kept until the end of the scan.\n",
+	)
 	fl.StringVar(&opts.DriftignorePath,
 		"driftignore",
 		".driftignore",
 		"Path to the driftignore file",
 	)
+	fl.String(
+		"baseline",
+		"",
+		"Path to the JSON output of a previous scan.\n"+
+			"Drifts are classified as new, persisting or resolved and only new drifts make the scan fail.\n",
+	)
+	fl.String(
+		"severity-policy",
+		"",
+		"Path to a YAML file mapping resource types, drift kinds and attribute paths to severities.\n"+
+			"Severities are shown in outputs and the exit code reflects the highest severity found.\n",
+	)
+	fl.String(
+		"fail-on",
+		analyser.SeverityInfo.String(),
+		"Lowest severity of drifts making the scan fail.\n"+
+			fmt.Sprintf("Accepted values are: %s\n", strings.Join(analyser.SupportedSeverities(), ",")),
+	)
 	fl.String(
 		"tf-lockfile",
 		".terraform.lock.hcl",
@@ -238,7 +338,12 @@ func scanRun(opts *pkg.ScanOptions) error {
 
 	resFactory := terraform.NewTerraformResourceFactory(resourceSchemaRepository)
 
-	err := remote.Activate(opts.To, opts.ProviderVersion, alerter, providerLibrary, remoteLibrary, scanProgress, resourceSchemaRepository, resFactory, opts.ConfigDir)
+	err := remote.Activate(opts.To, opts.ProviderVersion, alerter, providerLibrary, remoteLibrary, scanProgress, resourceSchemaRepository, resFactory, opts.ConfigDir, common.RemoteOptions{
+		Regions:              opts.Regions,
+		AssumeRoleARNs:       opts.AssumeRoleARNs,
+		AssumeRoleExternalID: opts.AssumeRoleExternalID,
+		OrganizationRoleName: opts.OrganizationRoleName,
+	})
 	if err != nil {
 		return err
 	}
@@ -252,6 +357,9 @@ func scanRun(opts *pkg.ScanOptions) error {
 
 	logrus.Debug("Checking for driftignore")
 	driftIgnore := filter.NewDriftIgnore(opts.DriftignorePath)
+	for _, rule := range driftIgnore.ExpiredRules() {
+		alerter.SendAlert("", filter.NewExpiredRuleAlert(opts.DriftignorePath, rule))
+	}
 
 	scanner := remote.NewScanner(remoteLibrary, alerter, remote.ScannerOptions{Deep: opts.Deep}, driftIgnore)
 
@@ -279,7 +387,25 @@ func scanRun(opts *pkg.ScanOptions) error {
 		ctl.Stop()
 	}()
 
-	analysis, err := ctl.Run()
+	outputs := make([]output.Output, 0, len(opts.Output))
+	for _, o := range opts.Output {
+		outputs = append(outputs, output.GetOutput(o))
+	}
+
+	var analysis *analyser.Analysis
+	if opts.Stream {
+		analysis, err = ctl.RunStream(func(partial *analyser.Analysis) {
+			for i, o := range outputs {
+				if streamOutput, ok := o.(output.StreamOutput); ok {
+					if err := streamOutput.WritePartial(partial); err != nil {
+						logrus.Errorf("Error writing to output %s: %v", opts.Output[i].String(), err.Error())
+					}
+				}
+			}
+		})
+	} else {
+		analysis, err = ctl.Run()
+	}
 	if err != nil {
 		return err
 	}
@@ -289,9 +415,15 @@ func scanRun(opts *pkg.ScanOptions) error {
 	store.Bucket(memstore.TelemetryBucket).Set("provider_name", analysis.ProviderName)
 
 	validOutput := false
-	for _, o := range opts.Output {
-		if err = output.GetOutput(o).Write(analysis); err != nil {
-			logrus.Errorf("Error writing to output %s: %v", o.String(), err.Error())
+	for i, o := range outputs {
+		// Outputs written incrementally only need to be closed
+		if streamOutput, ok := o.(output.StreamOutput); ok && opts.Stream {
+			err = streamOutput.Close()
+		} else {
+			err = o.Write(analysis)
+		}
+		if err != nil {
+			logrus.Errorf("Error writing to output %s: %v", opts.Output[i].String(), err.Error())
 			continue
 		}
 		validOutput = true
@@ -312,13 +444,43 @@ func scanRun(opts *pkg.ScanOptions) error {
 		telemetry.SendTelemetry(store.Bucket(memstore.TelemetryBucket))
 	}
 
-	if !analysis.IsSync() {
+	// Only new drifts make the scan fail when a baseline is given
+	drifts := analysis.Drifts()
+	if baseline := analysis.Baseline(); baseline != nil {
+		drifts = baseline.New
+	}
+	if drifts.Count() == 0 {
+		return nil
+	}
+
+	severity := analysis.HighestSeverity(drifts)
+	if severity < opts.FailOn {
+		logrus.WithFields(logrus.Fields{
+			"severity": severity,
+			"fail-on":  opts.FailOn,
+		}).Debug("Ignoring drifts below the failure threshold")
+		return nil
+	}
+
+	if analysis.Baseline() == nil {
 		globaloutput.Printf("\nHint: use gen-driftignore command to generate a .driftignore file based on your drifts\n")
+	}
+
+	return cmderrors.InfrastructureNotInSync{Severity: severity}
+}
 
-		return cmderrors.InfrastructureNotInSync{}
+func readAnalysis(path string) (*analyser.Analysis, error) {
+	input, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	analysis := &analyser.Analysis{}
+	if err := json.Unmarshal(input, analysis); err != nil {
+		return nil, err
+	}
+
+	return analysis, nil
 }
 
 func parseFromFlag(from []string) ([]config.SupplierConfig, error) {
@@ -371,6 +533,17 @@ func parseFromFlag(from []string) ([]config.SupplierConfig, error) {
 
 		backendString := ""
 		if len(supplierBackend) == 2 {
+			if !supplier.SupportsBackends(supplierKey) {
+				return nil, errors.Wrapf(
+					cmderrors.NewUsageError(fmt.Sprintf(
+						"\nAccepted schemes are: %s",
+						strings.Join(supplier.GetSupportedSchemes(), ","),
+					),
+					),
+					"Unable to parse from scheme '%s'",
+					scheme,
+				)
+			}
 			backendString = supplierBackend[1]
 			if !backend.IsSupported(backendString) {
 				return nil, errors.Wrapf(
@@ -455,7 +628,24 @@ func parseOutputFlag(out string) (*output.OutputConfig, error) {
 				out,
 			)
 		}
-		o.Path = opts[0]
+		path, options, err := output.ParseOptions(opts[0], output.JSONOptionAttributes)
+		if err == nil {
+			_, err = output.JSONAttributes(options)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(
+				cmderrors.NewUsageError(
+					fmt.Sprintf(
+						"\n%s",
+						err,
+					),
+				),
+				"Invalid json output '%s'",
+				out,
+			)
+		}
+		o.Path = path
+		o.Options = options
 	case output.HTMLOutputType:
 		if len(opts) != 1 || opts[0] == "" {
 			return nil, errors.Wrapf(
@@ -484,6 +674,128 @@ func parseOutputFlag(out string) (*output.OutputConfig, error) {
 			)
 		}
 		o.Path = opts[0]
+	case output.SARIFOutputType:
+		if len(opts) != 1 || opts[0] == "" {
+			return nil, errors.Wrapf(
+				cmderrors.NewUsageError(
+					fmt.Sprintf(
+						"\nMust be of kind: %s",
+						output.Example(output.SARIFOutputType),
+					),
+				),
+				"Invalid sarif output '%s'",
+				out,
+			)
+		}
+		o.Path = opts[0]
+	case output.JUnitOutputType:
+		if len(opts) != 1 || opts[0] == "" {
+			return nil, errors.Wrapf(
+				cmderrors.NewUsageError(
+					fmt.Sprintf(
+						"\nMust be of kind: %s",
+						output.Example(output.JUnitOutputType),
+					),
+				),
+				"Invalid junit output '%s'",
+				out,
+			)
+		}
+		o.Path = opts[0]
+	case output.MarkdownOutputType:
+		if len(opts) != 1 || opts[0] == "" {
+			return nil, errors.Wrapf(
+				cmderrors.NewUsageError(
+					fmt.Sprintf(
+						"\nMust be of kind: %s",
+						output.Example(output.MarkdownOutputType),
+					),
+				),
+				"Invalid markdown output '%s'",
+				out,
+			)
+		}
+		path, options, err := output.ParseOptions(opts[0], output.MarkdownOptionMaxSize)
+		if err == nil {
+			_, err = output.MarkdownMaxSize(options)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(
+				cmderrors.NewUsageError(
+					fmt.Sprintf(
+						"\n%s",
+						err,
+					),
+				),
+				"Invalid markdown output '%s'",
+				out,
+			)
+		}
+		o.Path = path
+		o.Options = options
+	case output.CSVOutputType:
+		if len(opts) != 1 || opts[0] == "" || strings.HasPrefix(opts[0], "?") {
+			return nil, errors.Wrapf(
+				cmderrors.NewUsageError(
+					fmt.Sprintf(
+						"\nMust be of kind: %s",
+						output.Example(output.CSVOutputType),
+					),
+				),
+				"Invalid csv output '%s'",
+				out,
+			)
+		}
+		path, options, err := output.ParseOptions(opts[0], output.CSVOptionColumns)
+		if err == nil {
+			_, err = output.CSVColumns(options)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(
+				cmderrors.NewUsageError(
+					fmt.Sprintf(
+						"\n%s",
+						err,
+					),
+				),
+				"Invalid csv output '%s'",
+				out,
+			)
+		}
+		o.Path = path
+		o.Options = options
+	case output.OpenMetricsOutputType:
+		// The pushgateway option is an url containing a scheme separator
+		path, options, err := output.ParseOptions(strings.Join(opts, "://"), output.OpenMetricsOptionPushgateway, output.OpenMetricsOptionJob)
+		if err == nil && options[output.OpenMetricsOptionPushgateway] != "" {
+			_, err = url.ParseRequestURI(options[output.OpenMetricsOptionPushgateway])
+		}
+		if err != nil {
+			return nil, errors.Wrapf(
+				cmderrors.NewUsageError(
+					fmt.Sprintf(
+						"\n%s",
+						err,
+					),
+				),
+				"Invalid openmetrics output '%s'",
+				out,
+			)
+		}
+		if path == "" && options[output.OpenMetricsOptionPushgateway] == "" {
+			return nil, errors.Wrapf(
+				cmderrors.NewUsageError(
+					fmt.Sprintf(
+						"\nMust be of kind: %s",
+						output.Example(output.OpenMetricsOutputType),
+					),
+				),
+				"Invalid openmetrics output '%s'",
+				out,
+			)
+		}
+		o.Path = path
+		o.Options = options
 	}
 
 	return o, nil
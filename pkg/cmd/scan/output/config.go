@@ -1,12 +1,58 @@
 package output
 
-import "fmt"
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
 
 type OutputConfig struct {
-	Key  string
-	Path string
+	Key     string
+	Path    string
+	Options map[string]string
 }
 
 func (o *OutputConfig) String() string {
-	return fmt.Sprintf("%s://%s", o.Key, o.Path)
+	str := fmt.Sprintf("%s://%s", o.Key, o.Path)
+	if len(o.Options) > 0 {
+		values := url.Values{}
+		for key, value := range o.Options {
+			values.Set(key, value)
+		}
+		str += "?" + values.Encode()
+	}
+	return str
+}
+
+// ParseOptions splits the options given as a query string from an output path (e.g. PATH?key=value).
+// Options start at the first '?', so option values like URLs may contain their own query string.
+// Values containing '&' must be URL encoded. Only the options listed as supported are accepted.
+func ParseOptions(path string, supported ...string) (string, map[string]string, error) {
+	i := strings.Index(path, "?")
+	if i < 0 {
+		return path, nil, nil
+	}
+
+	values, err := url.ParseQuery(path[i+1:])
+	if err != nil {
+		return "", nil, err
+	}
+
+	options := make(map[string]string, len(values))
+	for key := range values {
+		if !contains(supported, key) {
+			return "", nil, fmt.Errorf("unsupported option '%s', supported options are: %s", key, strings.Join(supported, ","))
+		}
+		options[key] = values.Get(key)
+	}
+	return path[:i], options, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
 }
@@ -70,7 +70,7 @@ func (c *Console) Write(analysis *analyser.Analysis) error {
 				if deletedResource.SourceString() != "" {
 					humanStringSource = deletedResource.SourceString()
 				}
-				humanString := fmt.Sprintf("%s- %s (%s)", indentBase, deletedResource.ResourceId(), humanStringSource)
+				humanString := fmt.Sprintf("%s- %s (%s)%s", indentBase, deletedResource.ResourceId(), humanStringSource, formatSeverity(analysis.Severity(deletedResource)))
 
 				if humanAttrs := formatResourceAttributes(deletedResource); humanAttrs != "" {
 					humanString += fmt.Sprintf("\n%s    %s", indentBase, humanAttrs)
@@ -86,7 +86,7 @@ func (c *Console) Write(analysis *analyser.Analysis) error {
 		for _, ty := range keys {
 			fmt.Printf("  %s:\n", ty)
 			for _, res := range unmanagedByType[ty] {
-				humanString := fmt.Sprintf("    - %s", res.ResourceId())
+				humanString := fmt.Sprintf("    - %s%s", res.ResourceId(), formatSeverity(analysis.Severity(res)))
 				if humanAttrs := formatResourceAttributes(res); humanAttrs != "" {
 					humanString += fmt.Sprintf("\n        %s", humanAttrs)
 				}
@@ -127,7 +127,7 @@ func (c *Console) Write(analysis *analyser.Analysis) error {
 				if difference.Res.SourceString() != "" {
 					humanStringSource = difference.Res.SourceString()
 				}
-				humanString := fmt.Sprintf("%s- %s (%s):", indentBase, difference.Res.ResourceId(), humanStringSource)
+				humanString := fmt.Sprintf("%s- %s (%s)%s:", indentBase, difference.Res.ResourceId(), humanStringSource, formatSeverity(analysis.Severity(difference.Res)))
 				whiteSpace := indentBase + "    "
 				if humanAttrs := formatResourceAttributes(difference.Res); humanAttrs != "" {
 					humanString += fmt.Sprintf("\n%s%s", whiteSpace, humanAttrs)
@@ -178,6 +178,17 @@ func (c *Console) Write(analysis *analyser.Analysis) error {
 	return nil
 }
 
+// formatSeverity returns the severity of a drift to display after a resource, nothing when no severity policy was applied
+func formatSeverity(severity analyser.Severity) string {
+	switch severity {
+	case 0:
+		return ""
+	case analyser.SeverityHigh, analyser.SeverityCritical:
+		return " " + color.RedString("[%s]", severity)
+	}
+	return " " + color.YellowString("[%s]", severity)
+}
+
 func (c Console) writeSummary(analysis *analyser.Analysis) {
 	boldWriter := color.New(color.Bold)
 	successWriter := color.New(color.Bold, color.FgGreen)
@@ -221,11 +232,76 @@ func (c Console) writeSummary(analysis *analyser.Analysis) {
 		}
 		fmt.Printf(" - %s resource(s) found in a Terraform state but missing on the cloud provider\n", deleted)
 	}
+	c.writeAccountSummaries(analysis)
+	c.writeBaseline(analysis)
 	if analysis.IsSync() {
 		fmt.Println(color.GreenString("Congrats! Your infrastructure is fully in sync."))
 	}
 }
 
+func (c Console) writeAccountSummaries(analysis *analyser.Analysis) {
+	summaries := analysis.AccountSummaries()
+	if len(summaries) == 0 {
+		return
+	}
+
+	accounts := make([]string, 0, len(summaries))
+	for account := range summaries {
+		accounts = append(accounts, account)
+	}
+	sort.Strings(accounts)
+
+	boldWriter := color.New(color.Bold)
+	fmt.Println("Per account:")
+	for _, account := range accounts {
+		summary := summaries[account]
+		fmt.Printf(
+			" - %s: %s resource(s), %d managed, %d out of sync, %d not managed, %d missing\n",
+			boldWriter.Sprint(account),
+			boldWriter.Sprintf("%d", summary.TotalResources),
+			summary.TotalManaged,
+			summary.TotalDrifted,
+			summary.TotalUnmanaged,
+			summary.TotalDeleted,
+		)
+	}
+}
+
+func (c Console) writeBaseline(analysis *analyser.Analysis) {
+	baseline := analysis.Baseline()
+	if baseline == nil {
+		return
+	}
+
+	boldWriter := color.New(color.Bold)
+	successWriter := color.New(color.Bold, color.FgGreen)
+	errorWriter := color.New(color.Bold, color.FgRed)
+
+	newDrifts := successWriter.Sprintf("0")
+	if baseline.New.Count() > 0 {
+		newDrifts = errorWriter.Sprintf("%d", baseline.New.Count())
+	}
+
+	fmt.Println("Compared to baseline:")
+	fmt.Printf(" - %s new drift(s)\n", newDrifts)
+	c.writeBaselineDrifts(baseline.New)
+	fmt.Printf(" - %s persisting drift(s)\n", boldWriter.Sprintf("%d", baseline.Persisting.Count()))
+	fmt.Printf(" - %s resolved drift(s)\n", successWriter.Sprintf("%d", baseline.Resolved.Count()))
+	c.writeBaselineDrifts(baseline.Resolved)
+}
+
+func (c Console) writeBaselineDrifts(drifts analyser.Drifts) {
+	for _, res := range drifts.Unmanaged {
+		fmt.Printf("     - %s (%s, not managed by Terraform)\n", res.ResourceId(), res.ResourceType())
+	}
+	for _, res := range drifts.Deleted {
+		fmt.Printf("     - %s (%s, missing on the cloud provider)\n", res.ResourceId(), res.ResourceType())
+	}
+	for _, difference := range drifts.Differences {
+		fmt.Printf("     - %s (%s, out of sync with Terraform state)\n", difference.Res.ResourceId(), difference.Res.ResourceType())
+	}
+}
+
 func prettify(resource interface{}) string {
 	res := reflect.ValueOf(resource)
 	if resource == nil || res.Kind() == reflect.Ptr && res.IsNil() {
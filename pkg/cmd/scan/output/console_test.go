@@ -66,6 +66,46 @@ func TestConsole_Write(t *testing.T) {
 			}()},
 			wantErr: false,
 		},
+		{
+			name:       "test console output with accounts",
+			goldenfile: "output_accounts.txt",
+			args: args{analysis: func() *analyser.Analysis {
+				a := &analyser.Analysis{}
+				a.AddManaged(
+					&resource.Resource{
+						Id:      "test-id-1",
+						Type:    "aws_test_resource",
+						Account: "111111111111",
+					},
+				)
+				a.AddUnmanaged(
+					&resource.Resource{
+						Id:      "test-id-2",
+						Type:    "aws_test_resource",
+						Account: "222222222222",
+					},
+					&resource.Resource{
+						Id:      "test-id-3",
+						Type:    "aws_test_resource",
+						Account: "111111111111",
+					},
+				)
+				return a
+			}()},
+			wantErr: false,
+		},
+		{
+			name:       "test console output with baseline",
+			goldenfile: "output_baseline.txt",
+			args:       args{analysis: fakeAnalysisWithBaseline()},
+			wantErr:    false,
+		},
+		{
+			name:       "test console output with severities",
+			goldenfile: "output_severities.txt",
+			args:       args{analysis: fakeAnalysisWithSeverities()},
+			wantErr:    false,
+		},
 		{
 			name:       "test console output no drift",
 			goldenfile: "output_no_drift.txt",
@@ -0,0 +1,212 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/cloudskiff/driftctl/pkg/analyser"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+const CSVOutputType = "csv"
+const CSVOutputExample = "csv://PATH/TO/FILE.csv"
+
+// CSVOptionColumns selects the columns of the file, as a comma separated list
+const CSVOptionColumns = "columns"
+
+const (
+	CSVColumnStatus   = "status"
+	CSVColumnType     = "type"
+	CSVColumnId       = "id"
+	CSVColumnSource   = "source"
+	CSVColumnModule   = "module"
+	CSVColumnName     = "name"
+	CSVColumnRegion   = "region"
+	CSVColumnAccount  = "account"
+	CSVColumnChanges  = "changes"
+	CSVColumnSeverity = "severity"
+)
+
+var supportedCSVColumns = []string{
+	CSVColumnStatus,
+	CSVColumnType,
+	CSVColumnId,
+	CSVColumnSource,
+	CSVColumnModule,
+	CSVColumnName,
+	CSVColumnRegion,
+	CSVColumnAccount,
+	CSVColumnChanges,
+	CSVColumnSeverity,
+}
+
+var defaultCSVColumns = []string{
+	CSVColumnStatus,
+	CSVColumnType,
+	CSVColumnId,
+	CSVColumnSource,
+	CSVColumnModule,
+	CSVColumnChanges,
+	CSVColumnSeverity,
+}
+
+type csvRow struct {
+	status   string
+	res      *resource.Resource
+	changes  []string
+	severity analyser.Severity
+}
+
+func (r csvRow) column(name string) string {
+	switch name {
+	case CSVColumnStatus:
+		return r.status
+	case CSVColumnType:
+		return r.res.ResourceType()
+	case CSVColumnId:
+		return r.res.ResourceId()
+	case CSVColumnSource:
+		if r.res.Source != nil {
+			return r.res.Src().Source()
+		}
+	case CSVColumnModule:
+		if r.res.Source != nil {
+			return r.res.Src().Namespace()
+		}
+	case CSVColumnName:
+		if r.res.Source != nil {
+			return r.res.Src().InternalName()
+		}
+	case CSVColumnRegion:
+		return r.res.Region
+	case CSVColumnAccount:
+		return r.res.Account
+	case CSVColumnChanges:
+		return strings.Join(r.changes, ";")
+	case CSVColumnSeverity:
+		return r.severity.String()
+	}
+	return ""
+}
+
+type CSV struct {
+	path    string
+	columns []string
+	file    *os.File
+	writer  *csv.Writer
+}
+
+func NewCSV(path string, columns []string) *CSV {
+	return &CSV{path: path, columns: columns}
+}
+
+func (c *CSV) Write(analysis *analyser.Analysis) error {
+	if err := c.WritePartial(analysis); err != nil {
+		return err
+	}
+	return c.Close()
+}
+
+// WritePartial appends the rows of a partial analysis to the file, the header being written first
+func (c *CSV) WritePartial(analysis *analyser.Analysis) error {
+	if err := c.open(); err != nil {
+		return err
+	}
+
+	var rows []csvRow
+	changed := make(map[string]struct{}, len(analysis.Differences()))
+	for _, difference := range analysis.Differences() {
+		changed[resourceKey(difference.Res)] = struct{}{}
+		paths := make([]string, 0, len(difference.Changelog))
+		for _, change := range difference.Changelog {
+			paths = append(paths, strings.Join(change.Path, "."))
+		}
+		rows = append(rows, csvRow{status: "changed", res: difference.Res, changes: paths, severity: analysis.Severity(difference.Res)})
+	}
+	for _, res := range analysis.Managed() {
+		if _, exist := changed[resourceKey(res)]; exist {
+			continue
+		}
+		rows = append(rows, csvRow{status: "managed", res: res})
+	}
+	for _, res := range analysis.Unmanaged() {
+		rows = append(rows, csvRow{status: "unmanaged", res: res, severity: analysis.Severity(res)})
+	}
+	for _, res := range analysis.Deleted() {
+		rows = append(rows, csvRow{status: "missing", res: res, severity: analysis.Severity(res)})
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].res.ResourceType() != rows[j].res.ResourceType() {
+			return rows[i].res.ResourceType() < rows[j].res.ResourceType()
+		}
+		return rows[i].res.ResourceId() < rows[j].res.ResourceId()
+	})
+
+	for _, row := range rows {
+		record := make([]string, 0, len(c.columns))
+		for _, column := range c.columns {
+			record = append(record, row.column(column))
+		}
+		if err := c.writer.Write(record); err != nil {
+			return err
+		}
+	}
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// Close ends the file, it only contains the header when no analysis was written
+func (c *CSV) Close() error {
+	if err := c.open(); err != nil {
+		return err
+	}
+	c.writer.Flush()
+	err := c.writer.Error()
+	if c.file != os.Stdout {
+		if closeErr := c.file.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	c.file, c.writer = nil, nil
+	return err
+}
+
+func (c *CSV) open() error {
+	if c.writer != nil {
+		return nil
+	}
+
+	file := os.Stdout
+	if !isStdOut(c.path) {
+		f, err := os.OpenFile(c.path, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+		file = f
+	}
+
+	c.file = file
+	c.writer = csv.NewWriter(file)
+	return c.writer.Write(c.columns)
+}
+
+// CSVColumns reads the selected columns from the output options
+func CSVColumns(options map[string]string) ([]string, error) {
+	value, exist := options[CSVOptionColumns]
+	if !exist {
+		return defaultCSVColumns, nil
+	}
+
+	var columns []string
+	for _, column := range strings.Split(value, ",") {
+		column = strings.TrimSpace(column)
+		if !contains(supportedCSVColumns, column) {
+			return nil, fmt.Errorf("unsupported column '%s', supported columns are: %s", column, strings.Join(supportedCSVColumns, ","))
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
@@ -0,0 +1,153 @@
+package output
+
+import (
+	"io/ioutil"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudskiff/driftctl/pkg/analyser"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/cloudskiff/driftctl/test/goldenfile"
+)
+
+func TestCSV_Write(t *testing.T) {
+	tests := []struct {
+		name       string
+		goldenfile string
+		analysis   func() *analyser.Analysis
+		columns    []string
+		wantErr    bool
+	}{
+		{
+			name:       "test csv output",
+			goldenfile: "output.csv",
+			analysis:   fakeAnalysis,
+			columns:    defaultCSVColumns,
+			wantErr:    false,
+		},
+		{
+			name:       "test csv output with severities",
+			goldenfile: "output_severities.csv",
+			analysis:   fakeAnalysisWithSeverities,
+			columns:    defaultCSVColumns,
+			wantErr:    false,
+		},
+		{
+			name:       "test csv output when no infra",
+			goldenfile: "output_empty.csv",
+			analysis:   func() *analyser.Analysis { return &analyser.Analysis{} },
+			columns:    defaultCSVColumns,
+			wantErr:    false,
+		},
+		{
+			name:       "test csv output with selected columns",
+			goldenfile: "output_columns.csv",
+			analysis: func() *analyser.Analysis {
+				a := &analyser.Analysis{}
+				a.AddUnmanaged(&resource.Resource{
+					Id:      "queue",
+					Type:    "aws_sqs_queue",
+					Region:  "eu-west-3",
+					Account: "123456789012",
+				})
+				a.AddDeleted(&resource.Resource{
+					Id:     "bucket",
+					Type:   "aws_s3_bucket",
+					Region: "us-east-1",
+					Source: resource.NewTerraformStateSource("tfstate://terraform.tfstate", "module.logs", "logs"),
+				})
+				return a
+			},
+			columns: []string{CSVColumnAccount, CSVColumnRegion, CSVColumnId, CSVColumnName, CSVColumnStatus},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			tempFile, err := ioutil.TempFile(tempDir, "result")
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := NewCSV(tempFile.Name(), tt.columns)
+			if err := c.Write(tt.analysis()); (err != nil) != tt.wantErr {
+				t.Errorf("Write() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			result, err := ioutil.ReadFile(tempFile.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+			expectedFilePath := path.Join("./testdata/", tt.goldenfile)
+			if *goldenfile.Update == tt.goldenfile {
+				if err := ioutil.WriteFile(expectedFilePath, result, 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := ioutil.ReadFile(expectedFilePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, string(expected), string(result))
+		})
+	}
+}
+
+func TestCSV_WritePartial(t *testing.T) {
+	file := path.Join(t.TempDir(), "result.csv")
+	c := NewCSV(file, []string{CSVColumnStatus, CSVColumnType, CSVColumnId})
+
+	queues := &analyser.Analysis{}
+	queues.AddUnmanaged(&resource.Resource{Id: "queue", Type: "aws_sqs_queue"})
+	assert.NoError(t, c.WritePartial(queues))
+
+	// Rows are flushed as soon as they are written
+	result, err := ioutil.ReadFile(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "status,type,id\nunmanaged,aws_sqs_queue,queue\n", string(result))
+
+	buckets := &analyser.Analysis{}
+	buckets.AddDeleted(&resource.Resource{Id: "bucket", Type: "aws_s3_bucket"})
+	assert.NoError(t, c.WritePartial(buckets))
+	assert.NoError(t, c.Close())
+
+	result, err = ioutil.ReadFile(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "status,type,id\nunmanaged,aws_sqs_queue,queue\nmissing,aws_s3_bucket,bucket\n", string(result))
+}
+
+func TestCSVColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]string
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "default columns",
+			want: []string{"status", "type", "id", "source", "module", "changes", "severity"},
+		},
+		{
+			name:    "selected columns",
+			options: map[string]string{CSVOptionColumns: "type, id,account"},
+			want:    []string{"type", "id", "account"},
+		},
+		{
+			name:    "unsupported column",
+			options: map[string]string{CSVOptionColumns: "type,arn"},
+			wantErr: "unsupported column 'arn', supported columns are: status,type,id,source,module,name,region,account,changes,severity",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CSVColumns(tt.options)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
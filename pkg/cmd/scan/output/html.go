@@ -41,6 +41,7 @@ type HTMLTemplateParams struct {
 	Differences     []analyser.Difference
 	Deleted         []*resource.Resource
 	Alerts          alerter.Alerts
+	Baseline        *analyser.Baseline
 	Stylesheet      template.CSS
 	ScanDuration    string
 	ProviderName    string
@@ -110,6 +111,17 @@ func (c *HTML) Write(analysis *analyser.Analysis) error {
 			rate := 100 * float64(count) / float64(analysis.Summary().TotalResources)
 			return math.Floor(rate*100) / 100
 		},
+		"baselineBadge": func(res *resource.Resource) template.HTML {
+			status := analysis.Baseline().Status(res)
+			return template.HTML(fmt.Sprintf("<span class=\"baseline-%s\">%s</span>", status, status))
+		},
+		"severityBadge": func(res *resource.Resource) template.HTML {
+			severity := analysis.Severity(res)
+			if severity == 0 {
+				return ""
+			}
+			return template.HTML(fmt.Sprintf(" <span class=\"severity-%s\">%s</span>", severity, severity))
+		},
 		"jsonDiff": func(ch analyser.Changelog) template.HTML {
 			var buf bytes.Buffer
 
@@ -162,6 +174,7 @@ func (c *HTML) Write(analysis *analyser.Analysis) error {
 		Differences:     analysis.Differences(),
 		Deleted:         analysis.Deleted(),
 		Alerts:          analysis.Alerts(),
+		Baseline:        analysis.Baseline(),
 		Stylesheet:      template.CSS(styleFile),
 		ScanDuration:    analysis.Duration.Round(time.Second).String(),
 		ProviderName:    analysis.ProviderName,
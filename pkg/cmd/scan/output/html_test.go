@@ -52,6 +52,28 @@ func TestHTML_Write(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			name:       "test html output with baseline",
+			goldenfile: "output_baseline.html",
+			analysis: func() *analyser.Analysis {
+				a := fakeAnalysisWithBaseline()
+				a.Date = time.Date(2021, 06, 10, 0, 0, 0, 0, &time.Location{})
+				a.Duration = 91 * time.Second
+				return a
+			},
+			err: nil,
+		},
+		{
+			name:       "test html output with severities",
+			goldenfile: "output_severities.html",
+			analysis: func() *analyser.Analysis {
+				a := fakeAnalysisWithSeverities()
+				a.Date = time.Date(2021, 06, 10, 0, 0, 0, 0, &time.Location{})
+				a.Duration = 91 * time.Second
+				return a
+			},
+			err: nil,
+		},
 		{
 			name:       "test html output",
 			goldenfile: "output.html",
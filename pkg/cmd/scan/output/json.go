@@ -1,8 +1,11 @@
 package output
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/cloudskiff/driftctl/pkg/analyser"
 )
@@ -10,12 +13,16 @@ import (
 const JSONOutputType = "json"
 const JSONOutputExample = "json://PATH/TO/FILE.json"
 
+// JSONOptionAttributes writes the attributes of resources when true, values of sensitive attributes are masked
+const JSONOptionAttributes = "attributes"
+
 type JSON struct {
-	path string
+	path       string
+	attributes bool
 }
 
-func NewJSON(path string) *JSON {
-	return &JSON{path}
+func NewJSON(path string, attributes bool) *JSON {
+	return &JSON{path, attributes}
 }
 
 func (c *JSON) Write(analysis *analyser.Analysis) error {
@@ -29,12 +36,33 @@ func (c *JSON) Write(analysis *analyser.Analysis) error {
 		file = f
 	}
 
-	json, err := json.MarshalIndent(analysis, "", "\t")
+	marshal := analysis.MarshalJSON
+	if c.attributes {
+		marshal = analysis.MarshalJSONWithAttributes
+	}
+	content, err := marshal()
 	if err != nil {
 		return err
 	}
-	if _, err := file.Write(json); err != nil {
+	var output bytes.Buffer
+	if err := json.Indent(&output, content, "", "\t"); err != nil {
+		return err
+	}
+	if _, err := file.Write(output.Bytes()); err != nil {
 		return err
 	}
 	return nil
 }
+
+// JSONAttributes tells from the output options whether the attributes of resources are written
+func JSONAttributes(options map[string]string) (bool, error) {
+	value, exist := options[JSONOptionAttributes]
+	if !exist {
+		return false, nil
+	}
+	attributes, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s '%s', must be true or false", JSONOptionAttributes, value)
+	}
+	return attributes, nil
+}
@@ -21,6 +21,7 @@ func TestJSON_Write(t *testing.T) {
 	tests := []struct {
 		name       string
 		goldenfile string
+		attributes bool
 		args       args
 		wantErr    bool
 	}{
@@ -32,6 +33,22 @@ func TestJSON_Write(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:       "test json output with baseline",
+			goldenfile: "output_baseline.json",
+			args: args{
+				analysis: fakeAnalysisWithBaseline(),
+			},
+			wantErr: false,
+		},
+		{
+			name:       "test json output with severities",
+			goldenfile: "output_severities.json",
+			args: args{
+				analysis: fakeAnalysisWithSeverities(),
+			},
+			wantErr: false,
+		},
 		{
 			name:       "test json output with drift on computed fields",
 			goldenfile: "output_computed_fields.json",
@@ -40,6 +57,15 @@ func TestJSON_Write(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:       "test json output with attributes",
+			goldenfile: "output_attributes.json",
+			attributes: true,
+			args: args{
+				analysis: fakeAnalysisWithStringerResources(),
+			},
+			wantErr: false,
+		},
 		{
 			name:       "test json output with AWS enumeration alerts",
 			goldenfile: "output_access_denied_alert_aws.json",
@@ -64,7 +90,7 @@ func TestJSON_Write(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
-			c := NewJSON(tempFile.Name())
+			c := NewJSON(tempFile.Name(), tt.attributes)
 			if err := c.Write(tt.args.analysis); (err != nil) != tt.wantErr {
 				t.Errorf("Write() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -125,7 +151,7 @@ func TestJSON_Write_stdout(t *testing.T) {
 			r, w, _ := os.Pipe()
 			os.Stdout = w
 
-			c := NewJSON(tt.path)
+			c := NewJSON(tt.path, false)
 			if err := c.Write(tt.args.analysis); (err != nil) != tt.wantErr {
 				t.Errorf("Write() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -166,7 +192,7 @@ func TestJSON_WriteMultiplesTimesInSameFile(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	c := NewJSON(tempFile.Name())
+	c := NewJSON(tempFile.Name(), false)
 
 	if err := c.Write(longerAnalysis); err != nil {
 		t.Errorf("First write error = %v", err)
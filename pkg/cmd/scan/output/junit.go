@@ -0,0 +1,180 @@
+package output
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/r3labs/diff/v2"
+
+	"github.com/cloudskiff/driftctl/pkg/analyser"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+const JUnitOutputType = "junit"
+const JUnitOutputExample = "junit://PATH/TO/FILE.xml"
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr,omitempty"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",cdata"`
+}
+
+type JUnit struct {
+	path string
+}
+
+func NewJUnit(path string) *JUnit {
+	return &JUnit{path}
+}
+
+func (c *JUnit) Write(analysis *analyser.Analysis) error {
+	file := os.Stdout
+	if !isStdOut(c.path) {
+		f, err := os.OpenFile(c.path, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		file = f
+	}
+
+	cases := make(map[string][]junitTestCase)
+	changed := make(map[string]struct{}, len(analysis.Differences()))
+	for _, difference := range analysis.Differences() {
+		changed[resourceKey(difference.Res)] = struct{}{}
+		cases[difference.Res.ResourceType()] = append(cases[difference.Res.ResourceType()], newJUnitTestCase(difference.Res, &junitFailure{
+			Message: junitMessage("Resource changed outside of IaC", analysis.Severity(difference.Res)),
+			Type:    "changed",
+			Text:    junitChangelog(difference.Changelog),
+		}))
+	}
+	for _, res := range analysis.Managed() {
+		if _, exist := changed[resourceKey(res)]; exist {
+			continue
+		}
+		cases[res.ResourceType()] = append(cases[res.ResourceType()], newJUnitTestCase(res, nil))
+	}
+	for _, res := range analysis.Deleted() {
+		cases[res.ResourceType()] = append(cases[res.ResourceType()], newJUnitTestCase(res, &junitFailure{
+			Message: junitMessage("Resource managed by IaC is missing from the cloud provider", analysis.Severity(res)),
+			Type:    "missing",
+		}))
+	}
+	for _, res := range analysis.Unmanaged() {
+		cases[res.ResourceType()] = append(cases[res.ResourceType()], newJUnitTestCase(res, &junitFailure{
+			Message: junitMessage("Resource is not managed by IaC", analysis.Severity(res)),
+			Type:    "unmanaged",
+		}))
+	}
+
+	types := make([]string, 0, len(cases))
+	for ty := range cases {
+		types = append(types, ty)
+	}
+	sort.Strings(types)
+
+	report := junitTestSuites{Name: "driftctl"}
+	if analysis.Duration > 0 {
+		report.Time = fmt.Sprintf("%.3f", analysis.Duration.Seconds())
+	}
+	for _, ty := range types {
+		suite := junitTestSuite{Name: ty, TestCases: cases[ty]}
+		sort.SliceStable(suite.TestCases, func(i, j int) bool {
+			return suite.TestCases[i].Name < suite.TestCases[j].Name
+		})
+		for _, testCase := range suite.TestCases {
+			suite.Tests++
+			if testCase.Failure != nil {
+				suite.Failures++
+			}
+		}
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Suites = append(report.Suites, suite)
+	}
+
+	output, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(xml.Header); err != nil {
+		return err
+	}
+	if _, err := file.Write(output); err != nil {
+		return err
+	}
+	return nil
+}
+
+func newJUnitTestCase(res *resource.Resource, failure *junitFailure) junitTestCase {
+	testCase := junitTestCase{
+		Name:      res.ResourceId(),
+		Classname: res.ResourceType(),
+		Failure:   failure,
+	}
+	if res.Source != nil {
+		testCase.File = strings.TrimPrefix(res.Src().Source(), "tfstate://")
+	}
+	if location := resourceLocation(res); location != "" {
+		testCase.Name = fmt.Sprintf("%s (%s)", testCase.Name, location)
+	}
+	return testCase
+}
+
+// junitMessage appends the severity of the drift to a failure message, when a severity policy was applied
+func junitMessage(message string, severity analyser.Severity) string {
+	if severity == 0 {
+		return message
+	}
+	return fmt.Sprintf("%s [%s]", message, severity)
+}
+
+// junitChangelog renders a changelog like the console output does, without colors
+func junitChangelog(changelog analyser.Changelog) string {
+	var lines []string
+	for _, change := range changelog {
+		path := strings.Join(change.Path, ".")
+		pref := fmt.Sprintf("~ %s:", path)
+		if change.Type == diff.CREATE {
+			pref = fmt.Sprintf("+ %s:", path)
+		} else if change.Type == diff.DELETE {
+			pref = fmt.Sprintf("- %s:", path)
+		}
+		if change.Type == diff.UPDATE && change.JsonString {
+			lines = append(lines, fmt.Sprintf("%s\n%s", pref, jsonDiff(change.From, change.To, false)))
+			continue
+		}
+		line := fmt.Sprintf("%s %s => %s", pref, prettify(change.From), prettify(change.To))
+		if change.Computed {
+			line += " (computed)"
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
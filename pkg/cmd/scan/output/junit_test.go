@@ -0,0 +1,90 @@
+package output
+
+import (
+	"io/ioutil"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudskiff/driftctl/pkg/analyser"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/cloudskiff/driftctl/test/goldenfile"
+)
+
+func TestJUnit_Write(t *testing.T) {
+	tests := []struct {
+		name       string
+		goldenfile string
+		analysis   func() *analyser.Analysis
+		wantErr    bool
+	}{
+		{
+			name:       "test junit output",
+			goldenfile: "output_junit.xml",
+			analysis:   fakeAnalysis,
+			wantErr:    false,
+		},
+		{
+			name:       "test junit output with severities",
+			goldenfile: "output_junit_severities.xml",
+			analysis:   fakeAnalysisWithSeverities,
+			wantErr:    false,
+		},
+		{
+			name:       "test junit output without drift",
+			goldenfile: "output_junit_no_drift.xml",
+			analysis:   fakeAnalysisNoDrift,
+			wantErr:    false,
+		},
+		{
+			name:       "test junit output with regions and accounts",
+			goldenfile: "output_junit_accounts.xml",
+			analysis: func() *analyser.Analysis {
+				a := &analyser.Analysis{}
+				a.AddUnmanaged(&resource.Resource{
+					Id:      "queue",
+					Type:    "aws_sqs_queue",
+					Region:  "eu-west-3",
+					Account: "123456789012",
+				})
+				a.AddDeleted(&resource.Resource{
+					Id:     "bucket",
+					Type:   "aws_s3_bucket",
+					Region: "us-east-1",
+					Source: resource.NewTerraformStateSource("tfstate+s3://bucket/terraform.tfstate", "", "logs"),
+				})
+				return a
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			tempFile, err := ioutil.TempFile(tempDir, "result")
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := NewJUnit(tempFile.Name())
+			if err := c.Write(tt.analysis()); (err != nil) != tt.wantErr {
+				t.Errorf("Write() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			result, err := ioutil.ReadFile(tempFile.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+			expectedFilePath := path.Join("./testdata/", tt.goldenfile)
+			if *goldenfile.Update == tt.goldenfile {
+				if err := ioutil.WriteFile(expectedFilePath, result, 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := ioutil.ReadFile(expectedFilePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, string(expected), string(result))
+		})
+	}
+}
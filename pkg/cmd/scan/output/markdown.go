@@ -0,0 +1,293 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/r3labs/diff/v2"
+
+	"github.com/cloudskiff/driftctl/pkg/analyser"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+const MarkdownOutputType = "markdown"
+const MarkdownOutputExample = "markdown://PATH/TO/FILE.md"
+
+// MarkdownOptionMaxSize limits the size in bytes of the generated document, 0 disables the limit
+const MarkdownOptionMaxSize = "max-size"
+
+// MarkdownDefaultMaxSize keeps the document postable as a GitHub pull request comment, which is limited
+// to 65536 characters: a document of at most 65536 bytes never holds more characters than that
+const MarkdownDefaultMaxSize = 65536
+
+// MarkdownMinMaxSize is the smallest size limit, the summary and the truncation notice are always written
+const MarkdownMinMaxSize = 512
+
+// markdownNoticeReserve is kept free at the end of a truncated document to explain what was left out
+const markdownNoticeReserve = 200
+
+type Markdown struct {
+	path    string
+	maxSize int
+}
+
+func NewMarkdown(path string, maxSize int) *Markdown {
+	return &Markdown{path, maxSize}
+}
+
+// markdownSection is a collapsible block listing the resources of a drift category, grouped by resource type.
+// Blocks without type are listed without heading.
+type markdownSection struct {
+	title  string
+	types  []string
+	blocks map[string][]string
+}
+
+func (s markdownSection) count() int {
+	count := 0
+	for _, blocks := range s.blocks {
+		count += len(blocks)
+	}
+	return count
+}
+
+// firstBlock returns the first block of the section, with its heading
+func (s markdownSection) firstBlock() string {
+	for _, ty := range s.types {
+		if len(s.blocks[ty]) > 0 {
+			return markdownHeading(ty) + s.blocks[ty][0]
+		}
+	}
+	return ""
+}
+
+func (c *Markdown) Write(analysis *analyser.Analysis) error {
+	file := os.Stdout
+	if !isStdOut(c.path) {
+		f, err := os.OpenFile(c.path, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		file = f
+	}
+
+	w := &markdownWriter{maxSize: c.maxSize}
+	w.WriteString(markdownSummary(analysis))
+
+	w.writeSection(markdownDifferencesSection(analysis, "Changed resources", analysis.Differences()))
+	w.writeSection(markdownResourcesSection(analysis, "Missing resources", analysis.Deleted()))
+	w.writeSection(markdownResourcesSection(analysis, "Unmanaged resources", analysis.Unmanaged()))
+	w.writeSection(markdownAlertsSection(analysis))
+
+	if w.skipped > 0 {
+		w.WriteString(fmt.Sprintf(
+			"> :warning: Output truncated to %d bytes, %d entries not shown. Use another output format to get the full scan result.\n",
+			c.maxSize,
+			w.skipped,
+		))
+	}
+
+	if _, err := file.WriteString(w.String()); err != nil {
+		return err
+	}
+	return nil
+}
+
+func markdownSummary(analysis *analyser.Analysis) string {
+	var b strings.Builder
+	b.WriteString("## driftctl scan result\n\n")
+	if analysis.IsSync() {
+		b.WriteString(":white_check_mark: Your infrastructure is fully in sync.\n\n")
+	} else {
+		b.WriteString(":warning: Drift detected.\n\n")
+	}
+
+	summary := analysis.Summary()
+	b.WriteString("| Resources | Coverage | Managed | Changed | Unmanaged | Missing |\n")
+	b.WriteString("|---|---|---|---|---|---|\n")
+	_, _ = fmt.Fprintf(
+		&b,
+		"| %d | %d%% | %d | %d | %d | %d |\n\n",
+		summary.TotalResources,
+		analysis.Coverage(),
+		summary.TotalManaged,
+		summary.TotalDrifted,
+		summary.TotalUnmanaged,
+		summary.TotalDeleted,
+	)
+	return b.String()
+}
+
+func markdownResourcesSection(analysis *analyser.Analysis, title string, resources []*resource.Resource) markdownSection {
+	byType, types := groupByType(resources)
+	section := markdownSection{title: title, types: types, blocks: make(map[string][]string, len(byType))}
+	for _, ty := range types {
+		for _, res := range byType[ty] {
+			block := fmt.Sprintf("- `%s`", res.ResourceId())
+			if res.SourceString() != "" {
+				block += fmt.Sprintf(" (%s)", res.SourceString())
+			}
+			block += markdownSeverity(analysis.Severity(res))
+			section.blocks[ty] = append(section.blocks[ty], block+"\n")
+		}
+	}
+	return section
+}
+
+func markdownDifferencesSection(analysis *analyser.Analysis, title string, differences []analyser.Difference) markdownSection {
+	section := markdownSection{title: title, blocks: make(map[string][]string)}
+	for _, difference := range differences {
+		ty := difference.Res.ResourceType()
+		if _, exist := section.blocks[ty]; !exist {
+			section.types = append(section.types, ty)
+		}
+		section.blocks[ty] = append(section.blocks[ty], markdownDifference(difference, analysis.Severity(difference.Res)))
+	}
+	sort.Strings(section.types)
+	return section
+}
+
+func markdownDifference(difference analyser.Difference, severity analyser.Severity) string {
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "- `%s`", difference.Res.ResourceId())
+	if difference.Res.SourceString() != "" {
+		_, _ = fmt.Fprintf(&b, " (%s)", difference.Res.SourceString())
+	}
+	b.WriteString(markdownSeverity(severity))
+	b.WriteString("\n\n")
+	b.WriteString("  | Attribute | Change | Before | After |\n")
+	b.WriteString("  |---|---|---|---|\n")
+	for _, change := range difference.Changelog {
+		action := "~"
+		if change.Type == diff.CREATE {
+			action = "+"
+		} else if change.Type == diff.DELETE {
+			action = "-"
+		}
+		after := markdownCode(prettify(change.To))
+		if change.Computed {
+			after += " _(computed)_"
+		}
+		_, _ = fmt.Fprintf(
+			&b,
+			"  | %s | %s | %s | %s |\n",
+			markdownCode(strings.Join(change.Path, ".")),
+			action,
+			markdownCode(prettify(change.From)),
+			after,
+		)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// markdownSeverity returns the severity of a drift to display after a resource, nothing when no severity policy was applied
+func markdownSeverity(severity analyser.Severity) string {
+	if severity == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" **%s**", severity)
+}
+
+func markdownAlertsSection(analysis *analyser.Analysis) markdownSection {
+	section := markdownSection{title: "Alerts", blocks: make(map[string][]string)}
+	for key, alerts := range analysis.Alerts() {
+		for _, alert := range alerts {
+			section.blocks[key] = append(section.blocks[key], fmt.Sprintf("- %s\n", markdownEscapeCell(alert.Message())))
+		}
+		section.types = append(section.types, key)
+	}
+	sort.Strings(section.types)
+	return section
+}
+
+// markdownCode renders a value as inline code that can be used in a table cell
+func markdownCode(value string) string {
+	return "`" + markdownEscapeCell(strings.ReplaceAll(value, "`", "'")) + "`"
+}
+
+func markdownEscapeCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.ReplaceAll(value, "\n", " ")
+}
+
+// markdownWriter stops writing entries once the maximum size is reached, keeping the document well formed
+type markdownWriter struct {
+	strings.Builder
+	maxSize int
+	skipped int
+}
+
+func (w *markdownWriter) fits(content string) bool {
+	if w.maxSize <= 0 {
+		return true
+	}
+	if w.skipped > 0 {
+		return false
+	}
+	return w.Len()+len(content)+markdownNoticeReserve <= w.maxSize
+}
+
+func (w *markdownWriter) writeSection(section markdownSection) {
+	count := section.count()
+	if count == 0 {
+		return
+	}
+
+	open := fmt.Sprintf("<details>\n<summary>%s (%d)</summary>\n\n", section.title, count)
+	end := "</details>\n\n"
+	// Do not open a section none of the entries fits in
+	if !w.fits(open + section.firstBlock() + end) {
+		w.skipped += count
+		return
+	}
+	w.WriteString(open)
+
+	for _, ty := range section.types {
+		written := ""
+		for i, block := range section.blocks[ty] {
+			if i == 0 {
+				block = markdownHeading(ty) + block
+			}
+			if !w.fits(block + end) {
+				w.skipped += len(section.blocks[ty]) - i
+				break
+			}
+			w.WriteString(block)
+			written = block
+		}
+		// Lists must be followed by a blank line to be closed
+		if written != "" && !strings.HasSuffix(written, "\n\n") {
+			w.WriteString("\n")
+		}
+	}
+
+	w.WriteString(end)
+}
+
+func markdownHeading(ty string) string {
+	if ty == "" {
+		return ""
+	}
+	return fmt.Sprintf("#### %s\n\n", ty)
+}
+
+// MarkdownMaxSize reads the maximum size of the document from the output options
+func MarkdownMaxSize(options map[string]string) (int, error) {
+	value, exist := options[MarkdownOptionMaxSize]
+	if !exist {
+		return MarkdownDefaultMaxSize, nil
+	}
+	size, err := strconv.Atoi(value)
+	if err != nil || size < 0 {
+		return 0, fmt.Errorf("invalid %s '%s', must be a positive number of bytes", MarkdownOptionMaxSize, value)
+	}
+	if size > 0 && size < MarkdownMinMaxSize {
+		return 0, fmt.Errorf("invalid %s '%s', must be at least %d bytes", MarkdownOptionMaxSize, value, MarkdownMinMaxSize)
+	}
+	return size, nil
+}
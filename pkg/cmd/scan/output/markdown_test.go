@@ -0,0 +1,148 @@
+package output
+
+import (
+	"io/ioutil"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudskiff/driftctl/pkg/analyser"
+	"github.com/cloudskiff/driftctl/test/goldenfile"
+)
+
+func TestMarkdown_Write(t *testing.T) {
+	tests := []struct {
+		name       string
+		goldenfile string
+		analysis   *analyser.Analysis
+		maxSize    int
+		wantErr    bool
+	}{
+		{
+			name:       "test markdown output",
+			goldenfile: "output.md",
+			analysis:   fakeAnalysis(),
+			maxSize:    MarkdownDefaultMaxSize,
+			wantErr:    false,
+		},
+		{
+			name:       "test markdown output with severities",
+			goldenfile: "output_severities.md",
+			analysis:   fakeAnalysisWithSeverities(),
+			maxSize:    MarkdownDefaultMaxSize,
+			wantErr:    false,
+		},
+		{
+			name:       "test markdown output without drift",
+			goldenfile: "output_no_drift.md",
+			analysis:   fakeAnalysisNoDrift(),
+			maxSize:    MarkdownDefaultMaxSize,
+			wantErr:    false,
+		},
+		{
+			name:       "test markdown output with alerts",
+			goldenfile: "output_alerts.md",
+			analysis:   fakeAnalysisWithAlerts(),
+			maxSize:    MarkdownDefaultMaxSize,
+			wantErr:    false,
+		},
+		{
+			name:       "test markdown output truncated",
+			goldenfile: "output_truncated.md",
+			analysis:   fakeAnalysis(),
+			maxSize:    1000,
+			wantErr:    false,
+		},
+		{
+			name:       "test markdown output truncated to the minimum size",
+			goldenfile: "output_truncated_min.md",
+			analysis:   fakeAnalysisWithSeverities(),
+			maxSize:    MarkdownMinMaxSize,
+			wantErr:    false,
+		},
+		{
+			name:       "test markdown output without size limit",
+			goldenfile: "output.md",
+			analysis:   fakeAnalysis(),
+			maxSize:    0,
+			wantErr:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			tempFile, err := ioutil.TempFile(tempDir, "result")
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := NewMarkdown(tempFile.Name(), tt.maxSize)
+			if err := c.Write(tt.analysis); (err != nil) != tt.wantErr {
+				t.Errorf("Write() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			result, err := ioutil.ReadFile(tempFile.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.maxSize > 0 {
+				assert.LessOrEqual(t, len(result), tt.maxSize)
+			}
+			expectedFilePath := path.Join("./testdata/", tt.goldenfile)
+			if *goldenfile.Update == tt.goldenfile {
+				if err := ioutil.WriteFile(expectedFilePath, result, 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := ioutil.ReadFile(expectedFilePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, string(expected), string(result))
+		})
+	}
+}
+
+func TestMarkdownMaxSize(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]string
+		want    int
+		wantErr string
+	}{
+		{
+			name: "default size",
+			want: MarkdownDefaultMaxSize,
+		},
+		{
+			name:    "custom size",
+			options: map[string]string{MarkdownOptionMaxSize: "1000"},
+			want:    1000,
+		},
+		{
+			name:    "invalid size",
+			options: map[string]string{MarkdownOptionMaxSize: "-1"},
+			wantErr: "invalid max-size '-1', must be a positive number of bytes",
+		},
+		{
+			name:    "size smaller than the summary",
+			options: map[string]string{MarkdownOptionMaxSize: "100"},
+			wantErr: "invalid max-size '100', must be at least 512 bytes",
+		},
+		{
+			name:    "no size limit",
+			options: map[string]string{MarkdownOptionMaxSize: "0"},
+			want:    0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MarkdownMaxSize(tt.options)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
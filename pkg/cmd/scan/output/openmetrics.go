@@ -0,0 +1,265 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/cloudskiff/driftctl/pkg/analyser"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+const OpenMetricsOutputType = "openmetrics"
+const OpenMetricsOutputExample = "openmetrics://PATH/TO/FILE.prom"
+
+// OpenMetricsOptionPushgateway is the url of a Pushgateway compatible endpoint metrics are pushed to
+const OpenMetricsOptionPushgateway = "pushgateway"
+
+// OpenMetricsOptionJob is the job name used to group pushed metrics
+const OpenMetricsOptionJob = "job"
+
+const openMetricsDefaultJob = "driftctl"
+
+// Pushgateway does not support the OpenMetrics content type, the exposition is compatible with the text format
+const openMetricsPushContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+type OpenMetrics struct {
+	path        string
+	pushgateway string
+	job         string
+	client      *http.Client
+}
+
+func NewOpenMetrics(path string, options map[string]string) *OpenMetrics {
+	job := options[OpenMetricsOptionJob]
+	if job == "" {
+		job = openMetricsDefaultJob
+	}
+	return &OpenMetrics{
+		path:        path,
+		pushgateway: options[OpenMetricsOptionPushgateway],
+		job:         job,
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (c *OpenMetrics) Write(analysis *analyser.Analysis) error {
+	metrics := openMetricsExposition(analysis)
+
+	if c.path != "" {
+		file := os.Stdout
+		if !isStdOut(c.path) {
+			f, err := os.OpenFile(c.path, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0600)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			file = f
+		}
+		if _, err := file.Write(metrics); err != nil {
+			return err
+		}
+	}
+
+	if c.pushgateway != "" {
+		return c.push(metrics)
+	}
+	return nil
+}
+
+// push replaces the metrics of the job on the Pushgateway
+func (c *OpenMetrics) push(metrics []byte) error {
+	// The Pushgateway url may have a query string, e.g. to authenticate on a proxy
+	endpoint, err := url.Parse(c.pushgateway)
+	if err != nil {
+		return errors.Wrapf(err, "unable to push metrics to %s", c.pushgateway)
+	}
+	endpoint.RawPath = fmt.Sprintf("%s/metrics/job/%s", strings.TrimSuffix(endpoint.EscapedPath(), "/"), url.PathEscape(c.job))
+	endpoint.Path = fmt.Sprintf("%s/metrics/job/%s", strings.TrimSuffix(endpoint.Path, "/"), c.job)
+	req, err := http.NewRequest(http.MethodPut, endpoint.String(), bytes.NewReader(metrics))
+	if err != nil {
+		return errors.Wrapf(err, "unable to push metrics to %s", c.pushgateway)
+	}
+	req.Header.Set("Content-Type", openMetricsPushContentType)
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "unable to push metrics to %s", c.pushgateway)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return errors.Errorf("unable to push metrics to %s: %s", c.pushgateway, res.Status)
+	}
+	logrus.WithFields(logrus.Fields{
+		"pushgateway": c.pushgateway,
+		"job":         c.job,
+	}).Debug("Pushed metrics")
+	return nil
+}
+
+type openMetricsFamily struct {
+	name    string
+	help    string
+	samples []openMetricsSample
+}
+
+type openMetricsSample struct {
+	labels [][2]string
+	value  float64
+}
+
+func (f openMetricsFamily) write(buf *bytes.Buffer) {
+	_, _ = fmt.Fprintf(buf, "# TYPE %s gauge\n", f.name)
+	_, _ = fmt.Fprintf(buf, "# HELP %s %s\n", f.name, f.help)
+	for _, sample := range f.samples {
+		buf.WriteString(f.name)
+		if len(sample.labels) > 0 {
+			labels := make([]string, 0, len(sample.labels))
+			for _, label := range sample.labels {
+				labels = append(labels, fmt.Sprintf("%s=\"%s\"", label[0], openMetricsEscape(label[1])))
+			}
+			_, _ = fmt.Fprintf(buf, "{%s}", strings.Join(labels, ","))
+		}
+		_, _ = fmt.Fprintf(buf, " %s\n", strconv.FormatFloat(sample.value, 'f', -1, 64))
+	}
+}
+
+func openMetricsExposition(analysis *analyser.Analysis) []byte {
+	summaries := analysis.TypeSummaries()
+	types := make([]string, 0, len(summaries))
+	for ty := range summaries {
+		types = append(types, ty)
+	}
+	sort.Strings(types)
+
+	resources := []struct {
+		family openMetricsFamily
+		value  func(summary analyser.Summary) int
+	}{
+		{
+			openMetricsFamily{name: "driftctl_resources", help: "Number of resources found during the scan"},
+			func(summary analyser.Summary) int { return summary.TotalResources },
+		},
+		{
+			openMetricsFamily{name: "driftctl_managed_resources", help: "Number of resources managed by IaC"},
+			func(summary analyser.Summary) int { return summary.TotalManaged },
+		},
+		{
+			openMetricsFamily{name: "driftctl_unmanaged_resources", help: "Number of resources not managed by IaC"},
+			func(summary analyser.Summary) int { return summary.TotalUnmanaged },
+		},
+		{
+			openMetricsFamily{name: "driftctl_missing_resources", help: "Number of resources managed by IaC but missing from the cloud provider"},
+			func(summary analyser.Summary) int { return summary.TotalDeleted },
+		},
+		{
+			openMetricsFamily{name: "driftctl_changed_resources", help: "Number of resources managed by IaC but changed outside of it"},
+			func(summary analyser.Summary) int { return summary.TotalDrifted },
+		},
+	}
+
+	var buf bytes.Buffer
+	for _, r := range resources {
+		for _, ty := range types {
+			r.family.samples = append(r.family.samples, openMetricsSample{
+				labels: [][2]string{{"provider", resourceProvider(ty)}, {"type", ty}},
+				value:  float64(r.value(summaries[ty])),
+			})
+		}
+		r.family.write(&buf)
+	}
+
+	openMetricsFamily{
+		name:    "driftctl_coverage_percent",
+		help:    "Percentage of resources managed by IaC",
+		samples: []openMetricsSample{{value: float64(analysis.Coverage())}},
+	}.write(&buf)
+
+	openMetricsFamily{
+		name:    "driftctl_scan_duration_seconds",
+		help:    "Duration of the scan",
+		samples: []openMetricsSample{{value: analysis.Duration.Seconds()}},
+	}.write(&buf)
+
+	if !analysis.Date.IsZero() {
+		openMetricsFamily{
+			name:    "driftctl_scan_timestamp_seconds",
+			help:    "Date of the scan as a unix timestamp",
+			samples: []openMetricsSample{{value: float64(analysis.Date.Unix())}},
+		}.write(&buf)
+	}
+
+	alerts := openMetricsFamily{name: "driftctl_alerts", help: "Number of alerts raised during the scan"}
+	keys := make([]string, 0, len(analysis.Alerts()))
+	for key := range analysis.Alerts() {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		alerts.samples = append(alerts.samples, openMetricsSample{
+			labels: [][2]string{{"type", key}},
+			value:  float64(len(analysis.Alerts()[key])),
+		})
+	}
+	alerts.write(&buf)
+
+	if drifts := openMetricsDriftsBySeverity(analysis); len(drifts.samples) > 0 {
+		drifts.write(&buf)
+	}
+
+	buf.WriteString("# EOF\n")
+	return buf.Bytes()
+}
+
+// openMetricsDriftsBySeverity counts drifts by kind and severity, it has no sample when no severity policy was applied
+func openMetricsDriftsBySeverity(analysis *analyser.Analysis) openMetricsFamily {
+	family := openMetricsFamily{name: "driftctl_drifts", help: "Number of drifts by kind and severity"}
+	counts := make(map[[2]string]int)
+	count := func(kind string, res *resource.Resource) {
+		if severity := analysis.Severity(res); severity != 0 {
+			counts[[2]string{kind, severity.String()}]++
+		}
+	}
+	for _, res := range analysis.Unmanaged() {
+		count(analyser.DriftKindUnmanaged, res)
+	}
+	for _, res := range analysis.Deleted() {
+		count(analyser.DriftKindMissing, res)
+	}
+	for _, difference := range analysis.Differences() {
+		count(analyser.DriftKindChanged, difference.Res)
+	}
+
+	for _, kind := range []string{analyser.DriftKindUnmanaged, analyser.DriftKindMissing, analyser.DriftKindChanged} {
+		for _, severity := range analyser.SupportedSeverities() {
+			if value, exist := counts[[2]string{kind, severity}]; exist {
+				family.samples = append(family.samples, openMetricsSample{
+					labels: [][2]string{{"kind", kind}, {"severity", severity}},
+					value:  float64(value),
+				})
+			}
+		}
+	}
+	return family
+}
+
+// resourceProvider returns the terraform provider of a resource type, e.g. aws for aws_s3_bucket
+func resourceProvider(ty string) string {
+	return strings.SplitN(ty, "_", 2)[0]
+}
+
+func openMetricsEscape(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, "\n", `\n`)
+	return strings.ReplaceAll(value, `"`, `\"`)
+}
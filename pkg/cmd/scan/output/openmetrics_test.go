@@ -0,0 +1,148 @@
+package output
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudskiff/driftctl/pkg/analyser"
+	"github.com/cloudskiff/driftctl/test/goldenfile"
+)
+
+func fakeAnalysisForOpenMetrics() *analyser.Analysis {
+	a := fakeAnalysisWithAlerts()
+	a.Date = time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)
+	a.Duration = 90 * time.Second
+	return a
+}
+
+func TestOpenMetrics_Write(t *testing.T) {
+	tests := []struct {
+		name       string
+		goldenfile string
+		analysis   *analyser.Analysis
+		wantErr    bool
+	}{
+		{
+			name:       "test openmetrics output",
+			goldenfile: "output.prom",
+			analysis:   fakeAnalysisForOpenMetrics(),
+			wantErr:    false,
+		},
+		{
+			name:       "test openmetrics output with severities",
+			goldenfile: "output_severities.prom",
+			analysis:   fakeAnalysisWithSeverities(),
+			wantErr:    false,
+		},
+		{
+			name:       "test openmetrics output when no infra",
+			goldenfile: "output_empty.prom",
+			analysis:   &analyser.Analysis{},
+			wantErr:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			tempFile, err := ioutil.TempFile(tempDir, "result")
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := NewOpenMetrics(tempFile.Name(), nil)
+			if err := c.Write(tt.analysis); (err != nil) != tt.wantErr {
+				t.Errorf("Write() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			result, err := ioutil.ReadFile(tempFile.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+			expectedFilePath := path.Join("./testdata/", tt.goldenfile)
+			if *goldenfile.Update == tt.goldenfile {
+				if err := ioutil.WriteFile(expectedFilePath, result, 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := ioutil.ReadFile(expectedFilePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, string(expected), string(result))
+		})
+	}
+}
+
+func TestOpenMetrics_Push(t *testing.T) {
+	tests := []struct {
+		name          string
+		options       map[string]string
+		query         string
+		status        int
+		expectedPath  string
+		expectedQuery string
+		wantErr       string
+	}{
+		{
+			name:         "push with default job",
+			options:      map[string]string{},
+			status:       http.StatusOK,
+			expectedPath: "/metrics/job/driftctl",
+		},
+		{
+			name:         "push with custom job",
+			options:      map[string]string{OpenMetricsOptionJob: "nightly scan"},
+			status:       http.StatusAccepted,
+			expectedPath: "/metrics/job/nightly%20scan",
+		},
+		{
+			name:          "push to a pushgateway url with a query string",
+			options:       map[string]string{OpenMetricsOptionJob: "nightly/scan"},
+			query:         "/prefix/?token=abc",
+			status:        http.StatusOK,
+			expectedPath:  "/prefix/metrics/job/nightly%2Fscan",
+			expectedQuery: "token=abc",
+		},
+		{
+			name:         "push rejected",
+			options:      map[string]string{},
+			status:       http.StatusBadRequest,
+			expectedPath: "/metrics/job/driftctl",
+			wantErr:      "unable to push metrics to %s: 400 Bad Request",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPut, r.Method)
+				assert.Equal(t, tt.expectedPath, r.URL.EscapedPath())
+				assert.Equal(t, tt.expectedQuery, r.URL.RawQuery)
+				assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", r.Header.Get("Content-Type"))
+				body, _ = ioutil.ReadAll(r.Body)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			tt.options[OpenMetricsOptionPushgateway] = server.URL + tt.query
+			c := NewOpenMetrics("", tt.options)
+			err := c.Write(fakeAnalysisForOpenMetrics())
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, fmt.Sprintf(tt.wantErr, server.URL))
+				return
+			}
+			assert.NoError(t, err)
+
+			expected, err := ioutil.ReadFile("./testdata/output.prom")
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, string(expected), string(body))
+		})
+	}
+}
@@ -2,27 +2,48 @@ package output
 
 import (
 	"sort"
+	"strings"
 
 	"github.com/cloudskiff/driftctl/pkg/analyser"
 	"github.com/cloudskiff/driftctl/pkg/output"
+	"github.com/cloudskiff/driftctl/pkg/resource"
 )
 
 type Output interface {
 	Write(analysis *analyser.Analysis) error
 }
 
+// StreamOutput is an output able to write the partial analyses of a streaming scan as soon as they are done.
+// Partial analyses carry no baseline, drifts are only compared to the baseline once the scan is complete.
+type StreamOutput interface {
+	Output
+	WritePartial(analysis *analyser.Analysis) error
+	// Close ends the output once every partial analysis was written
+	Close() error
+}
+
 var supportedOutputTypes = []string{
 	ConsoleOutputType,
 	JSONOutputType,
 	HTMLOutputType,
 	PlanOutputType,
+	SARIFOutputType,
+	JUnitOutputType,
+	MarkdownOutputType,
+	OpenMetricsOutputType,
+	CSVOutputType,
 }
 
 var supportedOutputExample = map[string]string{
-	ConsoleOutputType: ConsoleOutputExample,
-	JSONOutputType:    JSONOutputExample,
-	HTMLOutputType:    HTMLOutputExample,
-	PlanOutputType:    PlanOutputExample,
+	ConsoleOutputType:     ConsoleOutputExample,
+	JSONOutputType:        JSONOutputExample,
+	HTMLOutputType:        HTMLOutputExample,
+	PlanOutputType:        PlanOutputExample,
+	SARIFOutputType:       SARIFOutputExample,
+	JUnitOutputType:       JUnitOutputExample,
+	MarkdownOutputType:    MarkdownOutputExample,
+	OpenMetricsOutputType: OpenMetricsOutputExample,
+	CSVOutputType:         CSVOutputExample,
 }
 
 func SupportedOutputsExample() []string {
@@ -50,11 +71,27 @@ func IsSupported(key string) bool {
 func GetOutput(config OutputConfig) Output {
 	switch config.Key {
 	case JSONOutputType:
-		return NewJSON(config.Path)
+		// Options are validated when parsing the output flag
+		attributes, _ := JSONAttributes(config.Options)
+		return NewJSON(config.Path, attributes)
 	case HTMLOutputType:
 		return NewHTML(config.Path)
 	case PlanOutputType:
 		return NewPlan(config.Path)
+	case SARIFOutputType:
+		return NewSARIF(config.Path)
+	case JUnitOutputType:
+		return NewJUnit(config.Path)
+	case MarkdownOutputType:
+		// Options are validated when parsing the output flag
+		maxSize, _ := MarkdownMaxSize(config.Options)
+		return NewMarkdown(config.Path, maxSize)
+	case OpenMetricsOutputType:
+		return NewOpenMetrics(config.Path, config.Options)
+	case CSVOutputType:
+		// Options are validated when parsing the output flag
+		columns, _ := CSVColumns(config.Options)
+		return NewCSV(config.Path, columns)
 	case ConsoleOutputType:
 		fallthrough
 	default:
@@ -89,6 +126,31 @@ func GetPrinter(config OutputConfig, quiet bool) output.Printer {
 			return &output.VoidPrinter{}
 		}
 		fallthrough
+	case SARIFOutputType:
+		if isStdOut(config.Path) {
+			return &output.VoidPrinter{}
+		}
+		fallthrough
+	case JUnitOutputType:
+		if isStdOut(config.Path) {
+			return &output.VoidPrinter{}
+		}
+		fallthrough
+	case MarkdownOutputType:
+		if isStdOut(config.Path) {
+			return &output.VoidPrinter{}
+		}
+		fallthrough
+	case OpenMetricsOutputType:
+		if isStdOut(config.Path) {
+			return &output.VoidPrinter{}
+		}
+		fallthrough
+	case CSVOutputType:
+		if isStdOut(config.Path) {
+			return &output.VoidPrinter{}
+		}
+		fallthrough
 	case ConsoleOutputType:
 		fallthrough
 	default:
@@ -99,3 +161,20 @@ func GetPrinter(config OutputConfig, quiet bool) output.Printer {
 func isStdOut(path string) bool {
 	return path == "/dev/stdout" || path == "stdout"
 }
+
+// resourceLocation distinguishes resources with the same id scanned in different accounts or regions
+func resourceLocation(res *resource.Resource) string {
+	var parts []string
+	if res.Account != "" {
+		parts = append(parts, res.Account)
+	}
+	if res.Region != "" {
+		parts = append(parts, res.Region)
+	}
+	return strings.Join(parts, "/")
+}
+
+// resourceKey identifies a resource across the managed resources and differences of an analysis
+func resourceKey(res *resource.Resource) string {
+	return strings.Join([]string{res.ResourceType(), res.ResourceId(), resourceLocation(res)}, ".")
+}
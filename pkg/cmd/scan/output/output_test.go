@@ -122,6 +122,49 @@ func fakeAnalysisWithAlerts() *analyser.Analysis {
 	return a
 }
 
+func fakeAnalysisWithBaseline() *analyser.Analysis {
+	previous := &analyser.Analysis{}
+	previous.AddUnmanaged(
+		&resource.Resource{
+			Id:   "unmanaged-id-1",
+			Type: "aws_unmanaged_resource",
+		},
+		&resource.Resource{
+			Id:   "unmanaged-id-3",
+			Type: "aws_unmanaged_resource",
+		},
+	)
+	previous.AddDeleted(
+		&resource.Resource{
+			Id:   "deleted-id-2",
+			Type: "aws_deleted_resource",
+		},
+	)
+	previous.AddDifference(analyser.Difference{
+		Res: &resource.Resource{
+			Id:   "diff-id-3",
+			Type: "aws_diff_resource",
+		},
+	})
+
+	a := fakeAnalysis()
+	a.SetBaseline(previous)
+	return a
+}
+
+func fakeAnalysisWithSeverities() *analyser.Analysis {
+	a := fakeAnalysis()
+	policy := &analyser.SeverityPolicy{
+		Rules: []analyser.SeverityRule{
+			{Type: "aws_unmanaged_*", Severity: analyser.SeverityHigh},
+			{Kind: analyser.DriftKindMissing, Severity: analyser.SeverityCritical},
+			{Type: "aws_diff_resource", Path: "new", Severity: analyser.SeverityLow},
+		},
+	}
+	policy.Apply(a)
+	return a
+}
+
 func fakeAnalysisNoDrift() *analyser.Analysis {
 	a := analyser.Analysis{}
 	for i := 0; i < 5; i++ {
@@ -510,6 +553,66 @@ func TestGetPrinter(t *testing.T) {
 			key:  PlanOutputType,
 			want: &output.VoidPrinter{},
 		},
+		{
+			name: "sarif file output",
+			path: "/path/to/file",
+			key:  SARIFOutputType,
+			want: output.NewConsolePrinter(),
+		},
+		{
+			name: "sarif stdout output",
+			path: "stdout",
+			key:  SARIFOutputType,
+			want: &output.VoidPrinter{},
+		},
+		{
+			name: "junit file output",
+			path: "/path/to/file",
+			key:  JUnitOutputType,
+			want: output.NewConsolePrinter(),
+		},
+		{
+			name: "junit stdout output",
+			path: "stdout",
+			key:  JUnitOutputType,
+			want: &output.VoidPrinter{},
+		},
+		{
+			name: "markdown file output",
+			path: "/path/to/file",
+			key:  MarkdownOutputType,
+			want: output.NewConsolePrinter(),
+		},
+		{
+			name: "markdown stdout output",
+			path: "stdout",
+			key:  MarkdownOutputType,
+			want: &output.VoidPrinter{},
+		},
+		{
+			name: "openmetrics file output",
+			path: "/path/to/file",
+			key:  OpenMetricsOutputType,
+			want: output.NewConsolePrinter(),
+		},
+		{
+			name: "openmetrics stdout output",
+			path: "stdout",
+			key:  OpenMetricsOutputType,
+			want: &output.VoidPrinter{},
+		},
+		{
+			name: "csv file output",
+			path: "/path/to/file",
+			key:  CSVOutputType,
+			want: output.NewConsolePrinter(),
+		},
+		{
+			name: "csv stdout output",
+			path: "stdout",
+			key:  CSVOutputType,
+			want: &output.VoidPrinter{},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
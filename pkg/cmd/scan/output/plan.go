@@ -24,10 +24,11 @@ type plannedValues struct {
 }
 
 type rscChange struct {
-	Address string `json:"address,omitempty"`
-	Type    string `json:"type,omitempty"`
-	Name    string `json:"name,omitempty"`
-	Change  change `json:"change,omitempty"`
+	Address  string `json:"address,omitempty"`
+	Type     string `json:"type,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Change   change `json:"change,omitempty"`
+	Severity string `json:"severity,omitempty"`
 }
 
 type change struct {
@@ -101,12 +102,12 @@ func listRsc(resources []*resource.Resource) []rsc {
 }
 
 func addResourceChanges(analysis *analyser.Analysis) []rscChange {
-	managedRsc := listRscChange(analysis.Managed(), "no-op")
-	unmanagedRsc := listRscChange(analysis.Unmanaged(), "create")
+	managedRsc := listRscChange(analysis, analysis.Managed(), "no-op")
+	unmanagedRsc := listRscChange(analysis, analysis.Unmanaged(), "create")
 	return append(managedRsc, unmanagedRsc...)
 }
 
-func listRscChange(resources []*resource.Resource, action string) []rscChange {
+func listRscChange(analysis *analyser.Analysis, resources []*resource.Resource, action string) []rscChange {
 	var ret []rscChange
 	for _, res := range resources {
 		r := rscChange{
@@ -117,6 +118,7 @@ func listRscChange(resources []*resource.Resource, action string) []rscChange {
 				Actions: []string{action},
 				After:   *res.Attributes(),
 			},
+			Severity: analysis.Severity(res).String(),
 		}
 		if action == "no-op" {
 			r.Change.Before = *res.Attributes()
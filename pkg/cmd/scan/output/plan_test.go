@@ -26,6 +26,17 @@ func TestPlan_Write(t *testing.T) {
 			analysis:   fakeAnalysisForJSONPlan(),
 			wantErr:    false,
 		},
+		{
+			name:       "test jsonplan output with severities",
+			goldenfile: "output_plan_severities.json",
+			analysis: func() *analyser.Analysis {
+				a := fakeAnalysisForJSONPlan()
+				policy := &analyser.SeverityPolicy{Default: analyser.SeverityHigh}
+				policy.Apply(a)
+				return a
+			}(),
+			wantErr: false,
+		},
 		{
 			name:       "test jsonplan output when no infra",
 			goldenfile: "output_plan_empty.json",
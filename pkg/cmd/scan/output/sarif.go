@@ -0,0 +1,259 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cloudskiff/driftctl/pkg/analyser"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/cloudskiff/driftctl/pkg/version"
+)
+
+const SARIFOutputType = "sarif"
+const SARIFOutputExample = "sarif://PATH/TO/FILE.sarif"
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	sarifKindUnmanaged = "unmanaged"
+	sarifKindMissing   = "missing"
+	sarifKindChanged   = "changed"
+)
+
+var sarifKindDescriptions = map[string]string{
+	sarifKindUnmanaged: "Resource %s found in the cloud provider but not managed by IaC",
+	sarifKindMissing:   "Resource %s managed by IaC but missing from the cloud provider",
+	sarifKindChanged:   "Resource %s managed by IaC but changed outside of it",
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Version        string      `json:"version"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations,omitempty"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+	Properties          map[string]string `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+type SARIF struct {
+	path string
+}
+
+func NewSARIF(path string) *SARIF {
+	return &SARIF{path}
+}
+
+func (c *SARIF) Write(analysis *analyser.Analysis) error {
+	file := os.Stdout
+	if !isStdOut(c.path) {
+		f, err := os.OpenFile(c.path, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		file = f
+	}
+
+	run := newSARIFRun()
+	for _, res := range analysis.Unmanaged() {
+		run.addResult(sarifKindUnmanaged, res, analysis.Severity(res), fmt.Sprintf("Unmanaged resource %s (%s)", res.ResourceId(), res.ResourceType()))
+	}
+	for _, res := range analysis.Deleted() {
+		run.addResult(sarifKindMissing, res, analysis.Severity(res), fmt.Sprintf("Missing resource %s (%s)", res.ResourceId(), res.ResourceType()))
+	}
+	for _, difference := range analysis.Differences() {
+		paths := make([]string, 0, len(difference.Changelog))
+		for _, change := range difference.Changelog {
+			paths = append(paths, strings.Join(change.Path, "."))
+		}
+		run.addResult(sarifKindChanged, difference.Res, analysis.Severity(difference.Res), fmt.Sprintf(
+			"Changed resource %s (%s): %s",
+			difference.Res.ResourceId(),
+			difference.Res.ResourceType(),
+			strings.Join(paths, ", "),
+		))
+	}
+
+	output, err := json.MarshalIndent(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{*run.sarifRun},
+	}, "", "\t")
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(output); err != nil {
+		return err
+	}
+	return nil
+}
+
+// sarifRunBuilder registers a rule for each drift kind and resource type the first time a result references it
+type sarifRunBuilder struct {
+	*sarifRun
+	ruleIndexes map[string]int
+}
+
+func newSARIFRun() *sarifRunBuilder {
+	return &sarifRunBuilder{
+		sarifRun: &sarifRun{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "driftctl",
+				InformationURI: "https://driftctl.com",
+				Version:        version.Current(),
+				Rules:          []sarifRule{},
+			}},
+			Results: []sarifResult{},
+		},
+		ruleIndexes: make(map[string]int),
+	}
+}
+
+func (r *sarifRunBuilder) addResult(kind string, res *resource.Resource, severity analyser.Severity, message string) {
+	ruleID := fmt.Sprintf("%s/%s", kind, res.ResourceType())
+	index, exist := r.ruleIndexes[ruleID]
+	if !exist {
+		index = len(r.Tool.Driver.Rules)
+		r.ruleIndexes[ruleID] = index
+		r.Tool.Driver.Rules = append(r.Tool.Driver.Rules, sarifRule{
+			ID:               ruleID,
+			Name:             kind,
+			ShortDescription: sarifMessage{Text: fmt.Sprintf(sarifKindDescriptions[kind], res.ResourceType())},
+		})
+	}
+
+	result := sarifResult{
+		RuleID:    ruleID,
+		RuleIndex: index,
+		Level:     sarifLevel(severity),
+		Message:   sarifMessage{Text: message},
+		PartialFingerprints: map[string]string{
+			"driftctl/v1": sarifFingerprint(kind, res),
+		},
+	}
+	if location := sarifResourceLocation(res); location != nil {
+		result.Locations = []sarifLocation{*location}
+	}
+
+	properties := map[string]string{}
+	if res.Region != "" {
+		properties["region"] = res.Region
+	}
+	if res.Account != "" {
+		properties["account"] = res.Account
+	}
+	if severity != 0 {
+		properties["severity"] = severity.String()
+	}
+	if len(properties) > 0 {
+		result.Properties = properties
+	}
+
+	r.Results = append(r.Results, result)
+}
+
+// sarifLevel maps the severity of a drift to a result level, drifts are warnings when no severity policy was applied
+func sarifLevel(severity analyser.Severity) string {
+	switch severity {
+	case analyser.SeverityInfo:
+		return "note"
+	case analyser.SeverityHigh, analyser.SeverityCritical:
+		return "error"
+	}
+	return "warning"
+}
+
+// sarifFingerprint identifies a drift across scans so code scanning tools can track it
+func sarifFingerprint(kind string, res *resource.Resource) string {
+	parts := []string{kind, res.ResourceType(), res.ResourceId()}
+	if res.Account != "" {
+		parts = append(parts, res.Account)
+	}
+	if res.Region != "" {
+		parts = append(parts, res.Region)
+	}
+	return strings.Join(parts, "/")
+}
+
+// sarifResourceLocation points to the terraform state the resource comes from, when known.
+// Local states are referenced by their path so code scanning tools can link them to the repository.
+func sarifResourceLocation(res *resource.Resource) *sarifLocation {
+	if res.Source == nil || res.Src().Source() == "" {
+		return nil
+	}
+
+	uri := strings.TrimPrefix(res.Src().Source(), "tfstate://")
+	name := fmt.Sprintf("%s.%s", res.ResourceType(), res.Src().InternalName())
+	address := name
+	if res.Src().Namespace() != "" {
+		address = fmt.Sprintf("%s.%s", res.Src().Namespace(), name)
+	}
+
+	return &sarifLocation{
+		PhysicalLocation: &sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: uri},
+		},
+		LogicalLocations: []sarifLogicalLocation{
+			{
+				Name:               name,
+				FullyQualifiedName: address,
+				Kind:               "resource",
+			},
+		},
+	}
+}
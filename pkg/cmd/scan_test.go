@@ -52,6 +52,11 @@ func TestScanCmd_Valid(t *testing.T) {
 		{args: []string{"scan", "--driftignore", ".driftignore"}},
 		{args: []string{"scan", "-o", "html://result.html", "-o", "json://result.json"}},
 		{args: []string{"scan", "--tf-lockfile", "../.terraform.lock.hcl"}},
+		{args: []string{"scan", "--regions", "us-east-1,eu-west-3"}},
+		{args: []string{"scan", "--to", "aws+tf", "--regions", "all"}},
+		{args: []string{"scan", "--assume-role", "arn:aws:iam::111111111111:role/driftctl", "--assume-role-external-id", "secret"}},
+		{args: []string{"scan", "--organization-role", "OrganizationAccountAccessRole"}},
+		{args: []string{"scan", "--severity-policy", "testdata/severity_policy.yml", "--fail-on", "high"}},
 	}
 
 	for _, tt := range cases {
@@ -65,6 +70,18 @@ func TestScanCmd_Valid(t *testing.T) {
 	}
 }
 
+func TestScanCmd_Stream(t *testing.T) {
+	rootCmd := &cobra.Command{Use: "root"}
+	opts := &pkg.ScanOptions{}
+	scanCmd := NewScanCmd(opts)
+	scanCmd.RunE = func(_ *cobra.Command, args []string) error { return nil }
+	rootCmd.AddCommand(scanCmd)
+
+	_, err := test.Execute(rootCmd, "scan", "--stream", "-o", "csv://result.csv")
+	assert.NoError(t, err)
+	assert.True(t, opts.Stream)
+}
+
 func TestScanCmd_Invalid(t *testing.T) {
 	cases := []struct {
 		args     []string
@@ -80,20 +97,27 @@ func TestScanCmd_Invalid(t *testing.T) {
 		{args: []string{"scan", "-f"}, expected: `flag needs an argument: 'f' in -f`},
 		{args: []string{"scan", "--from"}, expected: `flag needs an argument: --from`},
 		{args: []string{"scan", "--from"}, expected: `flag needs an argument: --from`},
-		{args: []string{"scan", "--from", "tosdgjhgsdhgkjs"}, expected: "Unable to parse from flag 'tosdgjhgsdhgkjs': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+http://,tfstate+https://,tfstate+tfcloud://"},
-		{args: []string{"scan", "--from", "://"}, expected: "Unable to parse from flag '://': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+http://,tfstate+https://,tfstate+tfcloud://"},
-		{args: []string{"scan", "--from", "://test"}, expected: "Unable to parse from flag '://test': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+http://,tfstate+https://,tfstate+tfcloud://"},
-		{args: []string{"scan", "--from", "tosdgjhgsdhgkjs://"}, expected: "Unable to parse from flag 'tosdgjhgsdhgkjs://': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+http://,tfstate+https://,tfstate+tfcloud://"},
-		{args: []string{"scan", "--from", "terraform+foo+bar://test"}, expected: "Unable to parse from scheme 'terraform+foo+bar': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+http://,tfstate+https://,tfstate+tfcloud://"},
-		{args: []string{"scan", "--from", "unsupported://test"}, expected: "Unsupported IaC source 'unsupported': \nAccepted values are: tfstate"},
-		{args: []string{"scan", "--from", "tfstate+foobar://test"}, expected: "Unsupported IaC backend 'foobar': \nAccepted values are: s3,http,https,tfcloud"},
-		{args: []string{"scan", "--from", "tfstate:///tmp/test", "--from", "tfstate+toto://test"}, expected: "Unsupported IaC backend 'toto': \nAccepted values are: s3,http,https,tfcloud"},
+		{args: []string{"scan", "--from", "tosdgjhgsdhgkjs"}, expected: "Unable to parse from flag 'tosdgjhgsdhgkjs': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+gs://,tfstate+azurerm://,tfstate+consul://,tfstate+pg://,tfstate+kubernetes://,tfstate+http://,tfstate+https://,tfstate+tfcloud://,tfdir://,tfplan://,cfn://,pulumi://,terragrunt://"},
+		{args: []string{"scan", "--from", "://"}, expected: "Unable to parse from flag '://': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+gs://,tfstate+azurerm://,tfstate+consul://,tfstate+pg://,tfstate+kubernetes://,tfstate+http://,tfstate+https://,tfstate+tfcloud://,tfdir://,tfplan://,cfn://,pulumi://,terragrunt://"},
+		{args: []string{"scan", "--from", "://test"}, expected: "Unable to parse from flag '://test': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+gs://,tfstate+azurerm://,tfstate+consul://,tfstate+pg://,tfstate+kubernetes://,tfstate+http://,tfstate+https://,tfstate+tfcloud://,tfdir://,tfplan://,cfn://,pulumi://,terragrunt://"},
+		{args: []string{"scan", "--from", "tosdgjhgsdhgkjs://"}, expected: "Unable to parse from flag 'tosdgjhgsdhgkjs://': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+gs://,tfstate+azurerm://,tfstate+consul://,tfstate+pg://,tfstate+kubernetes://,tfstate+http://,tfstate+https://,tfstate+tfcloud://,tfdir://,tfplan://,cfn://,pulumi://,terragrunt://"},
+		{args: []string{"scan", "--from", "terraform+foo+bar://test"}, expected: "Unable to parse from scheme 'terraform+foo+bar': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+gs://,tfstate+azurerm://,tfstate+consul://,tfstate+pg://,tfstate+kubernetes://,tfstate+http://,tfstate+https://,tfstate+tfcloud://,tfdir://,tfplan://,cfn://,pulumi://,terragrunt://"},
+		{args: []string{"scan", "--from", "unsupported://test"}, expected: "Unsupported IaC source 'unsupported': \nAccepted values are: tfstate,tfdir,tfplan,cfn,pulumi,terragrunt"},
+		{args: []string{"scan", "--from", "tfstate+foobar://test"}, expected: "Unsupported IaC backend 'foobar': \nAccepted values are: s3,gs,azurerm,consul,pg,kubernetes,http,https,tfcloud"},
+		{args: []string{"scan", "--from", "tfstate:///tmp/test", "--from", "tfstate+toto://test"}, expected: "Unsupported IaC backend 'toto': \nAccepted values are: s3,gs,azurerm,consul,pg,kubernetes,http,https,tfcloud"},
 		{args: []string{"scan", "--filter", "Type='test'"}, expected: "unable to parse filter expression: SyntaxError: Expected tRbracket, received: tUnknown"},
 		{args: []string{"scan", "--filter", "Type='test'", "--filter", "Type='test2'"}, expected: "Filter flag should be specified only once"},
 		{args: []string{"scan", "--tf-provider-version", ".30.2"}, expected: "Invalid version argument .30.2, expected a valid semver string (e.g. 2.13.4)"},
 		{args: []string{"scan", "--tf-provider-version", "foo"}, expected: "Invalid version argument foo, expected a valid semver string (e.g. 2.13.4)"},
 		{args: []string{"scan", "--driftignore"}, expected: "flag needs an argument: --driftignore"},
 		{args: []string{"scan", "--tf-lockfile"}, expected: "flag needs an argument: --tf-lockfile"},
+		{args: []string{"scan", "--to", "github+tf", "--regions", "us-east-1"}, expected: "--regions is only supported with the aws+tf cloud provider"},
+		{args: []string{"scan", "--to", "github+tf", "--organization-role", "driftctl"}, expected: "--organization-role is only supported with the aws+tf cloud provider"},
+		{args: []string{"scan", "--baseline", "testdata/not-found.json"}, expected: "unable to read baseline 'testdata/not-found.json': open testdata/not-found.json: no such file or directory"},
+		{args: []string{"scan", "--severity-policy", "testdata/not-found.yml"}, expected: "unable to read severity policy 'testdata/not-found.yml': open testdata/not-found.yml: no such file or directory"},
+		{args: []string{"scan", "--fail-on", "urgent"}, expected: "unsupported severity 'urgent', supported severities are: info,low,high,critical"},
+		{args: []string{"scan", "--stream"}, expected: "--stream is not supported by the console output, only outputs written incrementally like csv can be used"},
+		{args: []string{"scan", "--stream", "-o", "csv://result.csv", "-o", "json://result.json"}, expected: "--stream is not supported by the json output, only outputs written incrementally like csv can be used"},
 	}
 
 	for _, tt := range cases {
@@ -152,6 +176,78 @@ func Test_parseFromFlag(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "test terraform working directory",
+			args: args{
+				from: []string{"tfdir://path/to/project?workspace=prod"},
+			},
+			want: []config.SupplierConfig{
+				{
+					Key:     "tfdir",
+					Backend: "",
+					Path:    "path/to/project?workspace=prod",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "test terraform plan",
+			args: args{
+				from: []string{"tfplan://plan.json"},
+			},
+			want: []config.SupplierConfig{
+				{
+					Key:     "tfplan",
+					Backend: "",
+					Path:    "plan.json",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "test terraform plan with backend",
+			args: args{
+				from: []string{"tfplan+s3://bucket/plan.json"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "test cloudformation stack",
+			args: args{
+				from: []string{"cfn://prod-*?region=eu-west-3"},
+			},
+			want: []config.SupplierConfig{
+				{
+					Key:     "cfn",
+					Backend: "",
+					Path:    "prod-*?region=eu-west-3",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "test terragrunt stack",
+			args: args{
+				from: []string{"terragrunt://live/prod"},
+			},
+			want: []config.SupplierConfig{
+				{
+					Key:     "terragrunt",
+					Backend: "",
+					Path:    "live/prod",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "test terraform working directory with backend",
+			args: args{
+				from: []string{"tfdir+s3://path/to/project"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -183,7 +279,7 @@ func Test_parseOutputFlag(t *testing.T) {
 				out: []string{""},
 			},
 			want: []output.OutputConfig{},
-			err:  fmt.Errorf("Unable to parse output flag '': \nAccepted formats are: console://,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,plan://PATH/TO/FILE.json"),
+			err:  fmt.Errorf("Unable to parse output flag '': \nAccepted formats are: console://,csv://PATH/TO/FILE.csv,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,junit://PATH/TO/FILE.xml,markdown://PATH/TO/FILE.md,openmetrics://PATH/TO/FILE.prom,plan://PATH/TO/FILE.json,sarif://PATH/TO/FILE.sarif"),
 		},
 		{
 			name: "test empty array",
@@ -199,7 +295,7 @@ func Test_parseOutputFlag(t *testing.T) {
 				out: []string{"sdgjsdgjsdg"},
 			},
 			want: []output.OutputConfig{},
-			err:  fmt.Errorf("Unable to parse output flag 'sdgjsdgjsdg': \nAccepted formats are: console://,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,plan://PATH/TO/FILE.json"),
+			err:  fmt.Errorf("Unable to parse output flag 'sdgjsdgjsdg': \nAccepted formats are: console://,csv://PATH/TO/FILE.csv,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,junit://PATH/TO/FILE.xml,markdown://PATH/TO/FILE.md,openmetrics://PATH/TO/FILE.prom,plan://PATH/TO/FILE.json,sarif://PATH/TO/FILE.sarif"),
 		},
 		{
 			name: "test invalid",
@@ -207,7 +303,7 @@ func Test_parseOutputFlag(t *testing.T) {
 				out: []string{"://"},
 			},
 			want: []output.OutputConfig{},
-			err:  fmt.Errorf("Unable to parse output flag '://': \nAccepted formats are: console://,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,plan://PATH/TO/FILE.json"),
+			err:  fmt.Errorf("Unable to parse output flag '://': \nAccepted formats are: console://,csv://PATH/TO/FILE.csv,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,junit://PATH/TO/FILE.xml,markdown://PATH/TO/FILE.md,openmetrics://PATH/TO/FILE.prom,plan://PATH/TO/FILE.json,sarif://PATH/TO/FILE.sarif"),
 		},
 		{
 			name: "test unsupported",
@@ -215,7 +311,7 @@ func Test_parseOutputFlag(t *testing.T) {
 				out: []string{"foobar://"},
 			},
 			want: []output.OutputConfig{},
-			err:  fmt.Errorf("Unsupported output 'foobar': \nValid formats are: console://,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,plan://PATH/TO/FILE.json"),
+			err:  fmt.Errorf("Unsupported output 'foobar': \nValid formats are: console://,csv://PATH/TO/FILE.csv,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,junit://PATH/TO/FILE.xml,markdown://PATH/TO/FILE.md,openmetrics://PATH/TO/FILE.prom,plan://PATH/TO/FILE.json,sarif://PATH/TO/FILE.sarif"),
 		},
 		{
 			name: "test empty json",
@@ -250,6 +346,28 @@ func Test_parseOutputFlag(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			name: "test json with attributes",
+			args: args{
+				out: []string{"json:///tmp/foobar.json?attributes=true"},
+			},
+			want: []output.OutputConfig{
+				{
+					Key:     "json",
+					Path:    "/tmp/foobar.json",
+					Options: map[string]string{"attributes": "true"},
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "test json with invalid attributes",
+			args: args{
+				out: []string{"json:///tmp/foobar.json?attributes=maybe"},
+			},
+			want: []output.OutputConfig{},
+			err:  fmt.Errorf("Invalid json output 'json:///tmp/foobar.json?attributes=maybe': \ninvalid attributes 'maybe', must be true or false"),
+		},
 		{
 			name: "test empty jsonplan",
 			args: args{
@@ -271,6 +389,206 @@ func Test_parseOutputFlag(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			name: "test empty sarif",
+			args: args{
+				out: []string{"sarif://"},
+			},
+			want: []output.OutputConfig{},
+			err:  fmt.Errorf("Invalid sarif output 'sarif://': \nMust be of kind: sarif://PATH/TO/FILE.sarif"),
+		},
+		{
+			name: "test valid sarif",
+			args: args{
+				out: []string{"sarif:///tmp/foobar.sarif"},
+			},
+			want: []output.OutputConfig{
+				{
+					Key:  "sarif",
+					Path: "/tmp/foobar.sarif",
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "test empty junit",
+			args: args{
+				out: []string{"junit://"},
+			},
+			want: []output.OutputConfig{},
+			err:  fmt.Errorf("Invalid junit output 'junit://': \nMust be of kind: junit://PATH/TO/FILE.xml"),
+		},
+		{
+			name: "test valid junit",
+			args: args{
+				out: []string{"junit:///tmp/foobar.xml"},
+			},
+			want: []output.OutputConfig{
+				{
+					Key:  "junit",
+					Path: "/tmp/foobar.xml",
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "test empty markdown",
+			args: args{
+				out: []string{"markdown://"},
+			},
+			want: []output.OutputConfig{},
+			err:  fmt.Errorf("Invalid markdown output 'markdown://': \nMust be of kind: markdown://PATH/TO/FILE.md"),
+		},
+		{
+			name: "test valid markdown",
+			args: args{
+				out: []string{"markdown:///tmp/foobar.md"},
+			},
+			want: []output.OutputConfig{
+				{
+					Key:  "markdown",
+					Path: "/tmp/foobar.md",
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "test markdown with max size",
+			args: args{
+				out: []string{"markdown:///tmp/foobar.md?max-size=1000"},
+			},
+			want: []output.OutputConfig{
+				{
+					Key:     "markdown",
+					Path:    "/tmp/foobar.md",
+					Options: map[string]string{"max-size": "1000"},
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "test markdown with invalid max size",
+			args: args{
+				out: []string{"markdown:///tmp/foobar.md?max-size=foo"},
+			},
+			want: []output.OutputConfig{},
+			err:  fmt.Errorf("Invalid markdown output 'markdown:///tmp/foobar.md?max-size=foo': \ninvalid max-size 'foo', must be a positive number of bytes"),
+		},
+		{
+			name: "test markdown with unsupported option",
+			args: args{
+				out: []string{"markdown:///tmp/foobar.md?foo=bar"},
+			},
+			want: []output.OutputConfig{},
+			err:  fmt.Errorf("Invalid markdown output 'markdown:///tmp/foobar.md?foo=bar': \nunsupported option 'foo', supported options are: max-size"),
+		},
+		{
+			name: "test empty openmetrics",
+			args: args{
+				out: []string{"openmetrics://"},
+			},
+			want: []output.OutputConfig{},
+			err:  fmt.Errorf("Invalid openmetrics output 'openmetrics://': \nMust be of kind: openmetrics://PATH/TO/FILE.prom"),
+		},
+		{
+			name: "test valid openmetrics",
+			args: args{
+				out: []string{"openmetrics:///tmp/foobar.prom"},
+			},
+			want: []output.OutputConfig{
+				{
+					Key:  "openmetrics",
+					Path: "/tmp/foobar.prom",
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "test openmetrics pushed to a pushgateway",
+			args: args{
+				out: []string{"openmetrics://?pushgateway=http://localhost:9091&job=nightly"},
+			},
+			want: []output.OutputConfig{
+				{
+					Key:     "openmetrics",
+					Options: map[string]string{"pushgateway": "http://localhost:9091", "job": "nightly"},
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "test openmetrics pushed to a pushgateway url with a query string",
+			args: args{
+				out: []string{"openmetrics:///tmp/foobar.prom?pushgateway=http://localhost:9091/metrics/job/x?foo=bar&job=nightly"},
+			},
+			want: []output.OutputConfig{
+				{
+					Key:     "openmetrics",
+					Path:    "/tmp/foobar.prom",
+					Options: map[string]string{"pushgateway": "http://localhost:9091/metrics/job/x?foo=bar", "job": "nightly"},
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "test openmetrics with invalid pushgateway",
+			args: args{
+				out: []string{"openmetrics:///tmp/foobar.prom?pushgateway=localhost"},
+			},
+			want: []output.OutputConfig{},
+			err:  fmt.Errorf("Invalid openmetrics output 'openmetrics:///tmp/foobar.prom?pushgateway=localhost': \nparse \"localhost\": invalid URI for request"),
+		},
+		{
+			name: "test empty csv",
+			args: args{
+				out: []string{"csv://"},
+			},
+			want: []output.OutputConfig{},
+			err:  fmt.Errorf("Invalid csv output 'csv://': \nMust be of kind: csv://PATH/TO/FILE.csv"),
+		},
+		{
+			name: "test csv with columns and without path",
+			args: args{
+				out: []string{"csv://?columns=foo"},
+			},
+			want: []output.OutputConfig{},
+			err:  fmt.Errorf("Invalid csv output 'csv://?columns=foo': \nMust be of kind: csv://PATH/TO/FILE.csv"),
+		},
+		{
+			name: "test valid csv",
+			args: args{
+				out: []string{"csv:///tmp/foobar.csv"},
+			},
+			want: []output.OutputConfig{
+				{
+					Key:  "csv",
+					Path: "/tmp/foobar.csv",
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "test csv with columns",
+			args: args{
+				out: []string{"csv:///tmp/foobar.csv?columns=type,id"},
+			},
+			want: []output.OutputConfig{
+				{
+					Key:     "csv",
+					Path:    "/tmp/foobar.csv",
+					Options: map[string]string{"columns": "type,id"},
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "test csv with invalid column",
+			args: args{
+				out: []string{"csv:///tmp/foobar.csv?columns=type,foo"},
+			},
+			want: []output.OutputConfig{},
+			err:  fmt.Errorf("Invalid csv output 'csv:///tmp/foobar.csv?columns=type,foo': \nunsupported column 'foo', supported columns are: status,type,id,source,module,name,region,account,changes,severity"),
+		},
 		{
 			name: "test multiple output values",
 			args: args{
@@ -297,7 +615,7 @@ func Test_parseOutputFlag(t *testing.T) {
 					Key: "console",
 				},
 			},
-			err: fmt.Errorf("Unsupported output 'invalid': \nValid formats are: console://,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,plan://PATH/TO/FILE.json"),
+			err: fmt.Errorf("Unsupported output 'invalid': \nValid formats are: console://,csv://PATH/TO/FILE.csv,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,junit://PATH/TO/FILE.xml,markdown://PATH/TO/FILE.md,openmetrics://PATH/TO/FILE.prom,plan://PATH/TO/FILE.json,sarif://PATH/TO/FILE.sarif"),
 		},
 		{
 			name: "test multiple valid output values",
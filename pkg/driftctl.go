@@ -2,6 +2,7 @@ package pkg
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/cloudskiff/driftctl/pkg/memstore"
@@ -20,20 +21,33 @@ import (
 )
 
 type ScanOptions struct {
-	Coverage         bool
-	Detect           bool
-	From             []config.SupplierConfig
-	To               string
-	Output           []output.OutputConfig
-	Filter           *jmespath.JMESPath
-	Quiet            bool
-	BackendOptions   *backend.Options
-	StrictMode       bool
-	DisableTelemetry bool
-	ProviderVersion  string
-	ConfigDir        string
-	DriftignorePath  string
-	Deep             bool
+	Coverage             bool
+	Detect               bool
+	From                 []config.SupplierConfig
+	To                   string
+	Regions              []string
+	AssumeRoleARNs       []string
+	AssumeRoleExternalID string
+	OrganizationRoleName string
+	Baseline             *analyser.Analysis
+	Output               []output.OutputConfig
+	Filter               *jmespath.JMESPath
+	Quiet                bool
+	BackendOptions       *backend.Options
+	StrictMode           bool
+	DisableTelemetry     bool
+	ProviderVersion      string
+	ConfigDir            string
+	DriftignorePath      string
+	Deep                 bool
+	Stream               bool
+	SeverityPolicy       *analyser.SeverityPolicy
+	FailOn               analyser.Severity
+}
+
+// ScansMultipleAccounts returns true when AWS roles are assumed, resources being bound to an account only then
+func (o *ScanOptions) ScansMultipleAccounts() bool {
+	return len(o.AssumeRoleARNs) > 0 || o.OrganizationRoleName != ""
 }
 
 type DriftCTL struct {
@@ -80,7 +94,156 @@ func (d DriftCTL) Run() (*analyser.Analysis, error) {
 		return nil, err
 	}
 
-	middleware := middlewares.NewChain(
+	logrus.Debug("Ready to run middlewares")
+	err = d.middlewares().Execute(&remoteResources, &resourcesFromState)
+	if err != nil {
+		return nil, err
+	}
+
+	analysis, err := d.analyze(remoteResources, resourcesFromState)
+	if err != nil {
+		return nil, err
+	}
+	d.analyzer.Complete(&analysis)
+
+	return d.complete(&analysis, start), nil
+}
+
+// RunStream runs the scan like Run, except that remote resources are received type by type and analyzed
+// per group of types handled by the same middlewares, as soon as every type of a group is enumerated.
+// Each partial analysis is given to flush when done, so results are reported before the scan ends.
+// Memory is not bounded though: IaC resources are all read upfront, and partial analyses are kept
+// to be merged into the returned analysis.
+// It falls back to Run when the remote supplier does not stream resources.
+func (d DriftCTL) RunStream(flush func(analysis *analyser.Analysis)) (*analyser.Analysis, error) {
+	streamingSupplier, isStreaming := d.remoteSupplier.(resource.StreamingSupplier)
+	groups, isTyped := d.middlewares().TypeGroups()
+	if !isStreaming || !isTyped {
+		logrus.Debug("Remote resources cannot be streamed, running a full scan")
+		analysis, err := d.Run()
+		if err != nil {
+			return nil, err
+		}
+		flush(analysis)
+		return analysis, nil
+	}
+
+	start := time.Now()
+	logrus.Info("Start reading IaC")
+	d.iacProgress.Start()
+	resourcesFromState, err := d.iacSupplier.Resources()
+	d.iacProgress.Stop()
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make(map[resource.ResourceType]*typeGroup)
+	groupOf := func(ty resource.ResourceType) *typeGroup {
+		name := groups.Group(ty)
+		group, exists := pending[name]
+		if !exists {
+			group = &typeGroup{name: name}
+			pending[name] = group
+		}
+		return group
+	}
+	for _, res := range resourcesFromState {
+		group := groupOf(resource.ResourceType(res.ResourceType()))
+		group.resourcesFromState = append(group.resourcesFromState, res)
+	}
+	for _, ty := range streamingSupplier.Types() {
+		groupOf(ty).enumerating++
+	}
+
+	analyses := make([]*analyser.Analysis, 0, len(pending))
+	analyzeGroup := func(group *typeGroup) error {
+		logrus.WithFields(logrus.Fields{
+			"group": group.name,
+		}).Debug("Analyzing resource group")
+		err := groups.Chain(group.name).Execute(&group.remoteResources, &group.resourcesFromState)
+		if err != nil {
+			return err
+		}
+		analysis, err := d.analyze(group.remoteResources, group.resourcesFromState)
+		if err != nil {
+			return err
+		}
+		delete(pending, group.name)
+		flush(&analysis)
+		analyses = append(analyses, &analysis)
+		return nil
+	}
+
+	// Groups of types that are not enumerated only contain IaC resources, they can be analyzed right away
+	for _, group := range sortedGroups(pending) {
+		if group.enumerating == 0 {
+			if err := analyzeGroup(group); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	logrus.Info("Start scanning cloud provider")
+	d.scanProgress.Start()
+	defer d.scanProgress.Stop()
+
+	stream := make(chan resource.TypedResources)
+	streamErr := make(chan error, 1)
+	go func() {
+		streamErr <- streamingSupplier.Stream(stream)
+	}()
+	for typed := range stream {
+		group := groupOf(typed.Type)
+		group.remoteResources = append(group.remoteResources, typed.Resources...)
+		group.enumerating--
+		if group.enumerating > 0 {
+			continue
+		}
+		if err := analyzeGroup(group); err != nil {
+			d.Stop()
+			// Wait for the supplier to stop sending resources
+			for range stream {
+			}
+			return nil, err
+		}
+	}
+	if err := <-streamErr; err != nil {
+		return nil, err
+	}
+
+	for _, group := range sortedGroups(pending) {
+		if err := analyzeGroup(group); err != nil {
+			return nil, err
+		}
+	}
+
+	analysis := analyser.Merge(analyses...)
+	d.analyzer.Complete(analysis)
+
+	return d.complete(analysis, start), nil
+}
+
+// typeGroup holds resources of types that are handled by the same middlewares
+type typeGroup struct {
+	name               resource.ResourceType
+	enumerating        int
+	remoteResources    []*resource.Resource
+	resourcesFromState []*resource.Resource
+}
+
+func sortedGroups(groups map[resource.ResourceType]*typeGroup) []*typeGroup {
+	sorted := make([]*typeGroup, 0, len(groups))
+	for _, group := range groups {
+		sorted = append(sorted, group)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].name < sorted[j].name
+	})
+	return sorted
+}
+
+func (d DriftCTL) middlewares() middlewares.Chain {
+	chain := middlewares.NewChain(
 		middlewares.NewRoute53RecordIDReconcilier(),
 		middlewares.NewRoute53DefaultZoneRecordSanitizer(),
 		middlewares.NewS3BucketAcl(),
@@ -115,6 +278,7 @@ func (d DriftCTL) Run() (*analyser.Analysis, error) {
 		middlewares.NewAwsApiGatewayRestApiExpander(d.resourceFactory),
 		middlewares.NewAwsApiGatewayRestApiPolicyExpander(d.resourceFactory),
 		middlewares.NewAwsConsoleApiGatewayGatewayResponse(),
+		middlewares.NewAwsStateArnResolver(d.opts.ScansMultipleAccounts()),
 
 		middlewares.NewGoogleIAMBindingTransformer(d.resourceFactory),
 		middlewares.NewGoogleIAMPolicyTransformer(d.resourceFactory),
@@ -124,34 +288,44 @@ func (d DriftCTL) Run() (*analyser.Analysis, error) {
 	)
 
 	if !d.opts.StrictMode {
-		middleware = append(middleware,
+		chain = append(chain,
 			middlewares.NewAwsDefaults(),
 			middlewares.NewGoogleLegacyBucketIAMMember(),
 			middlewares.NewGoogleDefaultIAMMember(),
 		)
 	}
 
-	logrus.Debug("Ready to run middlewares")
-	err = middleware.Execute(&remoteResources, &resourcesFromState)
-	if err != nil {
-		return nil, err
-	}
+	return chain
+}
 
+// analyze filters resources with the filter expression, if any, and compares them
+func (d DriftCTL) analyze(remoteResources, resourcesFromState []*resource.Resource) (analyser.Analysis, error) {
 	if d.opts.Filter != nil {
 		engine := filter.NewFilterEngine(d.opts.Filter)
+		var err error
 		remoteResources, err = engine.Run(remoteResources)
 		if err != nil {
-			return nil, err
+			return analyser.Analysis{}, err
 		}
 		resourcesFromState, err = engine.Run(resourcesFromState)
 		if err != nil {
-			return nil, err
+			return analyser.Analysis{}, err
 		}
 	}
 
-	analysis, err := d.analyzer.Analyze(remoteResources, resourcesFromState)
+	analysis, err := d.analyzer.AnalyzePartial(remoteResources, resourcesFromState)
 	if err != nil {
-		return nil, err
+		return analysis, err
+	}
+	if d.opts.SeverityPolicy != nil {
+		d.opts.SeverityPolicy.Apply(&analysis)
+	}
+	return analysis, nil
+}
+
+func (d DriftCTL) complete(analysis *analyser.Analysis, start time.Time) *analyser.Analysis {
+	if d.opts.Baseline != nil {
+		analysis.SetBaseline(d.opts.Baseline)
 	}
 
 	analysis.Duration = time.Since(start)
@@ -161,7 +335,7 @@ func (d DriftCTL) Run() (*analyser.Analysis, error) {
 	d.store.Bucket(memstore.TelemetryBucket).Set("total_managed", analysis.Summary().TotalManaged)
 	d.store.Bucket(memstore.TelemetryBucket).Set("duration", uint(analysis.Duration.Seconds()+0.5))
 
-	return &analysis, nil
+	return analysis
 }
 
 func (d DriftCTL) Stop() {
@@ -99,6 +99,7 @@ func runTest(t *testing.T, cases TestCases) {
 			testFilter.On("IsTypeIgnored", mock.Anything).Return(false)
 			testFilter.On("IsResourceIgnored", mock.Anything).Return(false)
 			testFilter.On("IsFieldIgnored", mock.Anything, mock.Anything).Return(false)
+			testFilter.On("IsChangeIgnored", mock.Anything, mock.Anything).Return(false)
 			analyzer := analyser.NewAnalyzer(testAlerter, analyser.AnalyzerOptions{Deep: c.options.Deep}, testFilter)
 
 			store := memstore.New()
@@ -1763,7 +1764,225 @@ func TestDriftctlRun_Middlewares(t *testing.T) {
 	runTest(t, cases)
 }
 
+func TestDriftctlRun_Accounts(t *testing.T) {
+	cases := TestCases{
+		{
+			name: "default credentials scan does not report accounts",
+			stateResources: []*resource.Resource{
+				{
+					Id:   "topic",
+					Type: aws.AwsSnsTopicResourceType,
+					Attrs: &resource.Attributes{
+						"arn": "arn:aws:sns:eu-west-3:123456789012:topic",
+					},
+				},
+			},
+			remoteResources: []*resource.Resource{
+				{
+					Id:     "topic",
+					Type:   aws.AwsSnsTopicResourceType,
+					Region: "eu-west-3",
+					Attrs:  &resource.Attributes{},
+				},
+				{
+					Id:     "queue",
+					Type:   aws.AwsSqsQueueResourceType,
+					Region: "eu-west-3",
+					Attrs:  &resource.Attributes{},
+				},
+			},
+			assert: func(t *testing.T, result *test.ScanResult, err error) {
+				result.AssertManagedCount(1)
+				result.AssertUnmanagedCount(1)
+				assert.Empty(t, result.AccountSummaries())
+			},
+			options: &pkg.ScanOptions{},
+		},
+		{
+			name: "assumed roles scan reports accounts of every resource",
+			stateResources: []*resource.Resource{
+				{
+					Id:   "topic",
+					Type: aws.AwsSnsTopicResourceType,
+					Attrs: &resource.Attributes{
+						"arn": "arn:aws:sns:eu-west-3:111111111111:topic",
+					},
+				},
+				{
+					Id:    "r-rtb-1080169831",
+					Type:  aws.AwsRouteResourceType,
+					Attrs: &resource.Attributes{},
+				},
+			},
+			remoteResources: []*resource.Resource{
+				{
+					Id:      "topic",
+					Type:    aws.AwsSnsTopicResourceType,
+					Region:  "eu-west-3",
+					Account: "111111111111",
+					Attrs:   &resource.Attributes{},
+				},
+				{
+					Id:      "r-rtb-1080169831",
+					Type:    aws.AwsRouteResourceType,
+					Region:  "eu-west-3",
+					Account: "111111111111",
+					Attrs:   &resource.Attributes{},
+				},
+				{
+					Id:      "queue",
+					Type:    aws.AwsSqsQueueResourceType,
+					Region:  "eu-west-3",
+					Account: "222222222222",
+					Attrs:   &resource.Attributes{},
+				},
+			},
+			assert: func(t *testing.T, result *test.ScanResult, err error) {
+				result.AssertManagedCount(2)
+				result.AssertUnmanagedCount(1)
+				assert.Equal(t, map[string]analyser.Summary{
+					"111111111111": {TotalResources: 2, TotalManaged: 2},
+					"222222222222": {TotalResources: 1, TotalUnmanaged: 1},
+				}, result.AccountSummaries())
+			},
+			options: &pkg.ScanOptions{
+				AssumeRoleARNs: []string{
+					"arn:aws:iam::111111111111:role/driftctl",
+					"arn:aws:iam::222222222222:role/driftctl",
+				},
+			},
+		},
+	}
+
+	runTest(t, cases)
+}
+
 func getSchema(repo resource.SchemaRepositoryInterface, resourceType string) *resource.Schema {
 	sch, _ := repo.GetSchema(resourceType)
 	return sch
 }
+
+type fakeStreamingSupplier struct {
+	resources []*resource.Resource
+}
+
+func (s fakeStreamingSupplier) Resources() ([]*resource.Resource, error) {
+	return s.resources, nil
+}
+
+func (s fakeStreamingSupplier) Types() []resource.ResourceType {
+	types := make([]resource.ResourceType, 0)
+	for _, res := range s.resources {
+		ty := resource.ResourceType(res.ResourceType())
+		if !containsType(types, ty) {
+			types = append(types, ty)
+		}
+	}
+	return types
+}
+
+func (s fakeStreamingSupplier) Stream(out chan<- resource.TypedResources) error {
+	defer close(out)
+	for _, ty := range s.Types() {
+		typed := resource.TypedResources{Type: ty}
+		for _, res := range s.resources {
+			if res.ResourceType() == string(ty) {
+				typed.Resources = append(typed.Resources, res)
+			}
+		}
+		out <- typed
+	}
+	return nil
+}
+
+func containsType(types []resource.ResourceType, ty resource.ResourceType) bool {
+	for _, t := range types {
+		if t == ty {
+			return true
+		}
+	}
+	return false
+}
+
+func TestDriftctlRunStream(t *testing.T) {
+	repo := testresource.InitFakeSchemaRepository("aws", "3.19.0")
+	aws.InitResourcesMetadata(repo)
+	github.InitResourcesMetadata(repo)
+
+	stateSupplier := &resource.MockSupplier{}
+	stateSupplier.On("Resources").Return([]*resource.Resource{
+		{
+			Id:   "foo",
+			Type: aws.AwsS3BucketResourceType,
+			Attrs: &resource.Attributes{
+				"bucket": "foo",
+				"policy": "{\"Id\":\"foo\"}",
+			},
+		},
+		{
+			Id:    "repo",
+			Type:  github.GithubRepositoryResourceType,
+			Attrs: &resource.Attributes{},
+		},
+	}, nil)
+
+	remoteSupplier := fakeStreamingSupplier{resources: []*resource.Resource{
+		{
+			Id:    "foo",
+			Type:  aws.AwsS3BucketResourceType,
+			Attrs: &resource.Attributes{"bucket": "foo"},
+		},
+		{
+			Id:    "queue",
+			Type:  aws.AwsSqsQueueResourceType,
+			Attrs: &resource.Attributes{},
+		},
+		{
+			Id:   "foo",
+			Type: aws.AwsS3BucketPolicyResourceType,
+			Attrs: &resource.Attributes{
+				"id":     "foo",
+				"bucket": "foo",
+				"policy": "{\"Id\":\"foo\"}",
+			},
+		},
+	}}
+
+	scanProgress := &output.MockProgress{}
+	scanProgress.On("Start").Return().Once()
+	scanProgress.On("Stop").Return().Once()
+
+	iacProgress := &output.MockProgress{}
+	iacProgress.On("Start").Return().Once()
+	iacProgress.On("Stop").Return().Once()
+
+	testAlerter := alerter.NewAlerter()
+	testFilter := &filter.MockFilter{}
+	testFilter.On("IsResourceIgnored", mock.Anything).Return(false)
+	analyzer := analyser.NewAnalyzer(testAlerter, analyser.AnalyzerOptions{}, testFilter)
+	factory := terraform.NewTerraformResourceFactory(repo)
+
+	driftctl := pkg.NewDriftCTL(remoteSupplier, stateSupplier, testAlerter, analyzer, factory, &pkg.ScanOptions{}, scanProgress, iacProgress, repo, memstore.New())
+
+	var partials []*analyser.Analysis
+	analysis, err := driftctl.RunStream(func(partial *analyser.Analysis) {
+		partials = append(partials, partial)
+	})
+	assert.NoError(t, err)
+
+	// The repository group is only made of IaC resources and is analyzed first,
+	// the bucket waits for its policy to be enumerated while the queue is analyzed as soon as enumerated
+	if assert.Len(t, partials, 3) {
+		assert.Equal(t, 1, partials[0].Summary().TotalDeleted)
+		assert.Equal(t, 1, partials[1].Summary().TotalUnmanaged)
+		assert.Equal(t, 2, partials[2].Summary().TotalManaged)
+	}
+
+	result := test.NewScanResult(t, analysis)
+	result.AssertManagedCount(2)
+	result.AssertResourceUnmanaged("queue", aws.AwsSqsQueueResourceType)
+	result.AssertResourceDeleted("repo", github.GithubRepositoryResourceType)
+	assert.Equal(t, 4, analysis.Summary().TotalResources)
+	scanProgress.AssertExpectations(t)
+	iacProgress.AssertExpectations(t)
+}
@@ -0,0 +1,26 @@
+package filter
+
+import "fmt"
+
+type ExpiredRuleAlert struct {
+	path string
+	rule *DriftIgnoreRule
+}
+
+func NewExpiredRuleAlert(path string, rule *DriftIgnoreRule) *ExpiredRuleAlert {
+	return &ExpiredRuleAlert{path: path, rule: rule}
+}
+
+func (e *ExpiredRuleAlert) Message() string {
+	return fmt.Sprintf(
+		"Driftignore rule %s at %s:%d expired on %s and no longer ignores anything",
+		e.rule.Description(),
+		e.path,
+		e.rule.Line,
+		e.rule.Expires.Format(RuleExpiryLayout),
+	)
+}
+
+func (e *ExpiredRuleAlert) ShouldIgnoreResource() bool {
+	return false
+}
@@ -1,13 +1,13 @@
 package filter
 
 import (
-	"bufio"
 	"fmt"
-	"os"
 	"strings"
+	"time"
 
 	"github.com/cloudskiff/driftctl/pkg/resource"
 	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/r3labs/diff/v2"
 	"github.com/sirupsen/logrus"
 )
 
@@ -16,6 +16,8 @@ const separator = "_-_"
 type DriftIgnore struct {
 	driftignorePath string
 	matcher         gitignore.Matcher
+	changeRules     []*DriftIgnoreRule
+	expiredRules    []*DriftIgnoreRule
 }
 
 func NewDriftIgnore(path string) *DriftIgnore {
@@ -23,43 +25,44 @@ func NewDriftIgnore(path string) *DriftIgnore {
 		driftignorePath: path,
 		matcher:         gitignore.NewMatcher(nil),
 	}
-	err := d.readIgnoreFile()
+	err := d.readIgnoreFile(time.Now())
 	if err != nil {
 		logrus.Debug(err)
 	}
 	return &d
 }
 
-func (r *DriftIgnore) readIgnoreFile() error {
-	file, err := os.Open(r.driftignorePath)
+func (r *DriftIgnore) readIgnoreFile(now time.Time) error {
+	rules, err := ReadDriftIgnoreRules(r.driftignorePath)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
 
 	var lines []gitignore.Pattern
-	scanner := bufio.NewScanner(file)
-	for lineNumber := 1; scanner.Scan(); lineNumber++ {
-		line := scanner.Text()
-
-		if len(strings.ReplaceAll(line, " ", "")) <= 0 {
-			continue // empty
+	for _, rule := range rules {
+		if rule.Err != nil {
+			logrus.WithFields(logrus.Fields{
+				"line":  rule.Line,
+				"error": rule.Err.Error(),
+			}).Warn("Skipped invalid driftignore line")
+			continue
 		}
 
-		if strings.HasPrefix(line, "#") {
-			continue // this is a comment
+		if rule.Expired(now) {
+			logrus.WithFields(logrus.Fields{
+				"line":    rule.Line,
+				"expires": rule.Expires.Format(RuleExpiryLayout),
+			}).Debug("Skipped expired driftignore line")
+			r.expiredRules = append(r.expiredRules, rule)
+			continue
 		}
-		line = strings.ReplaceAll(line, "/", separator)
 
-		lines = append(lines, gitignore.ParsePattern(line, nil))
-		if !strings.HasSuffix(line, "*") {
-			line := fmt.Sprintf("%s.*", line)
-			lines = append(lines, gitignore.ParsePattern(line, nil))
+		// Lines with a predicate only ignore some changes of the matching fields
+		if rule.HasPredicate() {
+			r.changeRules = append(r.changeRules, rule)
+			continue
 		}
-	}
-
-	if err := scanner.Err(); err != nil {
-		return err
+		lines = append(lines, rule.patterns...)
 	}
 
 	r.matcher = gitignore.NewMatcher(lines)
@@ -67,6 +70,11 @@ func (r *DriftIgnore) readIgnoreFile() error {
 	return nil
 }
 
+// ExpiredRules returns the rules that stopped matching because their expiry date is reached
+func (r *DriftIgnore) ExpiredRules() []*DriftIgnoreRule {
+	return r.expiredRules
+}
+
 func (r *DriftIgnore) isAnyOfChildrenTypesNotIgnored(ty resource.ResourceType) bool {
 	childrenTypes := resource.GetMeta(ty).GetChildrenTypes()
 	for _, childrenType := range childrenTypes {
@@ -99,6 +107,16 @@ func (r *DriftIgnore) IsFieldIgnored(res *resource.Resource, path []string) bool
 	return r.match(full)
 }
 
+// IsChangeIgnored returns true when the change of a field matches a driftignore line with a predicate
+func (r *DriftIgnore) IsChangeIgnored(res *resource.Resource, change diff.Change) bool {
+	for _, rule := range r.changeRules {
+		if rule.MatchesChange(res, change) {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *DriftIgnore) match(strRes string) bool {
 	return r.matcher.Match([]string{strings.ReplaceAll(strRes, "/", separator)}, false)
 }
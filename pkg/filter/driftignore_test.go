@@ -4,7 +4,9 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/r3labs/diff/v2"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/cloudskiff/driftctl/pkg/resource"
@@ -436,6 +438,125 @@ func TestDriftIgnore_IsFieldIgnored(t *testing.T) {
 	}
 }
 
+func TestDriftIgnore_IsChangeIgnored(t *testing.T) {
+	service := &resource.Resource{Type: "aws_ecs_service", Id: "api"}
+	group := &resource.Resource{Type: "aws_autoscaling_group", Id: "workers"}
+	instance := &resource.Resource{Type: "aws_instance", Id: "i-1234"}
+
+	tests := []struct {
+		name   string
+		res    *resource.Resource
+		change diff.Change
+		want   bool
+	}{
+		{
+			name:   "value in range",
+			res:    service,
+			change: diff.Change{Type: diff.UPDATE, Path: []string{"desired_count"}, From: float64(2), To: float64(8)},
+			want:   true,
+		},
+		{
+			name:   "value out of range",
+			res:    service,
+			change: diff.Change{Type: diff.UPDATE, Path: []string{"desired_count"}, From: float64(2), To: float64(12)},
+			want:   false,
+		},
+		{
+			name:   "string value in range",
+			res:    service,
+			change: diff.Change{Type: diff.UPDATE, Path: []string{"desired_count"}, From: "2", To: "10"},
+			want:   true,
+		},
+		{
+			name:   "value below maximum without minimum",
+			res:    group,
+			change: diff.Change{Type: diff.UPDATE, Path: []string{"desired_capacity"}, From: float64(2), To: float64(-1)},
+			want:   true,
+		},
+		{
+			name:   "other field of a resource with a range",
+			res:    service,
+			change: diff.Change{Type: diff.UPDATE, Path: []string{"task_definition"}, From: "api:1", To: "api:2"},
+			want:   false,
+		},
+		{
+			name:   "value matching regex",
+			res:    instance,
+			change: diff.Change{Type: diff.UPDATE, Path: []string{"tags", "owner"}, From: "team-web", To: "team-net"},
+			want:   true,
+		},
+		{
+			name:   "value not matching regex",
+			res:    instance,
+			change: diff.Change{Type: diff.UPDATE, Path: []string{"tags", "owner"}, From: "team-web", To: "john"},
+			want:   false,
+		},
+		{
+			name:   "removed value is never matching a regex",
+			res:    instance,
+			change: diff.Change{Type: diff.DELETE, Path: []string{"tags", "owner"}, From: "team-web", To: nil},
+			want:   false,
+		},
+		{
+			name:   "key added to a map",
+			res:    instance,
+			change: diff.Change{Type: diff.CREATE, Path: []string{"tags", "cost-center"}, From: nil, To: "42"},
+			want:   true,
+		},
+		{
+			name:   "key updated in a map",
+			res:    instance,
+			change: diff.Change{Type: diff.UPDATE, Path: []string{"tags", "env"}, From: "prod", To: "dev"},
+			want:   false,
+		},
+		{
+			name:   "value in set",
+			res:    instance,
+			change: diff.Change{Type: diff.UPDATE, Path: []string{"instance_type"}, From: "t3.micro", To: "t3.small"},
+			want:   true,
+		},
+		{
+			name:   "value not in set",
+			res:    instance,
+			change: diff.Change{Type: diff.UPDATE, Path: []string{"instance_type"}, From: "t3.micro", To: "m5.large"},
+			want:   false,
+		},
+		{
+			name:   "value with an escaped comma in set",
+			res:    instance,
+			change: diff.Change{Type: diff.UPDATE, Path: []string{"tags", "Description"}, From: "batch", To: "web, api"},
+			want:   true,
+		},
+		{
+			name:   "part of a value with an escaped comma not in set",
+			res:    instance,
+			change: diff.Change{Type: diff.UPDATE, Path: []string{"tags", "Description"}, From: "batch", To: "web"},
+			want:   false,
+		},
+		{
+			name:   "invalid regex is skipped",
+			res:    instance,
+			change: diff.Change{Type: diff.UPDATE, Path: []string{"ami"}, From: "ami-1", To: "ami-2"},
+			want:   false,
+		},
+		{
+			name:   "invalid range is skipped",
+			res:    instance,
+			change: diff.Change{Type: diff.UPDATE, Path: []string{"cpu_core_count"}, From: float64(1), To: float64(1)},
+			want:   false,
+		},
+	}
+
+	r := NewDriftIgnore("testdata/drift_ignore_predicates/.driftignore")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, r.IsChangeIgnored(tt.res, tt.change))
+			// Predicates never ignore a field wholesale
+			assert.False(t, r.IsFieldIgnored(tt.res, tt.change.Path))
+		})
+	}
+}
+
 func TestDriftIgnore_IsTypeIgnored(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -534,3 +655,72 @@ func TestDriftIgnore_IsTypeIgnored(t *testing.T) {
 		})
 	}
 }
+
+func TestReadDriftIgnoreRules(t *testing.T) {
+	rules, err := ReadDriftIgnoreRules("testdata/drift_ignore_annotations/.driftignore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !assert.Len(t, rules, 7) {
+		return
+	}
+
+	assert.Equal(t, 2, rules[0].Line)
+	assert.Equal(t, "aws_s3_bucket.expired", rules[0].Text)
+	assert.Equal(t, map[string]string{"expires": "2020-01-01", "owner": "team-net", "ticket": "NET-42"}, rules[0].Metadata)
+	assert.Equal(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), rules[0].Expires)
+	assert.Equal(t, "'aws_s3_bucket.expired' (owner=team-net ticket=NET-42)", rules[0].Description())
+
+	assert.Equal(t, "aws_instance.*.tags.owner regex(^team-)", rules[2].Text)
+	assert.True(t, rules[2].HasPredicate())
+	assert.Equal(t, "'aws_instance.*.tags.owner regex(^team-)'", rules[2].Description())
+
+	assert.True(t, rules[3].Expires.IsZero())
+	assert.False(t, rules[3].Expired(time.Now()))
+
+	assert.EqualError(t, rules[4].Err, "invalid expiry date 'tomorrow', expected YYYY-MM-DD")
+	assert.EqualError(t, rules[5].Err, "invalid annotation 'reviewed', expected key=value pairs")
+
+	assert.Equal(t, 10, rules[6].Line)
+	assert.Equal(t, "aws_s3_bucket.commented", rules[6].Text)
+	assert.NoError(t, rules[6].Err)
+	assert.Empty(t, rules[6].Metadata)
+
+	_, err = ReadDriftIgnoreRules("testdata/drift_ignore_no_file/.driftignore")
+	assert.Error(t, err)
+}
+
+func TestDriftIgnoreRule_Expired(t *testing.T) {
+	rule := &DriftIgnoreRule{Expires: time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)}
+
+	assert.False(t, rule.Expired(time.Date(2021, 5, 31, 23, 59, 59, 0, time.UTC)))
+	assert.True(t, rule.Expired(time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, rule.Expired(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, (&DriftIgnoreRule{}).Expired(time.Now()))
+}
+
+func TestDriftIgnore_ExpiredRules(t *testing.T) {
+	r := NewDriftIgnore("testdata/drift_ignore_annotations/.driftignore")
+
+	assert.True(t, r.IsResourceIgnored(&resource.Resource{Type: "aws_s3_bucket", Id: "active"}))
+	assert.False(t, r.IsResourceIgnored(&resource.Resource{Type: "aws_s3_bucket", Id: "expired"}))
+	assert.False(t, r.IsResourceIgnored(&resource.Resource{Type: "aws_s3_bucket", Id: "invalid_date"}))
+	assert.False(t, r.IsResourceIgnored(&resource.Resource{Type: "aws_s3_bucket", Id: "invalid_annotation"}))
+	assert.True(t, r.IsResourceIgnored(&resource.Resource{Type: "aws_s3_bucket", Id: "commented"}))
+
+	instance := &resource.Resource{Type: "aws_instance", Id: "i-1234"}
+	assert.False(t, r.IsChangeIgnored(instance, diff.Change{Type: diff.UPDATE, Path: []string{"tags", "owner"}, To: "team-net"}))
+	assert.True(t, r.IsChangeIgnored(instance, diff.Change{Type: diff.UPDATE, Path: []string{"instance_type"}, To: "t3.micro"}))
+
+	expired := r.ExpiredRules()
+	if !assert.Len(t, expired, 2) {
+		return
+	}
+	assert.Equal(t, 2, expired[0].Line)
+	assert.Equal(t, 4, expired[1].Line)
+	assert.Equal(
+		t,
+		"Driftignore rule 'aws_s3_bucket.expired' (owner=team-net ticket=NET-42) at testdata/drift_ignore_annotations/.driftignore:2 expired on 2020-01-01 and no longer ignores anything",
+		NewExpiredRuleAlert("testdata/drift_ignore_annotations/.driftignore", expired[0]).Message(),
+	)
+}
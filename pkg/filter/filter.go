@@ -1,9 +1,14 @@
 package filter
 
-import "github.com/cloudskiff/driftctl/pkg/resource"
+import (
+	"github.com/r3labs/diff/v2"
+
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
 
 type Filter interface {
 	IsTypeIgnored(ty resource.ResourceType) bool
 	IsResourceIgnored(res *resource.Resource) bool
 	IsFieldIgnored(res *resource.Resource, path []string) bool
+	IsChangeIgnored(res *resource.Resource, change diff.Change) bool
 }
@@ -3,6 +3,8 @@
 package filter
 
 import (
+	diff "github.com/r3labs/diff/v2"
+
 	resource "github.com/cloudskiff/driftctl/pkg/resource"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -12,6 +14,20 @@ type MockFilter struct {
 	mock.Mock
 }
 
+// IsChangeIgnored provides a mock function with given fields: res, change
+func (_m *MockFilter) IsChangeIgnored(res *resource.Resource, change diff.Change) bool {
+	ret := _m.Called(res, change)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*resource.Resource, diff.Change) bool); ok {
+		r0 = rf(res, change)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // IsFieldIgnored provides a mock function with given fields: res, path
 func (_m *MockFilter) IsFieldIgnored(res *resource.Resource, path []string) bool {
 	ret := _m.Called(res, path)
@@ -0,0 +1,156 @@
+package filter
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/r3labs/diff/v2"
+)
+
+// Field rules followed by a predicate only ignore changes when the remote value matches the predicate, e.g.
+//
+//	aws_ecs_service.*.desired_count range(1,10)
+//	aws_instance.*.tags.owner regex(^team-)
+//	aws_instance.*.instance_type in(t3.micro,t3.small)
+//	aws_instance.*.tags additions
+//
+// Values of the in predicate are separated by commas, a comma or a backslash in a value is escaped
+// with a backslash, e.g. in(web\, api,C:\\) matches "web, api" and "C:\".
+var (
+	predicateLineRegex = regexp.MustCompile(`^(.*?)\s+(regex|range|in)\((.*)\)\s*$`)
+	additionsLineRegex = regexp.MustCompile(`^(.*?)\s+additions\s*$`)
+)
+
+// changePredicate tells whether a change is ignored, given the change from the state value to the remote one
+type changePredicate func(change diff.Change) bool
+
+// parsePredicateLine splits a driftignore line into its pattern and predicate.
+// It returns a nil predicate for lines without predicate.
+func parsePredicateLine(line string) (string, changePredicate, error) {
+	if matches := additionsLineRegex.FindStringSubmatch(line); matches != nil {
+		return matches[1], isAddition, nil
+	}
+	matches := predicateLineRegex.FindStringSubmatch(line)
+	if matches == nil {
+		return line, nil, nil
+	}
+
+	pattern, name, args := matches[1], matches[2], matches[3]
+	var predicate changePredicate
+	var err error
+	switch name {
+	case "regex":
+		predicate, err = newRegexPredicate(args)
+	case "range":
+		predicate, err = newRangePredicate(args)
+	case "in":
+		predicate = newInPredicate(args)
+	}
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "invalid %s predicate", name)
+	}
+	return pattern, predicate, nil
+}
+
+// isAddition only ignores keys added to a map or elements added to a list
+func isAddition(change diff.Change) bool {
+	return change.Type == diff.CREATE
+}
+
+func newRegexPredicate(expr string) (changePredicate, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	return func(change diff.Change) bool {
+		return change.To != nil && re.MatchString(fmt.Sprint(change.To))
+	}, nil
+}
+
+// newRangePredicate parses inclusive bounds like 1,10, one of the bounds can be omitted
+func newRangePredicate(args string) (changePredicate, error) {
+	bounds := strings.Split(args, ",")
+	if len(bounds) != 2 {
+		return nil, errors.Errorf("expected a minimum and a maximum, got '%s'", args)
+	}
+	var limits [2]*float64
+	for i, bound := range bounds {
+		bound = strings.TrimSpace(bound)
+		if bound == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(bound, 64)
+		if err != nil {
+			return nil, errors.Errorf("'%s' is not a number", bound)
+		}
+		limits[i] = &value
+	}
+	min, max := limits[0], limits[1]
+	return func(change diff.Change) bool {
+		value, ok := toFloat(change.To)
+		if !ok {
+			return false
+		}
+		return (min == nil || value >= *min) && (max == nil || value <= *max)
+	}, nil
+}
+
+func newInPredicate(args string) changePredicate {
+	values := make(map[string]struct{})
+	for _, value := range splitInValues(args) {
+		values[strings.TrimSpace(value)] = struct{}{}
+	}
+	return func(change diff.Change) bool {
+		if change.To == nil {
+			return false
+		}
+		_, exist := values[fmt.Sprint(change.To)]
+		return exist
+	}
+}
+
+// splitInValues splits the arguments of the in predicate on commas that are not escaped with a backslash
+func splitInValues(args string) []string {
+	var values []string
+	var value strings.Builder
+	escaped := false
+	for _, r := range args {
+		switch {
+		case escaped:
+			value.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ',':
+			values = append(values, value.String())
+			value.Reset()
+		default:
+			value.WriteRune(r)
+		}
+	}
+	return append(values, value.String())
+}
+
+// toFloat converts numeric attributes, that may also be stored as strings, to a float
+func toFloat(value interface{}) (float64, bool) {
+	if value == nil {
+		return 0, false
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.String:
+		f, err := strconv.ParseFloat(v.String(), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,183 @@
+package filter
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/pkg/errors"
+	"github.com/r3labs/diff/v2"
+
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+// Metadata keys of driftignore rules
+const (
+	RuleMetadataExpires = "expires"
+	RuleMetadataOwner   = "owner"
+	RuleMetadataTicket  = "ticket"
+)
+
+// RuleExpiryLayout is the format of the expiry date of driftignore rules
+const RuleExpiryLayout = "2006-01-02"
+
+// Rules can be annotated with a trailing comment made of key=value pairs, e.g.
+//
+//	aws_s3_bucket.logs # expires=2026-12-01 owner=team-net ticket=NET-42
+//
+// Trailing comments without any '=' are free text and are ignored, e.g.
+//
+//	aws_s3_bucket.logs # managed by the logging team
+var annotationRegex = regexp.MustCompile(`\s+#([^#]*)$`)
+
+// DriftIgnoreRule is a line of a driftignore file
+type DriftIgnoreRule struct {
+	// Line is the line number of the rule in the file
+	Line int
+	// Text is the rule without its annotation
+	Text     string
+	Metadata map[string]string
+	// Expires is the date the rule stops matching, zero when the rule never expires
+	Expires time.Time
+	// Err is set when the rule is malformed, malformed rules never match
+	Err error
+
+	patterns  []gitignore.Pattern
+	predicate changePredicate
+}
+
+// ReadDriftIgnoreRules parses every rule of a driftignore file, skipping empty lines and comments
+func ReadDriftIgnoreRules(path string) ([]*DriftIgnoreRule, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var rules []*DriftIgnoreRule
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+
+		if len(strings.ReplaceAll(line, " ", "")) <= 0 {
+			continue // empty
+		}
+
+		if strings.HasPrefix(line, "#") {
+			continue // this is a comment
+		}
+
+		rules = append(rules, parseDriftIgnoreRule(lineNumber, line))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func parseDriftIgnoreRule(lineNumber int, line string) *DriftIgnoreRule {
+	rule := &DriftIgnoreRule{Line: lineNumber, Text: line}
+
+	if loc := annotationRegex.FindStringSubmatchIndex(line); loc != nil {
+		rule.Text = strings.TrimRight(line[:loc[0]], " \t")
+		if annotation := line[loc[2]:loc[3]]; strings.Contains(annotation, "=") {
+			rule.Metadata, rule.Expires, rule.Err = parseAnnotation(annotation)
+			if rule.Err != nil {
+				return rule
+			}
+		}
+	}
+
+	pattern, predicate, err := parsePredicateLine(rule.Text)
+	if err != nil {
+		rule.Err = err
+		return rule
+	}
+	rule.predicate = predicate
+
+	pattern = strings.ReplaceAll(pattern, "/", separator)
+	rule.patterns = []gitignore.Pattern{gitignore.ParsePattern(pattern, nil)}
+	if !strings.HasSuffix(pattern, "*") {
+		rule.patterns = append(rule.patterns, gitignore.ParsePattern(fmt.Sprintf("%s.*", pattern), nil))
+	}
+	return rule
+}
+
+func parseAnnotation(annotation string) (map[string]string, time.Time, error) {
+	metadata := make(map[string]string)
+	var expires time.Time
+	for _, field := range strings.Fields(annotation) {
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, time.Time{}, errors.Errorf("invalid annotation '%s', expected key=value pairs", field)
+		}
+		metadata[parts[0]] = parts[1]
+	}
+
+	if value, exist := metadata[RuleMetadataExpires]; exist {
+		date, err := time.Parse(RuleExpiryLayout, value)
+		if err != nil {
+			return nil, time.Time{}, errors.Errorf("invalid expiry date '%s', expected YYYY-MM-DD", value)
+		}
+		expires = date
+	}
+	return metadata, expires, nil
+}
+
+// Expired returns true once the expiry date of the rule is reached
+func (r *DriftIgnoreRule) Expired(now time.Time) bool {
+	return !r.Expires.IsZero() && !now.Before(r.Expires)
+}
+
+// IsNegated returns true for rules including back resources ignored by previous rules
+func (r *DriftIgnoreRule) IsNegated() bool {
+	return strings.HasPrefix(r.Text, "!")
+}
+
+// HasPredicate returns true for rules only ignoring the changes of fields matching a predicate
+func (r *DriftIgnoreRule) HasPredicate() bool {
+	return r.predicate != nil
+}
+
+// MatchesResource returns true when the rule ignores the whole resource
+func (r *DriftIgnoreRule) MatchesResource(res *resource.Resource) bool {
+	return r.predicate == nil && r.match(fmt.Sprintf("%s.%s", res.ResourceType(), res.ResourceId()))
+}
+
+// MatchesChange returns true when the rule ignores the change of a field of the resource
+func (r *DriftIgnoreRule) MatchesChange(res *resource.Resource, change diff.Change) bool {
+	full := fmt.Sprintf("%s.%s.%s", res.ResourceType(), res.ResourceId(), strings.Join(change.Path, "."))
+	if !r.match(full) {
+		return false
+	}
+	return r.predicate == nil || r.predicate(change)
+}
+
+func (r *DriftIgnoreRule) match(path string) bool {
+	path = strings.ReplaceAll(path, "/", separator)
+	for _, pattern := range r.patterns {
+		if pattern.Match([]string{path}, false) == gitignore.Exclude {
+			return true
+		}
+	}
+	return false
+}
+
+// Description returns the rule followed by its owner and ticket, when known
+func (r *DriftIgnoreRule) Description() string {
+	var details []string
+	for _, key := range []string{RuleMetadataOwner, RuleMetadataTicket} {
+		if value := r.Metadata[key]; value != "" {
+			details = append(details, fmt.Sprintf("%s=%s", key, value))
+		}
+	}
+	if len(details) == 0 {
+		return fmt.Sprintf("'%s'", r.Text)
+	}
+	return fmt.Sprintf("'%s' (%s)", r.Text, strings.Join(details, " "))
+}
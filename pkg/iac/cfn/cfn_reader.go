@@ -0,0 +1,182 @@
+package cfn
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/output"
+	"github.com/cloudskiff/driftctl/pkg/remote/aws/repository"
+	"github.com/cloudskiff/driftctl/pkg/remote/cache"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+const CloudformationReaderSupplier = "cfn"
+
+// OptionRegion selects the region of the stacks, e.g. cfn://my-stack?region=eu-west-3
+const OptionRegion = "region"
+
+// CloudformationReader reads the resources managed by CloudFormation stacks.
+// The path is a stack name or id, or a glob pattern matching stack names (e.g. cfn://prod-*).
+type CloudformationReader struct {
+	config     config.SupplierConfig
+	stack      string
+	repository repository.CloudformationRepository
+	factory    resource.ResourceFactory
+	progress   output.Progress
+	filter     filter.Filter
+}
+
+func NewReader(config config.SupplierConfig, progress output.Progress, factory resource.ResourceFactory, filter filter.Filter) (*CloudformationReader, error) {
+	stack := config.Path
+	values := url.Values{}
+	if idx := strings.Index(config.Path, "?"); idx >= 0 {
+		stack = config.Path[:idx]
+		var err error
+		if values, err = url.ParseQuery(config.Path[idx+1:]); err != nil {
+			return nil, errors.Wrapf(err, "Unable to parse options of '%s'", config.Path)
+		}
+	}
+	for key := range values {
+		if key != OptionRegion {
+			return nil, errors.Errorf("unsupported option '%s', supported options are: %s", key, OptionRegion)
+		}
+	}
+
+	sess := session.Must(session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	}))
+	if region := values.Get(OptionRegion); region != "" {
+		sess = sess.Copy(&awssdk.Config{Region: awssdk.String(region)})
+	}
+
+	return &CloudformationReader{
+		config:     config,
+		stack:      stack,
+		repository: repository.NewCloudformationRepository(sess, cache.New(100)),
+		factory:    factory,
+		progress:   progress,
+		filter:     filter,
+	}, nil
+}
+
+func (r *CloudformationReader) Resources() ([]*resource.Resource, error) {
+	logrus.WithFields(logrus.Fields{
+		"stack": r.stack,
+	}).Debug("Reading resources from CloudFormation stacks")
+	r.progress.Inc()
+
+	stacks, err := r.stacks()
+	if err != nil {
+		return nil, errors.Wrap(err, r.config.String())
+	}
+
+	results := make([]*resource.Resource, 0)
+	for _, stack := range stacks {
+		source := fmt.Sprintf("%s://%s", CloudformationReaderSupplier, stack)
+		resources, err := r.readStack(stack, source, "")
+		if err != nil {
+			return nil, errors.Wrap(err, r.config.String())
+		}
+		results = append(results, resources...)
+	}
+
+	return results, nil
+}
+
+// stacks returns the names of the root stacks to read
+func (r *CloudformationReader) stacks() ([]string, error) {
+	if !strings.ContainsAny(r.stack, "*?[{") {
+		return []string{r.stack}, nil
+	}
+
+	stacks, err := r.repository.ListAllStacks()
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to list CloudFormation stacks")
+	}
+
+	names := make([]string, 0)
+	for _, stack := range stacks {
+		// Nested stacks are read with their root stack
+		if stack.RootId != nil {
+			continue
+		}
+		if match, _ := doublestar.Match(r.stack, awssdk.StringValue(stack.StackName)); match {
+			names = append(names, awssdk.StringValue(stack.StackName))
+		}
+	}
+
+	if len(names) == 0 {
+		return nil, errors.Errorf("no CloudFormation stack was found in %s, exiting", r.stack)
+	}
+
+	return names, nil
+}
+
+// readStack reads the resources of a stack and of its nested stacks
+func (r *CloudformationReader) readStack(stack, source, namespace string) ([]*resource.Resource, error) {
+	stackResources, err := r.repository.ListAllStackResources(stack)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to read resources of CloudFormation stack '%s'", stack)
+	}
+
+	results := make([]*resource.Resource, 0, len(stackResources))
+	for _, stackRes := range stackResources {
+		cfnType := awssdk.StringValue(stackRes.ResourceType)
+		logicalId := awssdk.StringValue(stackRes.LogicalResourceId)
+		physicalId := awssdk.StringValue(stackRes.PhysicalResourceId)
+		logger := logrus.WithFields(logrus.Fields{
+			"stack":     stack,
+			"logicalId": logicalId,
+			"type":      cfnType,
+		})
+
+		// Resources that failed to be created or were deleted do not exist anymore
+		if physicalId == "" || awssdk.StringValue(stackRes.ResourceStatus) == cloudformation.ResourceStatusDeleteComplete {
+			logger.Debug("Skipping CloudFormation resource without physical id")
+			continue
+		}
+
+		if cfnType == cloudformationStackType {
+			nestedNamespace := logicalId
+			if namespace != "" {
+				nestedNamespace = strings.Join([]string{namespace, logicalId}, ".")
+			}
+			nested, err := r.readStack(physicalId, source, nestedNamespace)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, nested...)
+		}
+
+		ty, supported := supportedTypes[cfnType]
+		if !supported {
+			logger.Debug("Ignored unsupported resource from CloudFormation stack")
+			continue
+		}
+		if r.filter != nil && r.filter.IsTypeIgnored(resource.ResourceType(ty)) {
+			logger.Debug("Ignored resource from CloudFormation stack since it is ignored in filter")
+			continue
+		}
+
+		res := r.factory.CreateAbstractResource(ty, physicalId, map[string]interface{}{})
+		res.Source = resource.NewCloudformationSource(source, namespace, logicalId)
+		if stackArn, err := arn.Parse(awssdk.StringValue(stackRes.StackId)); err == nil {
+			res.Region = stackArn.Region
+			res.Account = stackArn.AccountID
+		}
+		results = append(results, res)
+	}
+
+	return results, nil
+}
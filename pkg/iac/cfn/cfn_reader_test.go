@@ -0,0 +1,223 @@
+package cfn
+
+import (
+	"errors"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/output"
+	"github.com/cloudskiff/driftctl/pkg/remote/aws/repository"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	resourceaws "github.com/cloudskiff/driftctl/pkg/resource/aws"
+	"github.com/cloudskiff/driftctl/pkg/terraform"
+	testresource "github.com/cloudskiff/driftctl/test/resource"
+)
+
+const testStackId = "arn:aws:cloudformation:eu-west-3:123456789012:stack/my-stack/8c0e2a70-2f7b-11ec-8d3d-0242ac130003"
+const testNestedStackId = "arn:aws:cloudformation:eu-west-3:123456789012:stack/my-stack-Network-1B2C3D/9d1f3b80-2f7b-11ec-8d3d-0242ac130003"
+
+func stackResource(stackId, cfnType, logicalId, physicalId, status string) *cloudformation.StackResource {
+	res := &cloudformation.StackResource{
+		StackId:           awssdk.String(stackId),
+		ResourceType:      awssdk.String(cfnType),
+		LogicalResourceId: awssdk.String(logicalId),
+		ResourceStatus:    awssdk.String(status),
+	}
+	if physicalId != "" {
+		res.PhysicalResourceId = awssdk.String(physicalId)
+	}
+	return res
+}
+
+func TestCloudformationReader_Resources(t *testing.T) {
+	tests := []struct {
+		name        string
+		stack       string
+		ignoredType string
+		mocks       func(repo *repository.MockCloudformationRepository)
+		want        []*resource.Resource
+		wantErr     string
+	}{
+		{
+			name:  "read stack resources",
+			stack: "my-stack",
+			mocks: func(repo *repository.MockCloudformationRepository) {
+				repo.On("ListAllStackResources", "my-stack").Return([]*cloudformation.StackResource{
+					stackResource(testStackId, "AWS::S3::Bucket", "Bucket", "my-stack-bucket-1x2y3z", cloudformation.ResourceStatusCreateComplete),
+					stackResource(testStackId, "AWS::SQS::Queue", "Queue", "https://sqs.eu-west-3.amazonaws.com/123456789012/my-stack-queue", cloudformation.ResourceStatusUpdateComplete),
+					stackResource(testStackId, "AWS::S3::BucketPolicy", "BucketPolicy", "my-stack-bucketpolicy-4a5b6c", cloudformation.ResourceStatusCreateComplete),
+					stackResource(testStackId, "AWS::IAM::Role", "FailedRole", "", cloudformation.ResourceStatusCreateFailed),
+					stackResource(testStackId, "AWS::IAM::User", "DeletedUser", "deleted-user", cloudformation.ResourceStatusDeleteComplete),
+					stackResource(testStackId, "AWS::CloudFormation::Stack", "Network", testNestedStackId, cloudformation.ResourceStatusCreateComplete),
+				}, nil)
+				repo.On("ListAllStackResources", testNestedStackId).Return([]*cloudformation.StackResource{
+					stackResource(testNestedStackId, "AWS::EC2::VPC", "Vpc", "vpc-0a1b2c3d", cloudformation.ResourceStatusCreateComplete),
+				}, nil)
+			},
+			want: []*resource.Resource{
+				{
+					Id:      "my-stack-bucket-1x2y3z",
+					Type:    resourceaws.AwsS3BucketResourceType,
+					Source:  resource.NewCloudformationSource("cfn://my-stack", "", "Bucket"),
+					Region:  "eu-west-3",
+					Account: "123456789012",
+				},
+				{
+					Id:      "https://sqs.eu-west-3.amazonaws.com/123456789012/my-stack-queue",
+					Type:    resourceaws.AwsSqsQueueResourceType,
+					Source:  resource.NewCloudformationSource("cfn://my-stack", "", "Queue"),
+					Region:  "eu-west-3",
+					Account: "123456789012",
+				},
+				{
+					Id:      "vpc-0a1b2c3d",
+					Type:    resourceaws.AwsVpcResourceType,
+					Source:  resource.NewCloudformationSource("cfn://my-stack", "Network", "Vpc"),
+					Region:  "eu-west-3",
+					Account: "123456789012",
+				},
+				{
+					Id:      testNestedStackId,
+					Type:    resourceaws.AwsCloudformationStackResourceType,
+					Source:  resource.NewCloudformationSource("cfn://my-stack", "", "Network"),
+					Region:  "eu-west-3",
+					Account: "123456789012",
+				},
+			},
+		},
+		{
+			name:  "read stacks matching a glob pattern",
+			stack: "prod-*",
+			mocks: func(repo *repository.MockCloudformationRepository) {
+				repo.On("ListAllStacks").Return([]*cloudformation.Stack{
+					{StackName: awssdk.String("prod-app"), StackId: awssdk.String(testStackId)},
+					{StackName: awssdk.String("prod-app-Network-1B2C3D"), StackId: awssdk.String(testNestedStackId), RootId: awssdk.String(testStackId)},
+					{StackName: awssdk.String("staging-app")},
+				}, nil)
+				repo.On("ListAllStackResources", "prod-app").Return([]*cloudformation.StackResource{
+					stackResource(testStackId, "AWS::Lambda::Function", "Function", "prod-app-function", cloudformation.ResourceStatusCreateComplete),
+				}, nil)
+			},
+			want: []*resource.Resource{
+				{
+					Id:      "prod-app-function",
+					Type:    resourceaws.AwsLambdaFunctionResourceType,
+					Source:  resource.NewCloudformationSource("cfn://prod-app", "", "Function"),
+					Region:  "eu-west-3",
+					Account: "123456789012",
+				},
+			},
+		},
+		{
+			name:        "read stack resources with ignored resource type",
+			stack:       "my-stack",
+			ignoredType: resourceaws.AwsS3BucketResourceType,
+			mocks: func(repo *repository.MockCloudformationRepository) {
+				repo.On("ListAllStackResources", "my-stack").Return([]*cloudformation.StackResource{
+					stackResource(testStackId, "AWS::S3::Bucket", "Bucket", "my-stack-bucket-1x2y3z", cloudformation.ResourceStatusCreateComplete),
+					stackResource(testStackId, "AWS::DynamoDB::Table", "Table", "my-stack-table", cloudformation.ResourceStatusCreateComplete),
+				}, nil)
+			},
+			want: []*resource.Resource{
+				{
+					Id:      "my-stack-table",
+					Type:    resourceaws.AwsDynamodbTableResourceType,
+					Source:  resource.NewCloudformationSource("cfn://my-stack", "", "Table"),
+					Region:  "eu-west-3",
+					Account: "123456789012",
+				},
+			},
+		},
+		{
+			name:  "no stack matching a glob pattern",
+			stack: "prod-*",
+			mocks: func(repo *repository.MockCloudformationRepository) {
+				repo.On("ListAllStacks").Return([]*cloudformation.Stack{
+					{StackName: awssdk.String("staging-app")},
+				}, nil)
+			},
+			wantErr: "cfn://prod-*: no CloudFormation stack was found in prod-*, exiting",
+		},
+		{
+			name:  "unknown stack",
+			stack: "my-stack",
+			mocks: func(repo *repository.MockCloudformationRepository) {
+				repo.On("ListAllStackResources", "my-stack").Return(nil, errors.New("ValidationError: Stack with id my-stack does not exist"))
+			},
+			wantErr: "cfn://my-stack: Unable to read resources of CloudFormation stack 'my-stack': ValidationError: Stack with id my-stack does not exist",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			progress := &output.MockProgress{}
+			progress.On("Inc").Return().Times(1)
+
+			repo := &repository.MockCloudformationRepository{}
+			tt.mocks(repo)
+
+			schemaRepository := testresource.InitFakeSchemaRepository(terraform.AWS, "3.19.0")
+			resourceaws.InitResourcesMetadata(schemaRepository)
+			factory := terraform.NewTerraformResourceFactory(schemaRepository)
+
+			testFilter := &filter.MockFilter{}
+			testFilter.On("IsTypeIgnored", resource.ResourceType(tt.ignoredType)).Return(true)
+			testFilter.On("IsTypeIgnored", mock.Anything).Return(false)
+
+			r := &CloudformationReader{
+				config:     config.SupplierConfig{Key: CloudformationReaderSupplier, Path: tt.stack},
+				stack:      tt.stack,
+				repository: repo,
+				factory:    factory,
+				progress:   progress,
+				filter:     testFilter,
+			}
+
+			got, err := r.Resources()
+			repo.AssertExpectations(t)
+			progress.AssertExpectations(t)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Len(t, got, len(tt.want))
+			for i, want := range tt.want {
+				assert.Equal(t, want.Id, got[i].Id)
+				assert.Equal(t, want.Type, got[i].Type)
+				assert.Equal(t, want.Source, got[i].Source)
+				assert.Equal(t, want.Region, got[i].Region)
+				assert.Equal(t, want.Account, got[i].Account)
+			}
+		})
+	}
+}
+
+func TestNewReader(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		wantStack string
+		wantErr   string
+	}{
+		{name: "stack name", path: "my-stack", wantStack: "my-stack"},
+		{name: "stack with region", path: "prod-*?region=eu-west-3", wantStack: "prod-*"},
+		{name: "unsupported option", path: "my-stack?profile=prod", wantErr: "unsupported option 'profile', supported options are: region"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewReader(config.SupplierConfig{Key: CloudformationReaderSupplier, Path: tt.path}, &output.MockProgress{}, nil, nil)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantStack, r.stack)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package cfn
+
+import (
+	"github.com/cloudskiff/driftctl/pkg/resource/aws"
+)
+
+const cloudformationStackType = "AWS::CloudFormation::Stack"
+
+// supportedTypes maps CloudFormation resource types to terraform resource types.
+// Only types whose CloudFormation physical id is the terraform id are listed,
+// so resources read from stacks match the ones enumerated by driftctl.
+var supportedTypes = map[string]string{
+	"AWS::ApiGateway::ApiKey":         aws.AwsApiGatewayApiKeyResourceType,
+	"AWS::ApiGateway::DomainName":     aws.AwsApiGatewayDomainNameResourceType,
+	"AWS::ApiGateway::RestApi":        aws.AwsApiGatewayRestApiResourceType,
+	"AWS::ApiGateway::VpcLink":        aws.AwsApiGatewayVpcLinkResourceType,
+	cloudformationStackType:           aws.AwsCloudformationStackResourceType,
+	"AWS::CloudFront::Distribution":   aws.AwsCloudfrontDistributionResourceType,
+	"AWS::DynamoDB::Table":            aws.AwsDynamodbTableResourceType,
+	"AWS::EC2::Instance":              aws.AwsInstanceResourceType,
+	"AWS::EC2::InternetGateway":       aws.AwsInternetGatewayResourceType,
+	"AWS::EC2::KeyPair":               aws.AwsKeyPairResourceType,
+	"AWS::EC2::NatGateway":            aws.AwsNatGatewayResourceType,
+	"AWS::EC2::NetworkAcl":            aws.AwsNetworkACLResourceType,
+	"AWS::EC2::RouteTable":            aws.AwsRouteTableResourceType,
+	"AWS::EC2::SecurityGroup":         aws.AwsSecurityGroupResourceType,
+	"AWS::EC2::Subnet":                aws.AwsSubnetResourceType,
+	"AWS::EC2::Volume":                aws.AwsEbsVolumeResourceType,
+	"AWS::EC2::VPC":                   aws.AwsVpcResourceType,
+	"AWS::ECR::Repository":            aws.AwsEcrRepositoryResourceType,
+	"AWS::IAM::AccessKey":             aws.AwsIamAccessKeyResourceType,
+	"AWS::IAM::ManagedPolicy":         aws.AwsIamPolicyResourceType,
+	"AWS::IAM::Role":                  aws.AwsIamRoleResourceType,
+	"AWS::IAM::User":                  aws.AwsIamUserResourceType,
+	"AWS::KMS::Alias":                 aws.AwsKmsAliasResourceType,
+	"AWS::KMS::Key":                   aws.AwsKmsKeyResourceType,
+	"AWS::Lambda::EventSourceMapping": aws.AwsLambdaEventSourceMappingResourceType,
+	"AWS::Lambda::Function":           aws.AwsLambdaFunctionResourceType,
+	"AWS::RDS::DBCluster":             aws.AwsRDSClusterResourceType,
+	"AWS::RDS::DBInstance":            aws.AwsDbInstanceResourceType,
+	"AWS::RDS::DBSubnetGroup":         aws.AwsDbSubnetGroupResourceType,
+	"AWS::Route53::HealthCheck":       aws.AwsRoute53HealthCheckResourceType,
+	"AWS::Route53::HostedZone":        aws.AwsRoute53ZoneResourceType,
+	"AWS::S3::Bucket":                 aws.AwsS3BucketResourceType,
+	"AWS::SNS::Subscription":          aws.AwsSnsTopicSubscriptionResourceType,
+	"AWS::SNS::Topic":                 aws.AwsSnsTopicResourceType,
+	"AWS::SQS::Queue":                 aws.AwsSqsQueueResourceType,
+}
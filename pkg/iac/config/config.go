@@ -1,6 +1,10 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
 
 type SupplierConfig struct {
 	Key     string
@@ -17,7 +21,21 @@ func (c *SupplierConfig) String() string {
 		str += "://"
 	}
 	if c.Path != "" {
-		str += c.Path
+		str += c.RedactedPath()
 	}
 	return str
 }
+
+// RedactedPath returns the path without the credentials it may hold, so it can be displayed or stored.
+// Only pg paths embed credentials, as the USER:PASSWORD@ part of their connection URL.
+func (c *SupplierConfig) RedactedPath() string {
+	if c.Backend != "pg" {
+		return c.Path
+	}
+	u, err := url.Parse("postgres://" + c.Path)
+	if err != nil || u.User == nil {
+		return c.Path
+	}
+	u.User = nil
+	return strings.TrimPrefix(u.String(), "postgres://")
+}
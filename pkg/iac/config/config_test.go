@@ -31,6 +31,24 @@ func TestSupplierConfig_String(t *testing.T) {
 			},
 			want: "tfstate+s3://my-bucket/terraform.tfstate",
 		},
+		{
+			name: "test pg config with credentials",
+			config: SupplierConfig{
+				Key:     "tfstate",
+				Backend: "pg",
+				Path:    "user:secret@localhost:5432/terraform?sslmode=disable&workspace=prod",
+			},
+			want: "tfstate+pg://localhost:5432/terraform?sslmode=disable&workspace=prod",
+		},
+		{
+			name: "test pg config without credentials",
+			config: SupplierConfig{
+				Key:     "tfstate",
+				Backend: "pg",
+				Path:    "localhost:5432/terraform",
+			},
+			want: "tfstate+pg://localhost:5432/terraform",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
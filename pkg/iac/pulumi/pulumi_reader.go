@@ -0,0 +1,123 @@
+package pulumi
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/output"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+const PulumiReaderSupplier = "pulumi"
+
+const urnPrefix = "urn:pulumi:"
+
+// stackExport is the subset of the pulumi stack export output read by driftctl
+type stackExport struct {
+	Version    int         `json:"version"`
+	Deployment *deployment `json:"deployment"`
+}
+
+type deployment struct {
+	Resources []stackResource `json:"resources"`
+}
+
+type stackResource struct {
+	Urn      string `json:"urn"`
+	Custom   bool   `json:"custom"`
+	Delete   bool   `json:"delete"`
+	External bool   `json:"external"`
+	Id       string `json:"id"`
+	Type     string `json:"type"`
+}
+
+// PulumiReader reads the resources managed by a Pulumi stack from its export.
+// Only resources of Terraform bridged providers are read, their Pulumi id being the terraform one.
+type PulumiReader struct {
+	config   config.SupplierConfig
+	factory  resource.ResourceFactory
+	progress output.Progress
+	filter   filter.Filter
+}
+
+func NewReader(config config.SupplierConfig, progress output.Progress, factory resource.ResourceFactory, filter filter.Filter) *PulumiReader {
+	return &PulumiReader{
+		config:   config,
+		factory:  factory,
+		progress: progress,
+		filter:   filter,
+	}
+}
+
+func (r *PulumiReader) Resources() ([]*resource.Resource, error) {
+	logrus.WithFields(logrus.Fields{
+		"path": r.config.Path,
+	}).Debug("Reading resources from Pulumi stack export")
+	r.progress.Inc()
+
+	export, err := readStackExport(r.config.Path)
+	if err != nil {
+		return nil, errors.Wrap(err, r.config.String())
+	}
+
+	results := make([]*resource.Resource, 0)
+	for _, stackRes := range export.Deployment.Resources {
+		logger := logrus.WithFields(logrus.Fields{
+			"urn":  stackRes.Urn,
+			"type": stackRes.Type,
+		})
+
+		// Component resources only group other resources, and external ones are read but not managed by the stack
+		if !stackRes.Custom || stackRes.External || stackRes.Delete || stackRes.Id == "" {
+			logger.Debug("Skipping Pulumi resource as it is not managed by the stack")
+			continue
+		}
+
+		ty, supported := terraformType(stackRes.Type)
+		if !supported {
+			logger.Debug("Ignored unsupported resource from Pulumi stack")
+			continue
+		}
+		if r.filter != nil && r.filter.IsTypeIgnored(resource.ResourceType(ty)) {
+			logger.Debug("Ignored resource from Pulumi stack since it is ignored in filter")
+			continue
+		}
+
+		res := r.factory.CreateAbstractResource(ty, stackRes.Id, map[string]interface{}{})
+		res.Source = resource.NewPulumiSource(r.config.String(), stackName(stackRes.Urn), stackRes.Urn)
+		results = append(results, res)
+	}
+
+	return results, nil
+}
+
+// stackName returns the stack of a URN, formatted as urn:pulumi:STACK::PROJECT::TYPE::NAME
+func stackName(urn string) string {
+	return strings.SplitN(strings.TrimPrefix(urn, urnPrefix), "::", 2)[0]
+}
+
+func readStackExport(path string) (*stackExport, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	export := stackExport{}
+	if err := json.Unmarshal(content, &export); err != nil {
+		return nil, errors.Wrap(err, "Unable to parse Pulumi stack export")
+	}
+	if export.Version == 0 || export.Deployment == nil {
+		return nil, errors.New("given file is not a Pulumi stack export, it must be generated with pulumi stack export")
+	}
+	if export.Version > 3 {
+		return nil, errors.Errorf("Unsupported Pulumi stack export version %d", export.Version)
+	}
+
+	return &export, nil
+}
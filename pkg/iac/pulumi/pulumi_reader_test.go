@@ -0,0 +1,154 @@
+package pulumi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/output"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	resourceaws "github.com/cloudskiff/driftctl/pkg/resource/aws"
+	resourcegithub "github.com/cloudskiff/driftctl/pkg/resource/github"
+	"github.com/cloudskiff/driftctl/pkg/terraform"
+	testresource "github.com/cloudskiff/driftctl/test/resource"
+)
+
+func TestPulumiReader_Resources(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		ignoredType string
+		want        []*resource.Resource
+		wantErr     string
+	}{
+		{
+			name: "read stack export",
+			path: "testdata/stack-export.json",
+			want: []*resource.Resource{
+				{
+					Id:     "assets-4f1c2d3",
+					Type:   resourceaws.AwsS3BucketResourceType,
+					Source: resource.NewPulumiSource("pulumi://testdata/stack-export.json", "dev", "urn:pulumi:dev::infra::aws:s3/bucket:Bucket::assets"),
+				},
+				{
+					Id:     "vpc-0a1b2c3d4e5f",
+					Type:   resourceaws.AwsVpcResourceType,
+					Source: resource.NewPulumiSource("pulumi://testdata/stack-export.json", "dev", "urn:pulumi:dev::infra::my:network:Vpc$aws:ec2/vpc:Vpc::main"),
+				},
+				{
+					Id:     "db-3e4f5a6",
+					Type:   resourceaws.AwsDbInstanceResourceType,
+					Source: resource.NewPulumiSource("pulumi://testdata/stack-export.json", "dev", "urn:pulumi:dev::infra::aws:rds/instance:Instance::db"),
+				},
+				{
+					Id:     "infra",
+					Type:   resourcegithub.GithubRepositoryResourceType,
+					Source: resource.NewPulumiSource("pulumi://testdata/stack-export.json", "dev", "urn:pulumi:dev::infra::github:index/repository:Repository::infra"),
+				},
+			},
+		},
+		{
+			name:        "read stack export with ignored resource type",
+			path:        "testdata/stack-export.json",
+			ignoredType: resourceaws.AwsVpcResourceType,
+			want: []*resource.Resource{
+				{
+					Id:   "assets-4f1c2d3",
+					Type: resourceaws.AwsS3BucketResourceType,
+				},
+				{
+					Id:   "db-3e4f5a6",
+					Type: resourceaws.AwsDbInstanceResourceType,
+				},
+				{
+					Id:   "infra",
+					Type: resourcegithub.GithubRepositoryResourceType,
+				},
+			},
+		},
+		{
+			name:    "read a terraform state",
+			path:    "testdata/not_an_export.json",
+			wantErr: "pulumi://testdata/not_an_export.json: given file is not a Pulumi stack export, it must be generated with pulumi stack export",
+		},
+		{
+			name:    "read an unsupported export version",
+			path:    "testdata/unsupported_version.json",
+			wantErr: "pulumi://testdata/unsupported_version.json: Unsupported Pulumi stack export version 4",
+		},
+		{
+			name:    "read a missing export",
+			path:    "testdata/missing.json",
+			wantErr: "pulumi://testdata/missing.json: open testdata/missing.json: no such file or directory",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			progress := &output.MockProgress{}
+			progress.On("Inc").Return().Times(1)
+
+			repo := testresource.InitFakeSchemaRepository(terraform.AWS, "3.19.0")
+			resourceaws.InitResourcesMetadata(repo)
+			factory := terraform.NewTerraformResourceFactory(repo)
+
+			testFilter := &filter.MockFilter{}
+			testFilter.On("IsTypeIgnored", resource.ResourceType(tt.ignoredType)).Return(true)
+			testFilter.On("IsTypeIgnored", mock.Anything).Return(false)
+
+			r := NewReader(config.SupplierConfig{Key: PulumiReaderSupplier, Path: tt.path}, progress, factory, testFilter)
+
+			got, err := r.Resources()
+			progress.AssertExpectations(t)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Len(t, got, len(tt.want))
+			for i, want := range tt.want {
+				assert.Equal(t, want.Id, got[i].Id)
+				assert.Equal(t, want.Type, got[i].Type)
+				if want.Source != nil {
+					assert.Equal(t, want.Source, got[i].Source)
+				}
+			}
+		})
+	}
+}
+
+func TestTerraformType(t *testing.T) {
+	tests := []struct {
+		pulumiType string
+		want       string
+	}{
+		{pulumiType: "aws:s3/bucket:Bucket", want: "aws_s3_bucket"},
+		{pulumiType: "aws:s3/bucketPolicy:BucketPolicy", want: "aws_s3_bucket_policy"},
+		{pulumiType: "aws:ec2/instance:Instance", want: "aws_instance"},
+		{pulumiType: "aws:ec2/securityGroupRule:SecurityGroupRule", want: "aws_security_group_rule"},
+		{pulumiType: "aws:ebs/volume:Volume", want: "aws_ebs_volume"},
+		{pulumiType: "aws:rds/instance:Instance", want: "aws_db_instance"},
+		{pulumiType: "aws:rds/clusterInstance:ClusterInstance", want: "aws_rds_cluster_instance"},
+		{pulumiType: "aws:apigateway/restApi:RestApi", want: "aws_api_gateway_rest_api"},
+		{pulumiType: "aws:lightsail/instance:Instance", want: ""},
+		{pulumiType: "azure:core/resourceGroup:ResourceGroup", want: "azurerm_resource_group"},
+		{pulumiType: "azure:network/virtualNetwork:VirtualNetwork", want: "azurerm_virtual_network"},
+		{pulumiType: "azure:storage/account:Account", want: "azurerm_storage_account"},
+		{pulumiType: "azure:privatedns/aRecord:ARecord", want: "azurerm_private_dns_a_record"},
+		{pulumiType: "gcp:compute/instance:Instance", want: "google_compute_instance"},
+		{pulumiType: "gcp:cloudrun/service:Service", want: "google_cloud_run_service"},
+		{pulumiType: "gcp:projects/iAMMember:IAMMember", want: "google_project_iam_member"},
+		{pulumiType: "github:index/branchProtection:BranchProtection", want: "github_branch_protection"},
+		{pulumiType: "kubernetes:core/v1:Namespace", want: ""},
+		{pulumiType: "pulumi:providers:aws", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pulumiType, func(t *testing.T) {
+			got, supported := terraformType(tt.pulumiType)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want != "", supported)
+		})
+	}
+}
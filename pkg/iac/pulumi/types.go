@@ -0,0 +1,102 @@
+package pulumi
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+// providerPrefixes maps the packages of Terraform bridged Pulumi providers to the prefix of terraform resource types
+var providerPrefixes = map[string]string{
+	"aws":    "aws",
+	"azure":  "azurerm",
+	"gcp":    "google",
+	"github": "github",
+}
+
+// moduleAliases maps Pulumi modules named differently in terraform resource types
+var moduleAliases = map[string]string{
+	"aws:apigateway":   "api_gateway",
+	"azure:privatedns": "private_dns",
+	"gcp:cloudrun":     "cloud_run",
+}
+
+// unprefixedModules lists the Pulumi modules whose resources are not prefixed by the module name in terraform
+var unprefixedModules = map[string]bool{
+	"aws:ec2":       true,
+	"azure:compute": true,
+	"azure:core":    true,
+	"azure:network": true,
+}
+
+// typeOverrides maps Pulumi types that do not follow the naming of terraform resource types
+var typeOverrides = map[string]string{
+	"aws:rds/instance":                "aws_db_instance",
+	"aws:rds/subnetGroup":             "aws_db_subnet_group",
+	"azure:containerservice/registry": "azurerm_container_registry",
+	"azure:lb/loadBalancer":           "azurerm_lb",
+	"gcp:projects/iAMBinding":         "google_project_iam_binding",
+	"gcp:projects/iAMMember":          "google_project_iam_member",
+	"gcp:projects/iAMPolicy":          "google_project_iam_policy",
+	"gcp:storage/bucketIAMBinding":    "google_storage_bucket_iam_binding",
+	"gcp:storage/bucketIAMMember":     "google_storage_bucket_iam_member",
+	"gcp:storage/bucketIAMPolicy":     "google_storage_bucket_iam_policy",
+}
+
+// terraformType returns the terraform resource type of a Pulumi resource type of a bridged provider,
+// e.g. aws:s3/bucket:Bucket is aws_s3_bucket and aws:ec2/instance:Instance is aws_instance
+func terraformType(pulumiType string) (string, bool) {
+	parts := strings.Split(pulumiType, ":")
+	if len(parts) != 3 {
+		return "", false
+	}
+	pkg, moduleMember := parts[0], parts[1]
+
+	if ty, exists := typeOverrides[pkg+":"+moduleMember]; exists {
+		return ty, true
+	}
+	prefix, exists := providerPrefixes[pkg]
+	if !exists {
+		return "", false
+	}
+
+	module, member := "index", moduleMember
+	if idx := strings.Index(moduleMember, "/"); idx >= 0 {
+		module, member = moduleMember[:idx], moduleMember[idx+1:]
+	}
+	member = toSnakeCase(member)
+
+	candidates := make([]string, 0, 2)
+	if module != "index" {
+		moduleName, aliased := moduleAliases[pkg+":"+module]
+		if !aliased {
+			moduleName = toSnakeCase(module)
+		}
+		candidates = append(candidates, strings.Join([]string{prefix, moduleName, member}, "_"))
+	}
+	if module == "index" || unprefixedModules[pkg+":"+module] {
+		candidates = append(candidates, strings.Join([]string{prefix, member}, "_"))
+	}
+
+	for _, ty := range candidates {
+		if resource.IsResourceTypeSupported(ty) {
+			return ty, true
+		}
+	}
+	return "", false
+}
+
+func toSnakeCase(str string) string {
+	var b strings.Builder
+	for i, r := range str {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteRune('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
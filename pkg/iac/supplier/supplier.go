@@ -11,15 +11,25 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
+	"github.com/cloudskiff/driftctl/pkg/iac/cfn"
 	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/pulumi"
 
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/plan"
 	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/terragrunt"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/tfdir"
 
 	"github.com/cloudskiff/driftctl/pkg/resource"
 )
 
 var supportedSuppliers = []string{
 	state.TerraformStateReaderSupplier,
+	tfdir.TerraformDirSupplier,
+	plan.TerraformPlanReaderSupplier,
+	cfn.CloudformationReaderSupplier,
+	pulumi.PulumiReaderSupplier,
+	terragrunt.TerragruntSupplier,
 }
 
 func IsSupplierSupported(supplierKey string) bool {
@@ -31,6 +41,11 @@ func IsSupplierSupported(supplierKey string) bool {
 	return false
 }
 
+// SupportsBackends returns whether the supplier reads states through a backend
+func SupportsBackends(supplierKey string) bool {
+	return supplierKey == state.TerraformStateReaderSupplier
+}
+
 func GetIACSupplier(configs []config.SupplierConfig,
 	library *terraform.ProviderLibrary,
 	backendOpts *backend.Options,
@@ -39,6 +54,11 @@ func GetIACSupplier(configs []config.SupplierConfig,
 	factory resource.ResourceFactory,
 	filter filter.Filter) (resource.Supplier, error) {
 
+	configs, err := resolveConfigs(configs)
+	if err != nil {
+		return nil, err
+	}
+
 	chainSupplier := NewIacChainSupplier()
 	for _, config := range configs {
 		if !IsSupplierSupported(config.Key) {
@@ -52,6 +72,12 @@ func GetIACSupplier(configs []config.SupplierConfig,
 		switch config.Key {
 		case state.TerraformStateReaderSupplier:
 			supplier, err = state.NewReader(config, library, backendOpts, progress, alerter, deserializer, filter)
+		case plan.TerraformPlanReaderSupplier:
+			supplier = plan.NewReader(config, library, progress, deserializer, filter)
+		case cfn.CloudformationReaderSupplier:
+			supplier, err = cfn.NewReader(config, progress, factory, filter)
+		case pulumi.PulumiReaderSupplier:
+			supplier = pulumi.NewReader(config, progress, factory, filter)
 		default:
 			return nil, errors.Errorf("Unsupported supplier '%s'", config.Key)
 		}
@@ -71,6 +97,34 @@ func GetIACSupplier(configs []config.SupplierConfig,
 	return chainSupplier, nil
 }
 
+// resolveConfigs replaces terraform working directories and terragrunt stacks by the configs of the states they use
+func resolveConfigs(configs []config.SupplierConfig) ([]config.SupplierConfig, error) {
+	resolved := make([]config.SupplierConfig, 0, len(configs))
+	for _, c := range configs {
+		switch c.Key {
+		case tfdir.TerraformDirSupplier:
+			dir, err := tfdir.NewWorkingDir(c.Path)
+			if err != nil {
+				return nil, err
+			}
+			stateConfigs, err := dir.SupplierConfigs()
+			if err != nil {
+				return nil, errors.Wrapf(err, "Unable to read terraform backend configuration from '%s'", c.String())
+			}
+			resolved = append(resolved, stateConfigs...)
+		case terragrunt.TerragruntSupplier:
+			stateConfigs, err := terragrunt.NewStack(c.Path).SupplierConfigs()
+			if err != nil {
+				return nil, errors.Wrapf(err, "Unable to read terragrunt configuration from '%s'", c.String())
+			}
+			resolved = append(resolved, stateConfigs...)
+		default:
+			resolved = append(resolved, c)
+		}
+	}
+	return resolved, nil
+}
+
 func GetSupportedSuppliers() []string {
 	return supportedSuppliers
 }
@@ -79,10 +133,12 @@ func GetSupportedSchemes() []string {
 	schemes := []string{
 		"tfstate://",
 	}
-	for _, supplier := range supportedSuppliers {
-		for _, backend := range backend.GetSupportedBackends() {
-			schemes = append(schemes, fmt.Sprintf("%s+%s://", supplier, backend))
-		}
+	for _, backend := range backend.GetSupportedBackends() {
+		schemes = append(schemes, fmt.Sprintf("%s+%s://", state.TerraformStateReaderSupplier, backend))
+	}
+	// Other suppliers do not read states through a backend
+	for _, supplier := range supportedSuppliers[1:] {
+		schemes = append(schemes, fmt.Sprintf("%s://", supplier))
 	}
 	return schemes
 }
@@ -69,6 +69,54 @@ func TestGetIACSupplier(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "test valid tfplan://plan.json",
+			args: args{
+				config: []config.SupplierConfig{
+					{Key: "tfplan", Backend: "", Path: "plan.json"},
+				},
+				options: &backend.Options{
+					Headers: map[string]string{},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "test valid cfn://my-stack",
+			args: args{
+				config: []config.SupplierConfig{
+					{Key: "cfn", Backend: "", Path: "my-stack"},
+				},
+				options: &backend.Options{
+					Headers: map[string]string{},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "test valid pulumi://stack-export.json",
+			args: args{
+				config: []config.SupplierConfig{
+					{Key: "pulumi", Backend: "", Path: "stack-export.json"},
+				},
+				options: &backend.Options{
+					Headers: map[string]string{},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "test missing terragrunt stack",
+			args: args{
+				config: []config.SupplierConfig{
+					{Key: "terragrunt", Backend: "", Path: "testdata/missing"},
+				},
+				options: &backend.Options{
+					Headers: map[string]string{},
+				},
+			},
+			wantErr: fmt.Errorf("Unable to read terragrunt configuration from 'terragrunt://testdata/missing': lstat testdata/missing: no such file or directory"),
+		},
 		{
 			name: "test valid multiples states",
 			args: args{
@@ -110,9 +158,19 @@ func TestGetSupportedSchemes(t *testing.T) {
 	want := []string{
 		"tfstate://",
 		"tfstate+s3://",
+		"tfstate+gs://",
+		"tfstate+azurerm://",
+		"tfstate+consul://",
+		"tfstate+pg://",
+		"tfstate+kubernetes://",
 		"tfstate+http://",
 		"tfstate+https://",
 		"tfstate+tfcloud://",
+		"tfdir://",
+		"tfplan://",
+		"cfn://",
+		"pulumi://",
+		"terragrunt://",
 	}
 
 	if got := GetSupportedSchemes(); !reflect.DeepEqual(got, want) {
@@ -0,0 +1,204 @@
+package plan
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/output"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/cloudskiff/driftctl/pkg/terraform"
+)
+
+const TerraformPlanReaderSupplier = "tfplan"
+
+const managedResourceMode = "managed"
+
+// plan is the subset of the terraform show -json output of a plan read by driftctl
+type plan struct {
+	FormatVersion    string  `json:"format_version"`
+	TerraformVersion string  `json:"terraform_version"`
+	PlannedValues    *values `json:"planned_values"`
+}
+
+type values struct {
+	RootModule module `json:"root_module"`
+}
+
+type module struct {
+	Address      string         `json:"address"`
+	Resources    []planResource `json:"resources"`
+	ChildModules []module       `json:"child_modules"`
+}
+
+type planResource struct {
+	Address      string          `json:"address"`
+	Mode         string          `json:"mode"`
+	Type         string          `json:"type"`
+	Name         string          `json:"name"`
+	ProviderName string          `json:"provider_name"`
+	Values       json.RawMessage `json:"values"`
+}
+
+// TerraformPlanReader reads the expected resources from the planned values of a terraform plan,
+// i.e. the state the infrastructure will be in once the plan is applied
+type TerraformPlanReader struct {
+	library      *terraform.ProviderLibrary
+	config       config.SupplierConfig
+	deserializer *resource.Deserializer
+	progress     output.Progress
+	filter       filter.Filter
+}
+
+func NewReader(config config.SupplierConfig, library *terraform.ProviderLibrary, progress output.Progress, deserializer *resource.Deserializer, filter filter.Filter) *TerraformPlanReader {
+	return &TerraformPlanReader{
+		library:      library,
+		config:       config,
+		deserializer: deserializer,
+		progress:     progress,
+		filter:       filter,
+	}
+}
+
+func (r *TerraformPlanReader) Resources() ([]*resource.Resource, error) {
+	logrus.WithFields(logrus.Fields{
+		"path": r.config.Path,
+	}).Debug("Reading resources from plan")
+	r.progress.Inc()
+
+	p, err := readPlan(r.config.Path)
+	if err != nil {
+		return nil, errors.Wrap(err, r.config.String())
+	}
+
+	results := make([]*resource.Resource, 0)
+	modules := []module{p.PlannedValues.RootModule}
+	for len(modules) > 0 {
+		mod := modules[0]
+		modules = append(modules[1:], mod.ChildModules...)
+
+		logrus.WithFields(logrus.Fields{
+			"module":        mod.Address,
+			"resourceCount": len(mod.Resources),
+		}).Debug("Found module in plan")
+
+		for _, planRes := range mod.Resources {
+			res, err := r.decode(mod.Address, planRes)
+			if err != nil {
+				return nil, errors.Wrap(err, r.config.String())
+			}
+			if res != nil {
+				results = append(results, res)
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// decode deserializes a planned resource, it returns nil for resources that cannot be compared
+func (r *TerraformPlanReader) decode(moduleAddress string, planRes planResource) (*resource.Resource, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"address": planRes.Address,
+		"type":    planRes.Type,
+	})
+
+	if planRes.Mode != managedResourceMode {
+		logger.WithField("mode", planRes.Mode).Debug("Skipping plan entry as it is not a managed resource")
+		return nil, nil
+	}
+	if !resource.IsResourceTypeSupported(planRes.Type) {
+		logger.Debug("Ignored unsupported resource from plan")
+		return nil, nil
+	}
+	if r.filter != nil && r.filter.IsTypeIgnored(resource.ResourceType(planRes.Type)) {
+		logger.Debug("Ignored resource from plan since it is ignored in filter")
+		return nil, nil
+	}
+
+	// Provider names are fully qualified since terraform 0.13 (e.g. registry.terraform.io/hashicorp/aws)
+	providerType := planRes.ProviderName[strings.LastIndex(planRes.ProviderName, "/")+1:]
+	provider := r.library.Provider(providerType)
+	if provider == nil {
+		logger.WithField("providerKey", providerType).Debug("Unsupported provider found in plan")
+		return nil, nil
+	}
+	schema, exists := provider.Schema()[planRes.Type]
+	if !exists || schema.Block == nil {
+		logger.Debug("Resource type not found in provider schema")
+		return nil, nil
+	}
+
+	val, err := decodeValues(planRes.Values, schema.Block.ImpliedType())
+	if err != nil {
+		logger.Error("Unable to decode resource from plan")
+		return nil, errors.Wrapf(err, "Unable to decode %s", planRes.Address)
+	}
+
+	// Values only known after apply are missing from the plan, such resources are yet to be created
+	id := val.GetAttr("id")
+	if id.IsNull() || id.AsString() == "" {
+		logger.Debug("Skipping planned resource as its id is only known after apply")
+		return nil, nil
+	}
+
+	res, err := r.deserializer.DeserializeOne(planRes.Type, val)
+	if err != nil {
+		logger.Warnf("Could not read from plan: %+v", err)
+		return nil, nil
+	}
+	res.Source = resource.NewTerraformStateSource(r.config.String(), moduleAddress, planRes.Name)
+	return res, nil
+}
+
+// decodeValues converts the planned values of a resource to the type of its schema,
+// ignoring attributes unknown to the schema so plans generated with a more recent provider can be read
+func decodeValues(raw json.RawMessage, ty cty.Type) (cty.Value, error) {
+	var attrs map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &attrs); err != nil {
+		return cty.NilVal, err
+	}
+	for name := range attrs {
+		if !ty.HasAttribute(name) {
+			delete(attrs, name)
+		}
+	}
+
+	filtered, err := json.Marshal(attrs)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	return ctyjson.Unmarshal(filtered, ty)
+}
+
+func readPlan(path string) (*plan, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	p := plan{}
+	if err := json.Unmarshal(content, &p); err != nil {
+		return nil, errors.Wrap(err, "Unable to parse plan")
+	}
+	if p.FormatVersion == "" || p.PlannedValues == nil {
+		return nil, errors.New("given file is not a JSON terraform plan, it must be generated with terraform show -json")
+	}
+	if major := strings.SplitN(p.FormatVersion, ".", 2)[0]; major != "0" && major != "1" {
+		return nil, errors.Errorf("Unsupported plan format version %s", p.FormatVersion)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"format_version":    p.FormatVersion,
+		"terraform_version": p.TerraformVersion,
+	}).Debug("Read terraform plan")
+
+	return &p, nil
+}
@@ -0,0 +1,148 @@
+package plan
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/output"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	resourcegithub "github.com/cloudskiff/driftctl/pkg/resource/github"
+	"github.com/cloudskiff/driftctl/pkg/terraform"
+	"github.com/cloudskiff/driftctl/test/goldenfile"
+	"github.com/cloudskiff/driftctl/test/mocks"
+	testresource "github.com/cloudskiff/driftctl/test/resource"
+)
+
+func TestTerraformPlanReader_Resources(t *testing.T) {
+	tests := []struct {
+		name        string
+		dirName     string
+		ignoredType string
+		want        []*resource.Resource
+		wantErr     string
+	}{
+		{
+			name:    "read planned values",
+			dirName: "planned_values",
+			want: []*resource.Resource{
+				{
+					Id:   "repo",
+					Type: resourcegithub.GithubRepositoryResourceType,
+					Attrs: &resource.Attributes{
+						"allow_merge_commit":     true,
+						"allow_rebase_merge":     true,
+						"allow_squash_merge":     false,
+						"archived":               false,
+						"default_branch":         "main",
+						"delete_branch_on_merge": false,
+						"description":            "Updated by the pending change",
+						"full_name":              "driftctl-test/repo",
+						"has_downloads":          false,
+						"has_issues":             true,
+						"has_projects":           false,
+						"has_wiki":               false,
+						"homepage_url":           "",
+						"id":                     "repo",
+						"is_template":            false,
+						"name":                   "repo",
+						"private":                true,
+						"topics":                 []interface{}{"terraform"},
+						"visibility":             "private",
+						"vulnerability_alerts":   false,
+					},
+					Source: resource.NewTerraformStateSource("tfplan://test/planned_values/plan.json", "", "repo"),
+				},
+				{
+					Id:   "4556715",
+					Type: resourcegithub.GithubTeamResourceType,
+					Attrs: &resource.Attributes{
+						"create_default_maintainer": false,
+						"description":               "Core team",
+						"id":                        "4556715",
+						"ldap_dn":                   "",
+						"members_count":             float64(1),
+						"name":                      "core",
+						"node_id":                   "MDQ6VGVhbTQ1NTY3MTU=",
+						"privacy":                   "closed",
+						"slug":                      "core",
+					},
+					Source: resource.NewTerraformStateSource("tfplan://test/planned_values/plan.json", "module.teams", "team"),
+				},
+			},
+		},
+		{
+			name:        "read planned values with ignored resource type",
+			dirName:     "planned_values",
+			ignoredType: resourcegithub.GithubTeamResourceType,
+			want: []*resource.Resource{
+				{
+					Id:   "repo",
+					Type: resourcegithub.GithubRepositoryResourceType,
+				},
+			},
+		},
+		{
+			name:    "read a state instead of a plan",
+			dirName: "not_a_plan",
+			wantErr: "tfplan://test/not_a_plan/plan.json: given file is not a JSON terraform plan, it must be generated with terraform show -json",
+		},
+		{
+			name:    "read a missing plan",
+			dirName: "missing",
+			wantErr: "tfplan://test/missing/plan.json: open test/missing/plan.json: no such file or directory",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			progress := &output.MockProgress{}
+			progress.On("Inc").Return().Times(1)
+
+			provider := mocks.NewMockedGoldenTFProvider(tt.dirName, nil, false)
+			library := terraform.NewProviderLibrary()
+			library.AddProvider(terraform.GITHUB, provider)
+
+			repo := testresource.InitFakeSchemaRepository(terraform.GITHUB, "4.4.0")
+			resourcegithub.InitResourcesMetadata(repo)
+			factory := terraform.NewTerraformResourceFactory(repo)
+
+			testFilter := &filter.MockFilter{}
+			testFilter.On("IsTypeIgnored", resource.ResourceType(tt.ignoredType)).Return(true)
+			testFilter.On("IsTypeIgnored", mock.Anything).Return(false)
+
+			r := NewReader(
+				config.SupplierConfig{
+					Key:  TerraformPlanReaderSupplier,
+					Path: path.Join(goldenfile.GoldenFilePath, tt.dirName, "plan.json"),
+				},
+				library,
+				progress,
+				resource.NewDeserializer(factory),
+				testFilter,
+			)
+
+			got, err := r.Resources()
+			progress.AssertExpectations(t)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Len(t, got, len(tt.want))
+			for i, want := range tt.want {
+				assert.Equal(t, want.Id, got[i].Id)
+				assert.Equal(t, want.Type, got[i].Type)
+				if want.Attrs != nil {
+					assert.Equal(t, want.Attrs, got[i].Attrs)
+				}
+				if want.Source != nil {
+					assert.Equal(t, want.Source, got[i].Source)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package backend
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/pkg/errors"
+
+	"github.com/cloudskiff/driftctl/pkg/envproxy"
+)
+
+const azureBlobAPIVersion = "2020-04-08"
+const azureStorageScope = "https://storage.azure.com/.default"
+
+// AzureBlob describes a blob returned when listing a container
+type AzureBlob struct {
+	Name string
+	Size int64
+}
+
+// AzureBlobClient is a read only client of the Azure Blob Storage REST API.
+// It never acquires leases, so states locked by a running terraform can still be read safely.
+type AzureBlobClient struct {
+	endpoint   string
+	account    string
+	key        []byte
+	sasToken   url.Values
+	credential azcore.TokenCredential
+	client     *http.Client
+}
+
+// NewAzureBlobClient creates a client for the given storage account.
+// Credentials are read from the environment, by order of precedence:
+// AZURE_STORAGE_CONNECTION_STRING (also used to target an emulator like Azurite),
+// AZURE_STORAGE_SAS_TOKEN, AZURE_STORAGE_KEY and finally Azure AD credentials.
+// Environment variables prefixed with DCTL_AZ_ override the AZURE_ ones.
+func NewAzureBlobClient(account string) (*AzureBlobClient, error) {
+	envProxy := envproxy.NewEnvProxy("DCTL_AZ_", "AZURE_")
+	envProxy.Apply()
+	defer envProxy.Restore()
+
+	c := &AzureBlobClient{
+		endpoint: fmt.Sprintf("https://%s.blob.core.windows.net", account),
+		account:  account,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}
+
+	if connectionString := os.Getenv("AZURE_STORAGE_CONNECTION_STRING"); connectionString != "" {
+		if err := c.applyConnectionString(connectionString); err != nil {
+			return nil, err
+		}
+	}
+	if sasToken := os.Getenv("AZURE_STORAGE_SAS_TOKEN"); sasToken != "" && c.sasToken == nil {
+		if err := c.setSASToken(sasToken); err != nil {
+			return nil, err
+		}
+	}
+	if key := os.Getenv("AZURE_STORAGE_KEY"); key != "" && c.key == nil {
+		if err := c.setKey(key); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.sasToken == nil && c.key == nil {
+		credential, err := azidentity.NewDefaultAzureCredential(&azidentity.DefaultAzureCredentialOptions{})
+		if err != nil {
+			return nil, errors.Wrap(err, "Unable to find Azure credentials")
+		}
+		c.credential = credential
+	}
+
+	return c, nil
+}
+
+func (c *AzureBlobClient) applyConnectionString(connectionString string) error {
+	settings := make(map[string]string)
+	for _, part := range strings.Split(connectionString, ";") {
+		keyValue := strings.SplitN(part, "=", 2)
+		if len(keyValue) == 2 {
+			settings[keyValue[0]] = keyValue[1]
+		}
+	}
+
+	if name, exist := settings["AccountName"]; exist && name != c.account {
+		return errors.Errorf("Storage account '%s' does not match the account '%s' of the connection string", c.account, name)
+	}
+	if endpoint, exist := settings["BlobEndpoint"]; exist {
+		c.endpoint = strings.TrimSuffix(endpoint, "/")
+	} else if suffix, exist := settings["EndpointSuffix"]; exist {
+		protocol := "https"
+		if p, exist := settings["DefaultEndpointsProtocol"]; exist {
+			protocol = p
+		}
+		c.endpoint = fmt.Sprintf("%s://%s.blob.%s", protocol, c.account, suffix)
+	}
+	if sasToken, exist := settings["SharedAccessSignature"]; exist {
+		if err := c.setSASToken(sasToken); err != nil {
+			return err
+		}
+	}
+	if key, exist := settings["AccountKey"]; exist {
+		if err := c.setKey(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *AzureBlobClient) setSASToken(sasToken string) error {
+	values, err := url.ParseQuery(strings.TrimPrefix(sasToken, "?"))
+	if err != nil {
+		return errors.Wrap(err, "Unable to parse Azure storage SAS token")
+	}
+	c.sasToken = values
+	return nil
+}
+
+func (c *AzureBlobClient) setKey(key string) error {
+	decoded, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return errors.Wrap(err, "Unable to decode Azure storage account key")
+	}
+	c.key = decoded
+	return nil
+}
+
+// GetBlob downloads a blob, the caller must close the returned reader
+func (c *AzureBlobClient) GetBlob(container, name string) (io.ReadCloser, error) {
+	res, err := c.do(fmt.Sprintf("/%s/%s", url.PathEscape(container), escapeBlobName(name)), url.Values{})
+	if err != nil {
+		return nil, err
+	}
+	return res.Body, nil
+}
+
+type azureBlobList struct {
+	Blobs []struct {
+		Name       string `xml:"Name"`
+		Properties struct {
+			ContentLength int64 `xml:"Content-Length"`
+		} `xml:"Properties"`
+	} `xml:"Blobs>Blob"`
+	NextMarker string `xml:"NextMarker"`
+}
+
+// ListBlobs lists the blobs of a container whose name starts with the given prefix
+func (c *AzureBlobClient) ListBlobs(container, prefix string) ([]AzureBlob, error) {
+	var blobs []AzureBlob
+	marker := ""
+	for {
+		query := url.Values{
+			"restype": {"container"},
+			"comp":    {"list"},
+		}
+		if prefix != "" {
+			query.Set("prefix", prefix)
+		}
+		if marker != "" {
+			query.Set("marker", marker)
+		}
+
+		res, err := c.do(fmt.Sprintf("/%s", url.PathEscape(container)), query)
+		if err != nil {
+			return nil, err
+		}
+		list := azureBlobList{}
+		err = xml.NewDecoder(res.Body).Decode(&list)
+		res.Body.Close()
+		if err != nil {
+			return nil, errors.Wrap(err, "Unable to decode blob list")
+		}
+
+		for _, blob := range list.Blobs {
+			blobs = append(blobs, AzureBlob{Name: blob.Name, Size: blob.Properties.ContentLength})
+		}
+		if list.NextMarker == "" {
+			return blobs, nil
+		}
+		marker = list.NextMarker
+	}
+}
+
+type azureStorageError struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+func (c *AzureBlobClient) do(path string, query url.Values) (*http.Response, error) {
+	for key, values := range c.sasToken {
+		query[key] = values
+	}
+	req, err := http.NewRequest(http.MethodGet, c.endpoint+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-ms-version", azureBlobAPIVersion)
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+
+	switch {
+	case c.sasToken != nil:
+		// The SAS token in the query string authorizes the request
+	case c.key != nil:
+		req.Header.Set("Authorization", fmt.Sprintf("SharedKey %s:%s", c.account, c.signature(req)))
+	case c.credential != nil:
+		token, err := c.credential.GetToken(context.Background(), policy.TokenRequestOptions{Scopes: []string{azureStorageScope}})
+		if err != nil {
+			return nil, errors.Wrap(err, "Unable to get Azure storage access token")
+		}
+		req.Header.Set("Authorization", "Bearer "+token.Token)
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		body, _ := ioutil.ReadAll(res.Body)
+		storageErr := azureStorageError{}
+		if err := xml.Unmarshal(body, &storageErr); err != nil || storageErr.Code == "" {
+			return nil, errors.Errorf("%s", res.Status)
+		}
+		return nil, errors.Errorf("%s: %s", storageErr.Code, strings.SplitN(storageErr.Message, "\n", 2)[0])
+	}
+	return res, nil
+}
+
+func (c *AzureBlobClient) signature(req *http.Request) string {
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write([]byte(c.stringToSign(req)))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// stringToSign builds the string signed with the shared key, as documented in
+// https://docs.microsoft.com/en-us/rest/api/storageservices/authorize-with-shared-key
+func (c *AzureBlobClient) stringToSign(req *http.Request) string {
+	var headers []string
+	for name := range req.Header {
+		if strings.HasPrefix(strings.ToLower(name), "x-ms-") {
+			headers = append(headers, strings.ToLower(name))
+		}
+	}
+	sort.Strings(headers)
+	var canonicalHeaders strings.Builder
+	for _, name := range headers {
+		_, _ = fmt.Fprintf(&canonicalHeaders, "%s:%s\n", name, strings.TrimSpace(req.Header.Get(name)))
+	}
+
+	canonicalResource := "/" + c.account + req.URL.EscapedPath()
+	query := req.URL.Query()
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+		canonicalResource += fmt.Sprintf("\n%s:%s", strings.ToLower(key), strings.Join(values, ","))
+	}
+
+	// Standard headers (Content-Encoding, Content-Length, Date, Range, ...) are never set on read requests
+	return req.Method + strings.Repeat("\n", 12) + canonicalHeaders.String() + canonicalResource
+}
+
+func escapeBlobName(name string) string {
+	parts := strings.Split(name, "/")
+	for i, part := range parts {
+		parts[i] = url.PathEscape(part)
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,184 @@
+package backend
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	azuretest "github.com/cloudskiff/driftctl/test/azure"
+)
+
+func TestNewAzureRMReaderInvalid(t *testing.T) {
+	got, err := NewAzureRMReader("account/container")
+	assert.Nil(t, got)
+	assert.EqualError(t, err, "Unable to parse azurerm path: account/container. Must be STORAGE_ACCOUNT/CONTAINER/PATH/TO/BLOB")
+}
+
+func TestNewAzureBlobClient(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		endpoint string
+		key      string
+		sasToken string
+		err      string
+	}{
+		{
+			name: "azurite connection string",
+			env: map[string]string{
+				"DCTL_AZ_STORAGE_CONNECTION_STRING": "DefaultEndpointsProtocol=http;AccountName=devstoreaccount1;AccountKey=a2V5;BlobEndpoint=http://127.0.0.1:10000/devstoreaccount1/;",
+			},
+			endpoint: "http://127.0.0.1:10000/devstoreaccount1",
+			key:      "key",
+		},
+		{
+			name: "connection string with endpoint suffix and sas token",
+			env: map[string]string{
+				"AZURE_STORAGE_CONNECTION_STRING": "DefaultEndpointsProtocol=https;AccountName=devstoreaccount1;EndpointSuffix=core.chinacloudapi.cn;SharedAccessSignature=sv=2020-04-08&sig=abc",
+			},
+			endpoint: "https://devstoreaccount1.blob.core.chinacloudapi.cn",
+			sasToken: "sig=abc&sv=2020-04-08",
+		},
+		{
+			name: "connection string of another account",
+			env: map[string]string{
+				"AZURE_STORAGE_CONNECTION_STRING": "AccountName=otheraccount;AccountKey=a2V5",
+			},
+			err: "Storage account 'devstoreaccount1' does not match the account 'otheraccount' of the connection string",
+		},
+		{
+			name: "sas token",
+			env: map[string]string{
+				"DCTL_AZ_STORAGE_SAS_TOKEN": "?sv=2020-04-08&sig=abc",
+			},
+			endpoint: "https://devstoreaccount1.blob.core.windows.net",
+			sasToken: "sig=abc&sv=2020-04-08",
+		},
+		{
+			name: "shared key",
+			env: map[string]string{
+				"AZURE_STORAGE_KEY": "a2V5",
+			},
+			endpoint: "https://devstoreaccount1.blob.core.windows.net",
+			key:      "key",
+		},
+		{
+			name: "invalid shared key",
+			env: map[string]string{
+				"AZURE_STORAGE_KEY": "not base64",
+			},
+			err: "Unable to decode Azure storage account key: illegal base64 data at input byte 3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				os.Setenv(key, value)
+			}
+			defer func() {
+				for key := range tt.env {
+					os.Unsetenv(key)
+				}
+			}()
+
+			client, err := NewAzureBlobClient("devstoreaccount1")
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.endpoint, client.endpoint)
+			assert.Equal(t, tt.key, string(client.key))
+			assert.Equal(t, tt.sasToken, client.sasToken.Encode())
+			assert.Nil(t, client.credential)
+		})
+	}
+}
+
+func TestAzureBlobClient_signature(t *testing.T) {
+	key, _ := base64.StdEncoding.DecodeString(azuretest.FakeBlobAccountKey)
+	client := &AzureBlobClient{account: "devstoreaccount1", key: key}
+
+	req, _ := http.NewRequest(http.MethodGet, "http://127.0.0.1:10000/devstoreaccount1/states?restype=container&comp=list&prefix=env%2Fprod", nil)
+	req.Header.Set("x-ms-version", "2020-04-08")
+	req.Header.Set("x-ms-date", "Mon, 18 Oct 2021 10:00:00 GMT")
+
+	assert.Equal(t, "GET\n\n\n\n\n\n\n\n\n\n\n\n"+
+		"x-ms-date:Mon, 18 Oct 2021 10:00:00 GMT\n"+
+		"x-ms-version:2020-04-08\n"+
+		"/devstoreaccount1/devstoreaccount1/states\n"+
+		"comp:list\n"+
+		"prefix:env/prod\n"+
+		"restype:container", client.stringToSign(req))
+	assert.Equal(t, "tUBBh9xSZv4LKbbXy6gtnA7B3UAJXrwFP3eh3M/gV/8=", client.signature(req))
+}
+
+func TestAzureRMBackend_Read(t *testing.T) {
+	tests := []struct {
+		name      string
+		container string
+		blob      string
+		key       string
+		want      string
+		wantErr   string
+	}{
+		{
+			name:      "read state",
+			container: "states",
+			blob:      "path/to/state.tfstate",
+			want:      `{"version": 4}`,
+		},
+		{
+			name:      "read state with a name to escape",
+			container: "states",
+			blob:      "path/to/my state+1.tfstate",
+			want:      `{"version": 3}`,
+		},
+		{
+			name:      "wrong shared key",
+			container: "states",
+			blob:      "path/to/state.tfstate",
+			key:       "d3Jvbmc=",
+			wantErr:   "Error reading state 'path/to/state.tfstate' from azurerm container 'states': AuthorizationFailure: This request is not authorized to perform this operation.",
+		},
+		{
+			name:      "missing state",
+			container: "states",
+			blob:      "path/to/missing.tfstate",
+			wantErr:   "Error reading state 'path/to/missing.tfstate' from azurerm container 'states': BlobNotFound: The specified blob does not exist.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, connectionString := azuretest.NewFakeBlobServer(map[string]string{
+				"states/path/to/state.tfstate":      `{"version": 4}`,
+				"states/path/to/my state+1.tfstate": `{"version": 3}`,
+			})
+			defer server.Close()
+			if tt.key != "" {
+				connectionString = strings.Replace(connectionString, azuretest.FakeBlobAccountKey, tt.key, 1)
+			}
+			os.Setenv("AZURE_STORAGE_CONNECTION_STRING", connectionString)
+			defer os.Unsetenv("AZURE_STORAGE_CONNECTION_STRING")
+
+			reader, err := NewAzureRMReader(azuretest.FakeBlobAccount + "/" + tt.container + "/" + tt.blob)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ioutil.ReadAll(reader)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.EqualError(t, reader.Close(), "Unable to close reader as nothing was opened")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+			assert.NoError(t, reader.Close())
+		})
+	}
+}
@@ -12,6 +12,11 @@ import (
 var supportedBackends = []string{
 	BackendKeyFile,
 	BackendKeyS3,
+	BackendKeyGS,
+	BackendKeyAzureRM,
+	BackendKeyConsul,
+	BackendKeyPg,
+	BackendKeyKubernetes,
 	BackendKeyHTTP,
 	BackendKeyHTTPS,
 	BackendKeyTFCloud,
@@ -47,6 +52,16 @@ func GetBackend(config config.SupplierConfig, opts *Options) (Backend, error) {
 		return NewFileReader(config.Path)
 	case BackendKeyS3:
 		return NewS3Reader(config.Path)
+	case BackendKeyGS:
+		return NewGSReader(config.Path)
+	case BackendKeyAzureRM:
+		return NewAzureRMReader(config.Path)
+	case BackendKeyConsul:
+		return NewConsulReader(config.Path)
+	case BackendKeyPg:
+		return NewPgReader(config.Path)
+	case BackendKeyKubernetes:
+		return NewKubernetesReader(config.Path)
 	case BackendKeyHTTP:
 		fallthrough
 	case BackendKeyHTTPS:
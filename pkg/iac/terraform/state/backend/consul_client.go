@@ -0,0 +1,103 @@
+package backend
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/cloudskiff/driftctl/pkg/envproxy"
+)
+
+const consulDefaultAddress = "127.0.0.1:8500"
+
+// ConsulClient is a read only client of the Consul KV HTTP API
+type ConsulClient struct {
+	address string
+	token   string
+	client  *http.Client
+}
+
+// NewConsulClient creates a client configured like the consul CLI, using CONSUL_HTTP_ADDR,
+// CONSUL_HTTP_TOKEN and CONSUL_HTTP_SSL. Environment variables prefixed with DCTL_CONSUL_
+// override the CONSUL_ ones.
+func NewConsulClient() *ConsulClient {
+	envProxy := envproxy.NewEnvProxy("DCTL_CONSUL_", "CONSUL_")
+	envProxy.Apply()
+	defer envProxy.Restore()
+
+	address := consulDefaultAddress
+	if addr := os.Getenv("CONSUL_HTTP_ADDR"); addr != "" {
+		address = addr
+	}
+	if !strings.HasPrefix(address, "http://") && !strings.HasPrefix(address, "https://") {
+		scheme := "http"
+		if ssl := os.Getenv("CONSUL_HTTP_SSL"); ssl == "true" || ssl == "1" {
+			scheme = "https"
+		}
+		address = fmt.Sprintf("%s://%s", scheme, address)
+	}
+
+	return &ConsulClient{
+		address: strings.TrimSuffix(address, "/"),
+		token:   os.Getenv("CONSUL_HTTP_TOKEN"),
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Get returns the raw value of a key, or nil if the key does not exist
+func (c *ConsulClient) Get(key string) ([]byte, error) {
+	res, err := c.do(key, url.Values{"raw": {""}})
+	if err != nil || res == nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	return ioutil.ReadAll(res.Body)
+}
+
+// Keys lists the keys starting with the given prefix, up to the given separator
+func (c *ConsulClient) Keys(prefix, separator string) ([]string, error) {
+	res, err := c.do(prefix, url.Values{"keys": {""}, "separator": {separator}})
+	if err != nil || res == nil {
+		return []string{}, err
+	}
+	defer res.Body.Close()
+
+	var keys []string
+	if err := json.NewDecoder(res.Body).Decode(&keys); err != nil {
+		return nil, errors.Wrap(err, "Unable to decode consul keys")
+	}
+	return keys, nil
+}
+
+func (c *ConsulClient) do(key string, query url.Values) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/v1/kv/%s?%s", c.address, strings.TrimPrefix(key, "/"), query.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.token != "" {
+		req.Header.Set("X-Consul-Token", c.token)
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	switch res.StatusCode {
+	case http.StatusOK:
+		return res, nil
+	case http.StatusNotFound:
+		res.Body.Close()
+		return nil, nil
+	default:
+		defer res.Body.Close()
+		body, _ := ioutil.ReadAll(res.Body)
+		return nil, errors.Errorf("Unexpected response from consul: %s: %s", res.Status, strings.TrimSpace(string(body)))
+	}
+}
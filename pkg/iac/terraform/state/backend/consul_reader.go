@@ -0,0 +1,106 @@
+package backend
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const BackendKeyConsul = "consul"
+
+// ConsulWorkspaceSeparator separates the state path from the workspace name in consul keys
+const ConsulWorkspaceSeparator = "-env:"
+
+const consulDefaultWorkspace = "default"
+
+type ConsulBackend struct {
+	path   string
+	reader io.ReadCloser
+	client *ConsulClient
+}
+
+func NewConsulReader(path string) (*ConsulBackend, error) {
+	if path == "" {
+		return nil, errors.New("Unable to parse consul path: must be PATH/TO/STATE")
+	}
+
+	// Terraform stores the default workspace at PATH, PATH-env:default is accepted to select it explicitly
+	return &ConsulBackend{
+		path:   strings.TrimSuffix(path, ConsulWorkspaceSeparator+consulDefaultWorkspace),
+		client: NewConsulClient(),
+	}, nil
+}
+
+func (c *ConsulBackend) Read(p []byte) (n int, err error) {
+	if c.reader == nil {
+		payload, err := c.readState()
+		if err != nil {
+			return 0, errors.Errorf("Error reading state '%s' from consul: %s", c.path, err)
+		}
+		c.reader = ioutil.NopCloser(bytes.NewReader(payload))
+	}
+	return c.reader.Read(p)
+}
+
+// consulChunks is stored in place of the state when terraform splits large states in several keys
+type consulChunks struct {
+	CurrentHash string   `json:"current-hash"`
+	Chunks      []string `json:"chunks"`
+}
+
+func (c *ConsulBackend) readState() ([]byte, error) {
+	payload, err := c.client.Get(c.path)
+	if err != nil {
+		return nil, err
+	}
+	if payload == nil {
+		return nil, errors.New("key does not exist")
+	}
+
+	chunks := consulChunks{}
+	if err := json.Unmarshal(payload, &chunks); err == nil && chunks.CurrentHash != "" {
+		payload = []byte{}
+		for _, chunk := range chunks.Chunks {
+			value, err := c.client.Get(chunk)
+			if err != nil {
+				return nil, err
+			}
+			if value == nil {
+				return nil, errors.Errorf("chunk '%s' does not exist", chunk)
+			}
+			payload = append(payload, value...)
+		}
+	}
+
+	// Gzipped states start with the gzip magic number instead of a JSON object
+	if len(payload) > 0 && payload[0] == '\x1f' {
+		gz, err := gzip.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		if payload, err = ioutil.ReadAll(gz); err != nil {
+			return nil, err
+		}
+	}
+
+	if chunks.CurrentHash != "" && fmt.Sprintf("%x", md5.Sum(payload)) != chunks.CurrentHash {
+		return nil, errors.New("state does not match the expected hash")
+	}
+
+	return payload, nil
+}
+
+func (c *ConsulBackend) Close() error {
+	if c.reader != nil {
+		return c.reader.Close()
+	}
+	return errors.New("Unable to close reader as nothing was opened")
+}
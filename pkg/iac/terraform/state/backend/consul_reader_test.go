@@ -0,0 +1,165 @@
+package backend
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/md5"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	consultest "github.com/cloudskiff/driftctl/test/consul"
+)
+
+func TestNewConsulReaderInvalid(t *testing.T) {
+	got, err := NewConsulReader("")
+	assert.Nil(t, got)
+	assert.EqualError(t, err, "Unable to parse consul path: must be PATH/TO/STATE")
+}
+
+func TestNewConsulClient(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		address string
+		token   string
+	}{
+		{
+			name:    "default address",
+			address: "http://127.0.0.1:8500",
+		},
+		{
+			name: "address with scheme",
+			env: map[string]string{
+				"CONSUL_HTTP_ADDR":  "https://consul.example.com/",
+				"CONSUL_HTTP_TOKEN": "token",
+			},
+			address: "https://consul.example.com",
+			token:   "token",
+		},
+		{
+			name: "ssl enabled from driftctl environment",
+			env: map[string]string{
+				"CONSUL_HTTP_ADDR":     "127.0.0.1:8500",
+				"DCTL_CONSUL_HTTP_SSL": "true",
+			},
+			address: "https://127.0.0.1:8500",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				os.Setenv(key, value)
+			}
+			defer func() {
+				for key := range tt.env {
+					os.Unsetenv(key)
+				}
+			}()
+
+			client := NewConsulClient()
+			assert.Equal(t, tt.address, client.address)
+			assert.Equal(t, tt.token, client.token)
+		})
+	}
+}
+
+func TestConsulBackend_Read(t *testing.T) {
+	state := `{"version": 4}`
+	var gzipped bytes.Buffer
+	gz := gzip.NewWriter(&gzipped)
+	_, _ = gz.Write([]byte(state))
+	_ = gz.Close()
+	hash := fmt.Sprintf("%x", md5.Sum([]byte(state)))
+
+	keys := map[string]string{
+		"states/plain":                          state,
+		"states/gzip":                           gzipped.String(),
+		"states/chunked":                        fmt.Sprintf(`{"current-hash":"%s","chunks":["states/chunked/tfstate.%s/0","states/chunked/tfstate.%s/1"]}`, hash, hash, hash),
+		"states/chunked/tfstate." + hash + "/0": `{"vers`,
+		"states/chunked/tfstate." + hash + "/1": `ion": 4}`,
+		"states/corrupted":                      fmt.Sprintf(`{"current-hash":"abcdef","chunks":["states/chunked/tfstate.%s/0","states/chunked/tfstate.%s/1"]}`, hash, hash),
+		"states/incomplete":                     `{"current-hash":"abcdef","chunks":["states/incomplete/tfstate.abcdef/0"]}`,
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		token   string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "plain state",
+			path: "states/plain",
+			want: state,
+		},
+		{
+			name: "explicit default workspace",
+			path: "states/plain-env:default",
+			want: state,
+		},
+		{
+			name: "gzipped state",
+			path: "states/gzip",
+			want: state,
+		},
+		{
+			name: "chunked state",
+			path: "states/chunked",
+			want: state,
+		},
+		{
+			name:    "chunked state with invalid hash",
+			path:    "states/corrupted",
+			wantErr: "Error reading state 'states/corrupted' from consul: state does not match the expected hash",
+		},
+		{
+			name:    "chunked state with missing chunk",
+			path:    "states/incomplete",
+			wantErr: "Error reading state 'states/incomplete' from consul: chunk 'states/incomplete/tfstate.abcdef/0' does not exist",
+		},
+		{
+			name:    "missing state",
+			path:    "states/missing",
+			wantErr: "Error reading state 'states/missing' from consul: key does not exist",
+		},
+		{
+			name:    "invalid token",
+			path:    "states/plain",
+			token:   "invalid",
+			wantErr: "Error reading state 'states/plain' from consul: Unexpected response from consul: 403 Forbidden: ACL not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := consultest.NewFakeKVServer(keys, "secret")
+			defer server.Close()
+			token := "secret"
+			if tt.token != "" {
+				token = tt.token
+			}
+			os.Setenv("DCTL_CONSUL_HTTP_ADDR", server.URL)
+			os.Setenv("DCTL_CONSUL_HTTP_TOKEN", token)
+			defer os.Unsetenv("DCTL_CONSUL_HTTP_ADDR")
+			defer os.Unsetenv("DCTL_CONSUL_HTTP_TOKEN")
+
+			reader, err := NewConsulReader(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ioutil.ReadAll(reader)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.EqualError(t, reader.Close(), "Unable to close reader as nothing was opened")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+			assert.NoError(t, reader.Close())
+		})
+	}
+}
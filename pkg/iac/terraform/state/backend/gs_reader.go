@@ -0,0 +1,84 @@
+package backend
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/pkg/errors"
+	"google.golang.org/api/option"
+
+	"github.com/cloudskiff/driftctl/pkg/envproxy"
+)
+
+const BackendKeyGS = "gs"
+
+type GSBackend struct {
+	bucket   string
+	object   string
+	reader   io.ReadCloser
+	GSClient *storage.Client
+}
+
+func NewGSReader(path string) (*GSBackend, error) {
+	bucketPath := strings.Split(path, "/")
+	if len(bucketPath) < 2 {
+		return nil, errors.Errorf("Unable to parse GCS path: %s. Must be BUCKET_NAME/PATH/TO/OBJECT", path)
+	}
+
+	client, err := NewGSClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return &GSBackend{
+		bucket:   bucketPath[0],
+		object:   strings.Join(bucketPath[1:], "/"),
+		GSClient: client,
+	}, nil
+}
+
+// NewGSClient creates a Google Cloud Storage client using application default credentials.
+// Environment variables prefixed with DCTL_GS_ override the GOOGLE_ ones, and STORAGE_EMULATOR_HOST
+// can be used to target a local fake GCS server.
+func NewGSClient() (*storage.Client, error) {
+	envProxy := envproxy.NewEnvProxy("DCTL_GS_", "GOOGLE_")
+	envProxy.Apply()
+	defer envProxy.Restore()
+
+	client, err := storage.NewClient(context.Background(), option.WithScopes(storage.ScopeReadOnly))
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to create GCS client")
+	}
+	return client, nil
+}
+
+func (s *GSBackend) Read(p []byte) (n int, err error) {
+	if s.reader == nil {
+		reader, err := s.GSClient.Bucket(s.bucket).Object(s.object).NewReader(context.Background())
+		if err != nil {
+			return 0, errors.Errorf(
+				"Error reading state '%s' from gs bucket '%s': %s",
+				s.object,
+				s.bucket,
+				err,
+			)
+		}
+		s.reader = reader
+	}
+	return s.reader.Read(p)
+}
+
+func (s *GSBackend) Close() error {
+	err := errors.New("Unable to close reader as nothing was opened")
+	if s.reader != nil {
+		err = s.reader.Close()
+	}
+	if s.GSClient != nil {
+		if clientErr := s.GSClient.Close(); clientErr != nil && err == nil {
+			err = errors.Wrap(clientErr, "Unable to close GCS client")
+		}
+	}
+	return err
+}
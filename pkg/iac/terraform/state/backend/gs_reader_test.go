@@ -0,0 +1,75 @@
+package backend
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	googletest "github.com/cloudskiff/driftctl/test/google"
+)
+
+func TestNewGSReaderInvalid(t *testing.T) {
+	got, err := NewGSReader("foobar")
+	assert.Nil(t, got)
+	assert.EqualError(t, err, "Unable to parse GCS path: foobar. Must be BUCKET_NAME/PATH/TO/OBJECT")
+}
+
+func TestNewGSReader(t *testing.T) {
+	// Target a storage emulator so no credentials are required
+	os.Setenv("STORAGE_EMULATOR_HOST", "localhost:9023")
+	defer os.Unsetenv("STORAGE_EMULATOR_HOST")
+
+	reader, err := NewGSReader("sample_bucket/path/to/state.tfstate")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "sample_bucket", reader.bucket)
+	assert.Equal(t, "path/to/state.tfstate", reader.object)
+}
+
+func TestGSBackend_Read(t *testing.T) {
+	tests := []struct {
+		name    string
+		bucket  string
+		object  string
+		want    string
+		wantErr string
+	}{
+		{
+			name:   "read state",
+			bucket: "sample_bucket",
+			object: "path/to/state.tfstate",
+			want:   `{"version": 4}`,
+		},
+		{
+			name:    "missing state",
+			bucket:  "sample_bucket",
+			object:  "path/to/missing.tfstate",
+			wantErr: "Error reading state 'path/to/missing.tfstate' from gs bucket 'sample_bucket': storage: object doesn't exist",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := googletest.NewFakeStorageServer(map[string]string{
+				"sample_bucket/path/to/state.tfstate": `{"version": 4}`,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			reader := &GSBackend{bucket: tt.bucket, object: tt.object, GSClient: client}
+			got, err := ioutil.ReadAll(reader)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.EqualError(t, reader.Close(), "Unable to close reader as nothing was opened")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+			assert.NoError(t, reader.Close())
+		})
+	}
+}
@@ -0,0 +1,320 @@
+package backend
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+
+	"github.com/cloudskiff/driftctl/pkg/envproxy"
+)
+
+const kubernetesServiceAccountPath = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// KubernetesSecret is the subset of a kubernetes secret used to read states
+type KubernetesSecret struct {
+	Metadata struct {
+		Name   string            `json:"name"`
+		Labels map[string]string `json:"labels"`
+	} `json:"metadata"`
+	Data map[string][]byte `json:"data"`
+}
+
+type kubernetesSecretList struct {
+	Items    []KubernetesSecret `json:"items"`
+	Metadata struct {
+		Continue string `json:"continue"`
+	} `json:"metadata"`
+}
+
+// KubernetesClient is a read only client of the kubernetes secrets API
+type KubernetesClient struct {
+	host   string
+	token  string
+	client *http.Client
+}
+
+// kubeConfig is the subset of a kubeconfig file supported by driftctl
+type kubeConfig struct {
+	CurrentContext string `json:"current-context"`
+	Contexts       []struct {
+		Name    string `json:"name"`
+		Context struct {
+			Cluster string `json:"cluster"`
+			User    string `json:"user"`
+		} `json:"context"`
+	} `json:"contexts"`
+	Clusters []struct {
+		Name    string `json:"name"`
+		Cluster struct {
+			Server                   string `json:"server"`
+			CertificateAuthority     string `json:"certificate-authority"`
+			CertificateAuthorityData []byte `json:"certificate-authority-data"`
+			InsecureSkipTLSVerify    bool   `json:"insecure-skip-tls-verify"`
+		} `json:"cluster"`
+	} `json:"clusters"`
+	Users []struct {
+		Name string `json:"name"`
+		User struct {
+			Token                 string `json:"token"`
+			TokenFile             string `json:"tokenFile"`
+			ClientCertificate     string `json:"client-certificate"`
+			ClientCertificateData []byte `json:"client-certificate-data"`
+			ClientKey             string `json:"client-key"`
+			ClientKeyData         []byte `json:"client-key-data"`
+			// Credential plugins are not supported, they are only decoded to be reported
+			Exec         *json.RawMessage `json:"exec"`
+			AuthProvider *json.RawMessage `json:"auth-provider"`
+		} `json:"user"`
+	} `json:"users"`
+}
+
+// kubernetesConfig holds what is needed to reach and authenticate to a cluster
+type kubernetesConfig struct {
+	host       string
+	token      string
+	caData     []byte
+	certData   []byte
+	keyData    []byte
+	insecure   bool
+	configPath string
+}
+
+// NewKubernetesClient creates a client configured like the terraform kubernetes backend:
+// KUBE_HOST and its KUBE_TOKEN, KUBE_CLUSTER_CA_CERT_DATA, KUBE_CLIENT_CERT_DATA, KUBE_CLIENT_KEY_DATA
+// and KUBE_INSECURE companions take precedence, then KUBE_IN_CLUSTER_CONFIG enables the pod service account,
+// and finally the KUBE_CTX context (or the current one) of the KUBE_CONFIG_PATH, KUBECONFIG or ~/.kube/config file is used.
+// Environment variables prefixed with DCTL_KUBE_ override the KUBE_ ones.
+func NewKubernetesClient() (*KubernetesClient, error) {
+	envProxy := envproxy.NewEnvProxy("DCTL_KUBE_", "KUBE_")
+	envProxy.Apply()
+	defer envProxy.Restore()
+
+	config, err := loadKubernetesConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: config.insecure}
+	if len(config.caData) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(config.caData) {
+			return nil, errors.New("Unable to load kubernetes cluster CA certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if len(config.certData) > 0 {
+		cert, err := tls.X509KeyPair(config.certData, config.keyData)
+		if err != nil {
+			return nil, errors.Wrap(err, "Unable to load kubernetes client certificate")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return &KubernetesClient{
+		host:  strings.TrimSuffix(config.host, "/"),
+		token: config.token,
+		client: &http.Client{
+			Timeout:   30 * time.Second,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig, Proxy: http.ProxyFromEnvironment},
+		},
+	}, nil
+}
+
+func loadKubernetesConfig() (*kubernetesConfig, error) {
+	if host := os.Getenv("KUBE_HOST"); host != "" {
+		return &kubernetesConfig{
+			host:     host,
+			token:    os.Getenv("KUBE_TOKEN"),
+			caData:   []byte(os.Getenv("KUBE_CLUSTER_CA_CERT_DATA")),
+			certData: []byte(os.Getenv("KUBE_CLIENT_CERT_DATA")),
+			keyData:  []byte(os.Getenv("KUBE_CLIENT_KEY_DATA")),
+			insecure: os.Getenv("KUBE_INSECURE") == "true",
+		}, nil
+	}
+
+	if os.Getenv("KUBE_IN_CLUSTER_CONFIG") == "true" {
+		token, err := ioutil.ReadFile(filepath.Join(kubernetesServiceAccountPath, "token"))
+		if err != nil {
+			return nil, errors.Wrap(err, "Unable to read kubernetes service account token")
+		}
+		caData, err := ioutil.ReadFile(filepath.Join(kubernetesServiceAccountPath, "ca.crt"))
+		if err != nil {
+			return nil, errors.Wrap(err, "Unable to read kubernetes service account CA certificate")
+		}
+		return &kubernetesConfig{
+			host:   fmt.Sprintf("https://%s:%s", os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")),
+			token:  strings.TrimSpace(string(token)),
+			caData: caData,
+		}, nil
+	}
+
+	configPath := os.Getenv("KUBE_CONFIG_PATH")
+	if configPath == "" {
+		configPath = strings.Split(os.Getenv("KUBECONFIG"), string(os.PathListSeparator))[0]
+	}
+	if configPath == "" {
+		configPath = "~/.kube/config"
+	}
+	return loadKubeConfigFile(configPath, os.Getenv("KUBE_CTX"))
+}
+
+func loadKubeConfigFile(path, context string) (*kubernetesConfig, error) {
+	path, err := homedir.Expand(path)
+	if err != nil {
+		return nil, err
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to read kubeconfig")
+	}
+	kubeconfig := kubeConfig{}
+	if err := yaml.Unmarshal(content, &kubeconfig); err != nil {
+		return nil, errors.Wrapf(err, "Unable to parse kubeconfig '%s'", path)
+	}
+
+	if context == "" {
+		context = kubeconfig.CurrentContext
+	}
+	config := &kubernetesConfig{configPath: path}
+	var clusterName, userName string
+	found := false
+	for _, c := range kubeconfig.Contexts {
+		if c.Name == context {
+			clusterName, userName, found = c.Context.Cluster, c.Context.User, true
+		}
+	}
+	if !found {
+		return nil, errors.Errorf("Context '%s' not found in kubeconfig '%s'", context, path)
+	}
+
+	for _, c := range kubeconfig.Clusters {
+		if c.Name == clusterName {
+			config.host = c.Cluster.Server
+			config.insecure = c.Cluster.InsecureSkipTLSVerify
+			config.caData = c.Cluster.CertificateAuthorityData
+			if c.Cluster.CertificateAuthority != "" {
+				if config.caData, err = config.readFile(c.Cluster.CertificateAuthority); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	if config.host == "" {
+		return nil, errors.Errorf("Cluster '%s' not found in kubeconfig '%s'", clusterName, path)
+	}
+
+	for _, u := range kubeconfig.Users {
+		if u.Name == userName {
+			if u.User.Exec != nil || u.User.AuthProvider != nil {
+				return nil, errors.Errorf(
+					"Unsupported kubeconfig auth for user '%s' in kubeconfig '%s': exec and auth-provider plugins are not supported, use a token or a client certificate",
+					userName,
+					path,
+				)
+			}
+			config.token = u.User.Token
+			config.certData = u.User.ClientCertificateData
+			config.keyData = u.User.ClientKeyData
+			if u.User.TokenFile != "" {
+				token, err := config.readFile(u.User.TokenFile)
+				if err != nil {
+					return nil, err
+				}
+				config.token = strings.TrimSpace(string(token))
+			}
+			if u.User.ClientCertificate != "" {
+				if config.certData, err = config.readFile(u.User.ClientCertificate); err != nil {
+					return nil, err
+				}
+			}
+			if u.User.ClientKey != "" {
+				if config.keyData, err = config.readFile(u.User.ClientKey); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return config, nil
+}
+
+// readFile reads a file referenced in the kubeconfig, relative paths are resolved from the kubeconfig directory
+func (c *kubernetesConfig) readFile(path string) ([]byte, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(c.configPath), path)
+	}
+	return ioutil.ReadFile(path)
+}
+
+// GetSecret returns a secret, or nil if it does not exist
+func (c *KubernetesClient) GetSecret(namespace, name string) (*KubernetesSecret, error) {
+	secret := &KubernetesSecret{}
+	found, err := c.get(fmt.Sprintf("/api/v1/namespaces/%s/secrets/%s", url.PathEscape(namespace), url.PathEscape(name)), url.Values{}, secret)
+	if err != nil || !found {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// ListSecrets lists the secrets of a namespace matching the given label selector
+func (c *KubernetesClient) ListSecrets(namespace, labelSelector string) ([]KubernetesSecret, error) {
+	var secrets []KubernetesSecret
+	query := url.Values{"labelSelector": {labelSelector}}
+	for {
+		list := kubernetesSecretList{}
+		if _, err := c.get(fmt.Sprintf("/api/v1/namespaces/%s/secrets", url.PathEscape(namespace)), query, &list); err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, list.Items...)
+		if list.Metadata.Continue == "" {
+			return secrets, nil
+		}
+		query.Set("continue", list.Metadata.Continue)
+	}
+}
+
+type kubernetesStatus struct {
+	Message string `json:"message"`
+}
+
+func (c *KubernetesClient) get(path string, query url.Values, out interface{}) (bool, error) {
+	req, err := http.NewRequest(http.MethodGet, c.host+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return true, json.NewDecoder(res.Body).Decode(out)
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		status := kubernetesStatus{}
+		if err := json.NewDecoder(res.Body).Decode(&status); err != nil || status.Message == "" {
+			return false, errors.Errorf("%s", res.Status)
+		}
+		return false, errors.Errorf("%s: %s", res.Status, status.Message)
+	}
+}
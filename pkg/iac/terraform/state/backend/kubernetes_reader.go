@@ -0,0 +1,99 @@
+package backend
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const BackendKeyKubernetes = "kubernetes"
+
+const kubernetesDefaultWorkspace = "default"
+
+// Labels set by terraform on the secrets storing states
+const KubernetesLabelState = "tfstate"
+const KubernetesLabelSecretSuffix = "tfstateSecretSuffix"
+const KubernetesLabelWorkspace = "tfstateWorkspace"
+
+// KubernetesBackend reads the state terraform stores in the secret tfstate-WORKSPACE-SECRET_SUFFIX.
+// Its path is NAMESPACE/SECRET_SUFFIX, optionally followed by /WORKSPACE.
+type KubernetesBackend struct {
+	namespace string
+	suffix    string
+	workspace string
+	reader    io.ReadCloser
+	client    *KubernetesClient
+}
+
+func NewKubernetesReader(path string) (*KubernetesBackend, error) {
+	secretPath := strings.Split(path, "/")
+	if len(secretPath) < 2 || len(secretPath) > 3 {
+		return nil, errors.Errorf("Unable to parse kubernetes path: %s. Must be NAMESPACE/SECRET_SUFFIX or NAMESPACE/SECRET_SUFFIX/WORKSPACE", path)
+	}
+	workspace := kubernetesDefaultWorkspace
+	if len(secretPath) == 3 {
+		workspace = secretPath[2]
+	}
+
+	client, err := NewKubernetesClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return &KubernetesBackend{
+		namespace: secretPath[0],
+		suffix:    secretPath[1],
+		workspace: workspace,
+		client:    client,
+	}, nil
+}
+
+func (k *KubernetesBackend) Read(p []byte) (n int, err error) {
+	if k.reader == nil {
+		name := strings.Join([]string{KubernetesLabelState, k.workspace, k.suffix}, "-")
+		payload, err := k.readState(name)
+		if err != nil {
+			return 0, errors.Errorf(
+				"Error reading state '%s' from kubernetes namespace '%s': %s",
+				name,
+				k.namespace,
+				err,
+			)
+		}
+		k.reader = ioutil.NopCloser(bytes.NewReader(payload))
+	}
+	return k.reader.Read(p)
+}
+
+func (k *KubernetesBackend) readState(name string) ([]byte, error) {
+	secret, err := k.client.GetSecret(k.namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if secret == nil {
+		return nil, errors.New("secret does not exist")
+	}
+	data, exist := secret.Data[KubernetesLabelState]
+	if !exist {
+		return nil, errors.Errorf("secret has no '%s' key", KubernetesLabelState)
+	}
+
+	// Terraform always gzips states stored in secrets
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	return ioutil.ReadAll(gz)
+}
+
+func (k *KubernetesBackend) Close() error {
+	if k.reader != nil {
+		return k.reader.Close()
+	}
+	return errors.New("Unable to close reader as nothing was opened")
+}
@@ -0,0 +1,137 @@
+package backend
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	kubernetestest "github.com/cloudskiff/driftctl/test/kubernetes"
+)
+
+func TestNewKubernetesReaderInvalid(t *testing.T) {
+	got, err := NewKubernetesReader("default")
+	assert.Nil(t, got)
+	assert.EqualError(t, err, "Unable to parse kubernetes path: default. Must be NAMESPACE/SECRET_SUFFIX or NAMESPACE/SECRET_SUFFIX/WORKSPACE")
+}
+
+func TestLoadKubeConfigFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		context string
+		want    *kubernetesConfig
+		err     string
+	}{
+		{
+			name: "current context",
+			want: &kubernetesConfig{
+				host:       "https://dev.example.com:6443",
+				token:      "dev-token",
+				insecure:   true,
+				configPath: "testdata/kubeconfig.yaml",
+			},
+		},
+		{
+			name:    "context with files relative to the kubeconfig",
+			context: "prod",
+			want: &kubernetesConfig{
+				host:       "https://prod.example.com:6443",
+				token:      "prod-token",
+				caData:     []byte("certificate"),
+				configPath: "testdata/kubeconfig.yaml",
+			},
+		},
+		{
+			name:    "missing context",
+			context: "staging",
+			err:     "Context 'staging' not found in kubeconfig 'testdata/kubeconfig.yaml'",
+		},
+		{
+			name:    "missing cluster",
+			context: "broken",
+			err:     "Cluster 'missing-cluster' not found in kubeconfig 'testdata/kubeconfig.yaml'",
+		},
+		{
+			name:    "exec user",
+			context: "eks",
+			err:     "Unsupported kubeconfig auth for user 'eks-user' in kubeconfig 'testdata/kubeconfig.yaml': exec and auth-provider plugins are not supported, use a token or a client certificate",
+		},
+		{
+			name:    "auth provider user",
+			context: "gke",
+			err:     "Unsupported kubeconfig auth for user 'gke-user' in kubeconfig 'testdata/kubeconfig.yaml': exec and auth-provider plugins are not supported, use a token or a client certificate",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := loadKubeConfigFile("testdata/kubeconfig.yaml", tt.context)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestKubernetesBackend_Read(t *testing.T) {
+	secrets := []kubernetestest.FakeSecret{
+		kubernetestest.StateSecret("terraform", "default", "app", `{"version": 4}`),
+		kubernetestest.StateSecret("terraform", "prod", "app", `{"version": 4, "serial": 2}`),
+		{Namespace: "terraform", Name: "tfstate-default-empty"},
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "default workspace",
+			path: "terraform/app",
+			want: `{"version": 4}`,
+		},
+		{
+			name: "workspace",
+			path: "terraform/app/prod",
+			want: `{"version": 4, "serial": 2}`,
+		},
+		{
+			name:    "missing workspace",
+			path:    "terraform/app/dev",
+			wantErr: "Error reading state 'tfstate-dev-app' from kubernetes namespace 'terraform': secret does not exist",
+		},
+		{
+			name:    "secret without state",
+			path:    "terraform/empty",
+			wantErr: "Error reading state 'tfstate-default-empty' from kubernetes namespace 'terraform': secret has no 'tfstate' key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := kubernetestest.NewFakeSecretServer(secrets, "token")
+			defer server.Close()
+			os.Setenv("DCTL_KUBE_HOST", server.URL)
+			os.Setenv("DCTL_KUBE_TOKEN", "token")
+			defer os.Unsetenv("DCTL_KUBE_HOST")
+			defer os.Unsetenv("DCTL_KUBE_TOKEN")
+
+			reader, err := NewKubernetesReader(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ioutil.ReadAll(reader)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.EqualError(t, reader.Close(), "Unable to close reader as nothing was opened")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+			assert.NoError(t, reader.Close())
+		})
+	}
+}
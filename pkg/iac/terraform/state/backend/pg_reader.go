@@ -0,0 +1,128 @@
+package backend
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+const BackendKeyPg = "pg"
+
+const pgDefaultSchemaName = "terraform_remote_state"
+const pgDefaultWorkspace = "default"
+
+// Query parameters of the path read by driftctl, all the other ones are given to the postgres driver
+const PgParamSchemaName = "schema_name"
+const PgParamWorkspace = "workspace"
+
+// PgPath is a parsed pg backend path: a postgres connection URL without its scheme,
+// optionally selecting the schema and the workspace to read, i.e.
+// USER:PASSWORD@HOST:PORT/DATABASE?sslmode=disable&schema_name=SCHEMA&workspace=WORKSPACE
+// Credentials may also be given using the standard PGUSER and PGPASSWORD environment variables,
+// they are never written to scan results, see config.SupplierConfig.RedactedPath.
+type PgPath struct {
+	ConnStr    string
+	SchemaName string
+	Workspace  string
+}
+
+func ParsePgPath(path string) (*PgPath, error) {
+	u, err := url.Parse("postgres://" + path)
+	if err != nil || u.Host == "" {
+		return nil, errors.Errorf("Unable to parse pg path: %s. Must be HOST:PORT/DATABASE", path)
+	}
+
+	query := u.Query()
+	pgPath := &PgPath{
+		SchemaName: pgDefaultSchemaName,
+		Workspace:  query.Get(PgParamWorkspace),
+	}
+	if schema := query.Get(PgParamSchemaName); schema != "" {
+		pgPath.SchemaName = schema
+	}
+	query.Del(PgParamSchemaName)
+	query.Del(PgParamWorkspace)
+	u.RawQuery = query.Encode()
+	pgPath.ConnStr = u.String()
+
+	return pgPath, nil
+}
+
+// PgWorkspacePath returns the given path targeting a single workspace
+func PgWorkspacePath(path, workspace string) string {
+	u, err := url.Parse("postgres://" + path)
+	if err != nil {
+		return path
+	}
+	query := u.Query()
+	query.Set(PgParamWorkspace, workspace)
+	u.RawQuery = query.Encode()
+	return strings.TrimPrefix(u.String(), "postgres://")
+}
+
+// PgStatesTable returns the quoted name of the table where terraform stores states
+func PgStatesTable(schemaName string) string {
+	return fmt.Sprintf("%s.states", pq.QuoteIdentifier(schemaName))
+}
+
+type PgBackend struct {
+	path   *PgPath
+	reader io.ReadCloser
+	db     *sql.DB
+}
+
+func NewPgReader(path string) (*PgBackend, error) {
+	pgPath, err := ParsePgPath(path)
+	if err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("postgres", pgPath.ConnStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PgBackend{
+		path: pgPath,
+		db:   db,
+	}, nil
+}
+
+func (p *PgBackend) Read(b []byte) (n int, err error) {
+	if p.reader == nil {
+		workspace := p.path.Workspace
+		if workspace == "" {
+			workspace = pgDefaultWorkspace
+		}
+
+		var data string
+		query := fmt.Sprintf("SELECT data FROM %s WHERE name = $1", PgStatesTable(p.path.SchemaName))
+		if err := p.db.QueryRow(query, workspace).Scan(&data); err != nil {
+			if err == sql.ErrNoRows {
+				err = errors.New("workspace does not exist")
+			}
+			return 0, errors.Errorf(
+				"Error reading state of workspace '%s' from pg schema '%s': %s",
+				workspace,
+				p.path.SchemaName,
+				err,
+			)
+		}
+		p.reader = ioutil.NopCloser(bytes.NewReader([]byte(data)))
+	}
+	return p.reader.Read(b)
+}
+
+func (p *PgBackend) Close() error {
+	defer p.db.Close()
+	if p.reader != nil {
+		return p.reader.Close()
+	}
+	return errors.New("Unable to close reader as nothing was opened")
+}
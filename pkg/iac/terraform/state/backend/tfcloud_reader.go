@@ -44,27 +44,32 @@ func NewTFCloudReader(client pkghttp.HTTPClient, workspaceId string, opts *Optio
 }
 
 func (t *TFCloudBackend) authorize() error {
-	token := t.opts.TFCloudToken
-	if token == "" {
-		tfConfigFile, err := getTerraformConfigFile()
-		if err != nil {
-			return err
-		}
-		file, err := os.Open(tfConfigFile)
-		if err != nil {
-			return err
-		}
-		defer file.Close()
-		reader := NewTFCloudConfigReader(file)
-		token, err = reader.GetToken(t.request.URL.Host)
-		if err != nil {
-			return err
-		}
+	token, err := GetTFCloudToken(t.opts, t.request.URL.Host)
+	if err != nil {
+		return err
 	}
 	t.request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
 	return nil
 }
 
+// GetTFCloudToken returns the API token from the options, or from the terraform credentials file for the given host
+func GetTFCloudToken(opts *Options, host string) (string, error) {
+	if opts.TFCloudToken != "" {
+		return opts.TFCloudToken, nil
+	}
+	tfConfigFile, err := getTerraformConfigFile()
+	if err != nil {
+		return "", err
+	}
+	file, err := os.Open(tfConfigFile)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	reader := NewTFCloudConfigReader(file)
+	return reader.GetToken(host)
+}
+
 func (t *TFCloudBackend) Read(p []byte) (n int, err error) {
 	if t.reader == nil {
 		if err := t.authorize(); err != nil {
@@ -0,0 +1,70 @@
+package enumerator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/pkg/errors"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+)
+
+type AzureRMEnumerator struct {
+	config config.SupplierConfig
+	client *backend.AzureBlobClient
+}
+
+func NewAzureRMEnumerator(config config.SupplierConfig) *AzureRMEnumerator {
+	return &AzureRMEnumerator{
+		config: config,
+	}
+}
+
+func (s *AzureRMEnumerator) Origin() string {
+	return s.config.String()
+}
+
+func (s *AzureRMEnumerator) Enumerate() ([]string, error) {
+	blobPath := strings.Split(s.config.Path, "/")
+	if len(blobPath) < 3 {
+		return nil, errors.Errorf("Unable to parse azurerm path: %s. Must be STORAGE_ACCOUNT/CONTAINER/PREFIX", s.config.Path)
+	}
+
+	account, container := blobPath[0], blobPath[1]
+	// The client is created lazily as creating it requires valid credentials
+	if s.client == nil {
+		client, err := backend.NewAzureBlobClient(account)
+		if err != nil {
+			return nil, err
+		}
+		s.client = client
+	}
+
+	// Blobs are listed using the part of the path without glob pattern as prefix
+	prefix, pattern := GlobS3(strings.Join(blobPath[2:], "/"))
+
+	fullPattern := strings.Join([]string{prefix, pattern}, "/")
+	fullPattern = strings.Trim(fullPattern, "/")
+
+	blobs, err := s.client.ListBlobs(container, prefix)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to list blobs of azurerm container '%s'", container)
+	}
+
+	files := make([]string, 0)
+	for _, blob := range blobs {
+		if blob.Size > 0 {
+			if match, _ := doublestar.Match(fullPattern, blob.Name); match {
+				files = append(files, strings.Join([]string{account, container, blob.Name}, "/"))
+			}
+		}
+	}
+
+	if len(files) == 0 {
+		return files, fmt.Errorf("no Terraform state was found in %s, exiting", s.config.Path)
+	}
+
+	return files, nil
+}
@@ -0,0 +1,98 @@
+package enumerator
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	azuretest "github.com/cloudskiff/driftctl/test/azure"
+)
+
+func TestAzureRMEnumerator_Enumerate(t *testing.T) {
+	blobs := map[string]string{
+		"states/a/nested/prefix/state1":                     "state",
+		"states/a/nested/prefix/state2":                     "state",
+		"states/a/nested/prefix/empty":                      "",
+		"states/a/nested/prefix/folder1/state3.tfstate":     "state",
+		"states/a/nested/prefix/folder2/sub/state4.tfstate": "state",
+		"states/b/state5.tfstate":                           "state",
+		"other-container/a/nested/prefix/state6":            "state",
+	}
+
+	tests := []struct {
+		name   string
+		config config.SupplierConfig
+		want   []string
+		err    string
+	}{
+		{
+			name: "invalid path",
+			config: config.SupplierConfig{
+				Path: "devstoreaccount1/states",
+			},
+			err: "Unable to parse azurerm path: devstoreaccount1/states. Must be STORAGE_ACCOUNT/CONTAINER/PREFIX",
+		},
+		{
+			name: "missing container",
+			config: config.SupplierConfig{
+				Path: "devstoreaccount1/missing/*",
+			},
+			err: "Unable to list blobs of azurerm container 'missing': ContainerNotFound: The specified container does not exist.",
+		},
+		{
+			name: "no state found under a prefix without glob",
+			config: config.SupplierConfig{
+				Path: "devstoreaccount1/states/a/nested/prefix",
+			},
+			want: []string{},
+			err:  "no Terraform state was found in devstoreaccount1/states/a/nested/prefix, exiting",
+		},
+		{
+			name: "single state",
+			config: config.SupplierConfig{
+				Path: "devstoreaccount1/states/a/nested/prefix/state2",
+			},
+			want: []string{"devstoreaccount1/states/a/nested/prefix/state2"},
+		},
+		{
+			name: "simple glob ignoring empty blobs",
+			config: config.SupplierConfig{
+				Path: "devstoreaccount1/states/a/nested/prefix/*",
+			},
+			want: []string{
+				"devstoreaccount1/states/a/nested/prefix/state1",
+				"devstoreaccount1/states/a/nested/prefix/state2",
+			},
+		},
+		{
+			name: "doublestar glob",
+			config: config.SupplierConfig{
+				Path: "devstoreaccount1/states/**/*.tfstate",
+			},
+			want: []string{
+				"devstoreaccount1/states/a/nested/prefix/folder1/state3.tfstate",
+				"devstoreaccount1/states/a/nested/prefix/folder2/sub/state4.tfstate",
+				"devstoreaccount1/states/b/state5.tfstate",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, connectionString := azuretest.NewFakeBlobServer(blobs)
+			defer server.Close()
+			os.Setenv("DCTL_AZ_STORAGE_CONNECTION_STRING", connectionString)
+			defer os.Unsetenv("DCTL_AZ_STORAGE_CONNECTION_STRING")
+
+			s := NewAzureRMEnumerator(tt.config)
+			got, err := s.Enumerate()
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
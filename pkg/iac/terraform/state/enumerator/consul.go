@@ -0,0 +1,59 @@
+package enumerator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+)
+
+// ConsulEnumerator lists the states of every workspace stored under a consul path.
+// Terraform stores the default workspace at PATH and the other ones at PATH-env:WORKSPACE.
+type ConsulEnumerator struct {
+	config config.SupplierConfig
+	client *backend.ConsulClient
+}
+
+func NewConsulEnumerator(config config.SupplierConfig) *ConsulEnumerator {
+	return &ConsulEnumerator{
+		config: config,
+		client: backend.NewConsulClient(),
+	}
+}
+
+func (s *ConsulEnumerator) Origin() string {
+	return s.config.String()
+}
+
+func (s *ConsulEnumerator) Enumerate() ([]string, error) {
+	path := s.config.Path
+	// A single workspace was requested
+	if strings.Contains(path, backend.ConsulWorkspaceSeparator) {
+		return []string{path}, nil
+	}
+
+	// Keys nested under the path are chunks or locks, the separator filters them out
+	keys, err := s.client.Keys(path, "/")
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to list consul keys of '%s'", path)
+	}
+
+	files := make([]string, 0)
+	for _, key := range keys {
+		workspace := strings.TrimPrefix(key, path+backend.ConsulWorkspaceSeparator)
+		if key == path || (workspace != key && !strings.Contains(workspace, "/")) {
+			files = append(files, key)
+		}
+	}
+	sort.Strings(files)
+
+	if len(files) == 0 {
+		return files, fmt.Errorf("no Terraform state was found in %s, exiting", path)
+	}
+
+	return files, nil
+}
@@ -0,0 +1,86 @@
+package enumerator
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	consultest "github.com/cloudskiff/driftctl/test/consul"
+)
+
+func TestConsulEnumerator_Enumerate(t *testing.T) {
+	keys := map[string]string{
+		"states/app":                           "state",
+		"states/app/.lock":                     "lock",
+		"states/app-env:prod":                  "state",
+		"states/app-env:prod/tfstate.abcd/0":   "chunk",
+		"states/app-env:staging":               "state",
+		"states/other":                         "state",
+		"states/workspaces-only-env:dev":       "state",
+		"states/workspaces-only-env:dev/.lock": "lock",
+	}
+
+	tests := []struct {
+		name   string
+		config config.SupplierConfig
+		want   []string
+		err    string
+	}{
+		{
+			name: "all workspaces",
+			config: config.SupplierConfig{
+				Path: "states/app",
+			},
+			want: []string{
+				"states/app",
+				"states/app-env:prod",
+				"states/app-env:staging",
+			},
+		},
+		{
+			name: "without default workspace",
+			config: config.SupplierConfig{
+				Path: "states/workspaces-only",
+			},
+			want: []string{
+				"states/workspaces-only-env:dev",
+			},
+		},
+		{
+			name: "single workspace",
+			config: config.SupplierConfig{
+				Path: "states/app-env:prod",
+			},
+			want: []string{
+				"states/app-env:prod",
+			},
+		},
+		{
+			name: "no state found",
+			config: config.SupplierConfig{
+				Path: "states/missing",
+			},
+			want: []string{},
+			err:  "no Terraform state was found in states/missing, exiting",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := consultest.NewFakeKVServer(keys, "")
+			defer server.Close()
+			os.Setenv("DCTL_CONSUL_HTTP_ADDR", server.URL)
+			defer os.Unsetenv("DCTL_CONSUL_HTTP_ADDR")
+
+			s := NewConsulEnumerator(tt.config)
+			got, err := s.Enumerate()
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package enumerator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/pkg/errors"
+	"google.golang.org/api/iterator"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+)
+
+type GSEnumerator struct {
+	config config.SupplierConfig
+	client *storage.Client
+}
+
+func NewGSEnumerator(config config.SupplierConfig) *GSEnumerator {
+	return &GSEnumerator{
+		config: config,
+	}
+}
+
+func (s *GSEnumerator) Origin() string {
+	return s.config.String()
+}
+
+func (s *GSEnumerator) Enumerate() ([]string, error) {
+	bucketPath := strings.Split(s.config.Path, "/")
+	if len(bucketPath) < 2 {
+		return nil, errors.Errorf("Unable to parse GCS path: %s. Must be BUCKET_NAME/PREFIX", s.config.Path)
+	}
+
+	// The client is created lazily as creating it requires valid credentials
+	client := s.client
+	if client == nil {
+		c, err := backend.NewGSClient()
+		if err != nil {
+			return nil, err
+		}
+		defer c.Close()
+		client = c
+	}
+
+	bucket := bucketPath[0]
+	// Objects are listed using the part of the path without glob pattern as prefix
+	prefix, pattern := GlobS3(strings.Join(bucketPath[1:], "/"))
+
+	fullPattern := strings.Join([]string{prefix, pattern}, "/")
+	fullPattern = strings.Trim(fullPattern, "/")
+
+	files := make([]string, 0)
+	it := client.Bucket(bucket).Objects(context.Background(), &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "Unable to list objects of gs bucket '%s'", bucket)
+		}
+		if attrs.Size > 0 {
+			if match, _ := doublestar.Match(fullPattern, attrs.Name); match {
+				files = append(files, strings.Join([]string{bucket, attrs.Name}, "/"))
+			}
+		}
+	}
+
+	if len(files) == 0 {
+		return files, fmt.Errorf("no Terraform state was found in %s, exiting", s.config.Path)
+	}
+
+	return files, nil
+}
@@ -0,0 +1,92 @@
+package enumerator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	googletest "github.com/cloudskiff/driftctl/test/google"
+)
+
+func TestGSEnumerator_Enumerate(t *testing.T) {
+	objects := map[string]string{
+		"bucket-name/a/nested/prefix/state1":                     "state",
+		"bucket-name/a/nested/prefix/state2":                     "state",
+		"bucket-name/a/nested/prefix/empty":                      "",
+		"bucket-name/a/nested/prefix/folder1/state3.tfstate":     "state",
+		"bucket-name/a/nested/prefix/folder2/sub/state4.tfstate": "state",
+		"bucket-name/b/state5.tfstate":                           "state",
+		"other-bucket/a/nested/prefix/state6":                    "state",
+	}
+
+	tests := []struct {
+		name   string
+		config config.SupplierConfig
+		want   []string
+		err    string
+	}{
+		{
+			name: "invalid path",
+			config: config.SupplierConfig{
+				Path: "bucket-name",
+			},
+			err: "Unable to parse GCS path: bucket-name. Must be BUCKET_NAME/PREFIX",
+		},
+		{
+			name: "no state found under a prefix without glob",
+			config: config.SupplierConfig{
+				Path: "bucket-name/a/nested/prefix",
+			},
+			want: []string{},
+			err:  "no Terraform state was found in bucket-name/a/nested/prefix, exiting",
+		},
+		{
+			name: "single state",
+			config: config.SupplierConfig{
+				Path: "bucket-name/a/nested/prefix/state2",
+			},
+			want: []string{"bucket-name/a/nested/prefix/state2"},
+		},
+		{
+			name: "simple glob ignoring empty objects",
+			config: config.SupplierConfig{
+				Path: "bucket-name/a/nested/prefix/*",
+			},
+			want: []string{
+				"bucket-name/a/nested/prefix/state1",
+				"bucket-name/a/nested/prefix/state2",
+			},
+		},
+		{
+			name: "doublestar glob",
+			config: config.SupplierConfig{
+				Path: "bucket-name/**/*.tfstate",
+			},
+			want: []string{
+				"bucket-name/a/nested/prefix/folder1/state3.tfstate",
+				"bucket-name/a/nested/prefix/folder2/sub/state4.tfstate",
+				"bucket-name/b/state5.tfstate",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := googletest.NewFakeStorageServer(objects)
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := &GSEnumerator{
+				config: tt.config,
+				client: client,
+			}
+			got, err := s.Enumerate()
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
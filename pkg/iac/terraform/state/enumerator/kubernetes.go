@@ -0,0 +1,69 @@
+package enumerator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+)
+
+// KubernetesEnumerator lists the states of every workspace sharing a secret suffix,
+// unless the path selects a single workspace
+type KubernetesEnumerator struct {
+	config config.SupplierConfig
+	client *backend.KubernetesClient
+}
+
+func NewKubernetesEnumerator(config config.SupplierConfig) *KubernetesEnumerator {
+	return &KubernetesEnumerator{
+		config: config,
+	}
+}
+
+func (s *KubernetesEnumerator) Origin() string {
+	return s.config.String()
+}
+
+func (s *KubernetesEnumerator) Enumerate() ([]string, error) {
+	secretPath := strings.Split(s.config.Path, "/")
+	if len(secretPath) == 3 {
+		return []string{s.config.Path}, nil
+	}
+	if len(secretPath) != 2 {
+		return nil, errors.Errorf("Unable to parse kubernetes path: %s. Must be NAMESPACE/SECRET_SUFFIX", s.config.Path)
+	}
+
+	// The client is created lazily as creating it requires a valid configuration
+	if s.client == nil {
+		client, err := backend.NewKubernetesClient()
+		if err != nil {
+			return nil, err
+		}
+		s.client = client
+	}
+
+	namespace, suffix := secretPath[0], secretPath[1]
+	selector := fmt.Sprintf("%s=true,%s=%s", backend.KubernetesLabelState, backend.KubernetesLabelSecretSuffix, suffix)
+	secrets, err := s.client.ListSecrets(namespace, selector)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to list secrets of kubernetes namespace '%s'", namespace)
+	}
+
+	files := make([]string, 0)
+	for _, secret := range secrets {
+		if workspace, exist := secret.Metadata.Labels[backend.KubernetesLabelWorkspace]; exist {
+			files = append(files, strings.Join([]string{namespace, suffix, workspace}, "/"))
+		}
+	}
+	sort.Strings(files)
+
+	if len(files) == 0 {
+		return files, fmt.Errorf("no Terraform state was found in %s, exiting", s.config.Path)
+	}
+
+	return files, nil
+}
@@ -0,0 +1,82 @@
+package enumerator
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	kubernetestest "github.com/cloudskiff/driftctl/test/kubernetes"
+)
+
+func TestKubernetesEnumerator_Enumerate(t *testing.T) {
+	secrets := []kubernetestest.FakeSecret{
+		kubernetestest.StateSecret("terraform", "default", "app", "state"),
+		kubernetestest.StateSecret("terraform", "prod", "app", "state"),
+		kubernetestest.StateSecret("terraform", "staging", "app", "state"),
+		kubernetestest.StateSecret("terraform", "default", "other", "state"),
+		kubernetestest.StateSecret("default", "default", "app", "state"),
+		{Namespace: "terraform", Name: "unrelated"},
+	}
+
+	tests := []struct {
+		name   string
+		config config.SupplierConfig
+		want   []string
+		err    string
+	}{
+		{
+			name: "invalid path",
+			config: config.SupplierConfig{
+				Path: "terraform",
+			},
+			err: "Unable to parse kubernetes path: terraform. Must be NAMESPACE/SECRET_SUFFIX",
+		},
+		{
+			name: "all workspaces",
+			config: config.SupplierConfig{
+				Path: "terraform/app",
+			},
+			want: []string{
+				"terraform/app/default",
+				"terraform/app/prod",
+				"terraform/app/staging",
+			},
+		},
+		{
+			name: "single workspace",
+			config: config.SupplierConfig{
+				Path: "terraform/app/prod",
+			},
+			want: []string{"terraform/app/prod"},
+		},
+		{
+			name: "no state found",
+			config: config.SupplierConfig{
+				Path: "terraform/missing",
+			},
+			want: []string{},
+			err:  "no Terraform state was found in terraform/missing, exiting",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := kubernetestest.NewFakeSecretServer(secrets, "token")
+			defer server.Close()
+			os.Setenv("DCTL_KUBE_HOST", server.URL)
+			os.Setenv("DCTL_KUBE_TOKEN", "token")
+			defer os.Unsetenv("DCTL_KUBE_HOST")
+			defer os.Unsetenv("DCTL_KUBE_TOKEN")
+
+			s := NewKubernetesEnumerator(tt.config)
+			got, err := s.Enumerate()
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package enumerator
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+)
+
+// PgEnumerator lists the states of every workspace stored in a pg backend,
+// unless the path selects a single workspace
+type PgEnumerator struct {
+	config config.SupplierConfig
+	db     *sql.DB
+}
+
+func NewPgEnumerator(config config.SupplierConfig) *PgEnumerator {
+	return &PgEnumerator{
+		config: config,
+	}
+}
+
+func (s *PgEnumerator) Origin() string {
+	return s.config.String()
+}
+
+func (s *PgEnumerator) Enumerate() ([]string, error) {
+	pgPath, err := backend.ParsePgPath(s.config.Path)
+	if err != nil {
+		return nil, err
+	}
+	if pgPath.Workspace != "" {
+		return []string{s.config.Path}, nil
+	}
+
+	if s.db == nil {
+		db, err := sql.Open("postgres", pgPath.ConnStr)
+		if err != nil {
+			return nil, err
+		}
+		s.db = db
+	}
+	defer s.db.Close()
+
+	rows, err := s.db.Query(fmt.Sprintf("SELECT name FROM %s ORDER BY name", backend.PgStatesTable(pgPath.SchemaName)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to list workspaces of pg schema '%s'", pgPath.SchemaName)
+	}
+	defer rows.Close()
+
+	files := make([]string, 0)
+	for rows.Next() {
+		var workspace string
+		if err := rows.Scan(&workspace); err != nil {
+			return nil, err
+		}
+		files = append(files, backend.PgWorkspacePath(s.config.Path, workspace))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(files) == 0 {
+		return files, fmt.Errorf("no Terraform state was found in %s, exiting", s.config.RedactedPath())
+	}
+
+	return files, nil
+}
@@ -0,0 +1,86 @@
+package enumerator
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+)
+
+func TestPgEnumerator_Enumerate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config config.SupplierConfig
+		mock   func(mock sqlmock.Sqlmock)
+		want   []string
+		err    string
+	}{
+		{
+			name: "invalid path",
+			config: config.SupplierConfig{
+				Path: "/terraform",
+			},
+			err: "Unable to parse pg path: /terraform. Must be HOST:PORT/DATABASE",
+		},
+		{
+			name: "single workspace",
+			config: config.SupplierConfig{
+				Path: "localhost/terraform?workspace=prod",
+			},
+			want: []string{"localhost/terraform?workspace=prod"},
+		},
+		{
+			name: "all workspaces",
+			config: config.SupplierConfig{
+				Path: "localhost/terraform?schema_name=states&sslmode=disable",
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT name FROM "states".states ORDER BY name`).
+					WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("default").AddRow("prod"))
+			},
+			want: []string{
+				"localhost/terraform?schema_name=states&sslmode=disable&workspace=default",
+				"localhost/terraform?schema_name=states&sslmode=disable&workspace=prod",
+			},
+		},
+		{
+			name: "no state found",
+			config: config.SupplierConfig{
+				Path: "localhost/terraform",
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT name FROM "terraform_remote_state".states ORDER BY name`).
+					WillReturnRows(sqlmock.NewRows([]string{"name"}))
+			},
+			want: []string{},
+			err:  "no Terraform state was found in localhost/terraform, exiting",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.mock != nil {
+				tt.mock(mock)
+				mock.ExpectClose()
+			}
+
+			s := &PgEnumerator{
+				config: tt.config,
+				db:     db,
+			}
+			got, err := s.Enumerate()
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
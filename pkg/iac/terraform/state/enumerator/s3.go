@@ -2,6 +2,7 @@ package enumerator
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -15,6 +16,26 @@ import (
 	"github.com/cloudskiff/driftctl/pkg/iac/config"
 )
 
+const (
+	// S3OptionWorkspaces enumerates the states of the terraform workspaces matching the given globs,
+	// e.g. BUCKET/KEY?workspaces=prod-*,staging, the default workspace being named default
+	S3OptionWorkspaces = "workspaces"
+	// S3OptionExcludeWorkspaces skips the states of the terraform workspaces matching the given globs
+	S3OptionExcludeWorkspaces = "exclude_workspaces"
+	// S3OptionWorkspaceKeyPrefix is the prefix of workspaces states, like the workspace_key_prefix of the s3 backend
+	S3OptionWorkspaceKeyPrefix = "workspace_key_prefix"
+)
+
+const (
+	s3DefaultWorkspace          = "default"
+	s3DefaultWorkspaceKeyPrefix = "env:"
+)
+
+var s3SupportedOptions = []string{S3OptionExcludeWorkspaces, S3OptionWorkspaceKeyPrefix, S3OptionWorkspaces}
+
+// S3Enumerator lists the states of a bucket matching a key glob.
+// Terraform workspaces are stored as BUCKET/WORKSPACE_KEY_PREFIX/WORKSPACE/KEY, the workspace of each state
+// is resolved from its key and can be used to select states.
 type S3Enumerator struct {
 	config config.SupplierConfig
 	client s3iface.S3API
@@ -38,15 +59,72 @@ func (s *S3Enumerator) Origin() string {
 }
 
 func (s *S3Enumerator) Enumerate() ([]string, error) {
-	bucketPath := strings.Split(s.config.Path, "/")
+	path, options, err := s.options()
+	if err != nil {
+		return nil, err
+	}
+
+	bucketPath := strings.Split(path, "/")
 	if len(bucketPath) < 2 {
 		return nil, errors.Errorf("Unable to parse S3 path: %s. Must be BUCKET_NAME/PREFIX", s.config.Path)
 	}
 
 	bucket := bucketPath[0]
+	key := strings.Join(bucketPath[1:], "/")
+	included := splitList(options.Get(S3OptionWorkspaces))
+	excluded := splitList(options.Get(S3OptionExcludeWorkspaces))
+
+	keys := []string{key}
+	if len(included) > 0 {
+		// Workspaces other than the default one are stored under the workspace key prefix
+		keys = append(keys, strings.Join([]string{s.workspaceKeyPrefix(options), "*", key}, "/"))
+	}
+
+	files := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, k := range keys {
+		matches, err := s.list(bucket, k)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range matches {
+			// A glob of the default workspace key can match workspaces states too
+			if seen[file] {
+				continue
+			}
+			seen[file] = true
+			workspace := s.workspace(file, options)
+			if len(included) > 0 && !matchAny(included, workspace) {
+				continue
+			}
+			if matchAny(excluded, workspace) {
+				continue
+			}
+			files = append(files, file)
+		}
+	}
+
+	if len(files) == 0 {
+		return files, fmt.Errorf("no Terraform state was found in %s, exiting", s.config.Path)
+	}
+
+	return files, nil
+}
+
+// Workspace returns the terraform workspace of an enumerated state
+func (s *S3Enumerator) Workspace(file string) string {
+	_, options, err := s.options()
+	if err != nil {
+		return s3DefaultWorkspace
+	}
+	return s.workspace(file, options)
+}
+
+// list returns the files of the bucket matching the key glob
+func (s *S3Enumerator) list(bucket, key string) ([]string, error) {
 	// prefix should contains everything that does not have a glob pattern
 	// Pattern should be the glob matcher string
-	prefix, pattern := GlobS3(strings.Join(bucketPath[1:], "/"))
+	prefix, pattern := GlobS3(key)
 
 	fullPattern := strings.Join([]string{prefix, pattern}, "/")
 	fullPattern = strings.Trim(fullPattern, "/")
@@ -70,10 +148,65 @@ func (s *S3Enumerator) Enumerate() ([]string, error) {
 	if err != nil {
 		return nil, err
 	}
+	return files, nil
+}
 
-	if len(files) == 0 {
-		return files, fmt.Errorf("no Terraform state was found in %s, exiting", s.config.Path)
+// options splits the path from its options. A question mark is only a glob character when not followed by options.
+func (s *S3Enumerator) options() (string, url.Values, error) {
+	path := s.config.Path
+	idx := strings.LastIndex(path, "?")
+	if idx < 0 || !strings.Contains(path[idx+1:], "=") {
+		return path, url.Values{}, nil
 	}
 
-	return files, nil
+	options, err := url.ParseQuery(path[idx+1:])
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "Unable to parse options of '%s'", path)
+	}
+	for key := range options {
+		if key != S3OptionWorkspaces && key != S3OptionExcludeWorkspaces && key != S3OptionWorkspaceKeyPrefix {
+			return "", nil, errors.Errorf("unsupported option '%s', supported options are: %s", key, strings.Join(s3SupportedOptions, ","))
+		}
+	}
+	return path[:idx], options, nil
+}
+
+func (s *S3Enumerator) workspaceKeyPrefix(options url.Values) string {
+	if prefix := strings.Trim(options.Get(S3OptionWorkspaceKeyPrefix), "/"); prefix != "" {
+		return prefix
+	}
+	return s3DefaultWorkspaceKeyPrefix
+}
+
+// workspace resolves the workspace of a file from its key, states outside the workspace key prefix belong to the default workspace
+func (s *S3Enumerator) workspace(file string, options url.Values) string {
+	bucketKey := strings.SplitN(file, "/", 2)
+	if len(bucketKey) < 2 {
+		return s3DefaultWorkspace
+	}
+	prefix := s.workspaceKeyPrefix(options) + "/"
+	if !strings.HasPrefix(bucketKey[1], prefix) {
+		return s3DefaultWorkspace
+	}
+	workspaceKey := strings.SplitN(strings.TrimPrefix(bucketKey[1], prefix), "/", 2)
+	if len(workspaceKey) < 2 {
+		return s3DefaultWorkspace
+	}
+	return workspaceKey[0]
+}
+
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+func matchAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if match, _ := doublestar.Match(pattern, value); match {
+			return true
+		}
+	}
+	return false
 }
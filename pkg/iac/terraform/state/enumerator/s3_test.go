@@ -530,6 +530,95 @@ func TestS3Enumerator_Enumerate(t *testing.T) {
 			},
 			want: []string{"bucket-name/a/nested/prefix/terraform.tfstate/terraform.tfstate"},
 		},
+		{
+			name: "test question mark is a glob when not followed by options",
+			config: config.SupplierConfig{
+				Path: "bucket-name/state?.tfstate",
+			},
+			mocks: func(client *awstest.MockFakeS3) {
+				mockS3Listing(client, "", "state1.tfstate", "state12.tfstate")
+			},
+			want: []string{"bucket-name/state1.tfstate"},
+		},
+		{
+			name: "test all workspaces",
+			config: config.SupplierConfig{
+				Path: "bucket-name/project/terraform.tfstate?workspaces=*",
+			},
+			mocks: func(client *awstest.MockFakeS3) {
+				mockS3Listing(client, "project/terraform.tfstate", "project/terraform.tfstate")
+				mockS3Listing(client, "env:",
+					"env:/prod/project/terraform.tfstate",
+					"env:/prod/other/terraform.tfstate",
+					"env:/staging/project/terraform.tfstate",
+				)
+			},
+			want: []string{
+				"bucket-name/project/terraform.tfstate",
+				"bucket-name/env:/prod/project/terraform.tfstate",
+				"bucket-name/env:/staging/project/terraform.tfstate",
+			},
+		},
+		{
+			name: "test included and excluded workspaces with custom prefix",
+			config: config.SupplierConfig{
+				Path: "bucket-name/project/terraform.tfstate?workspaces=prod-*,default&exclude_workspaces=prod-legacy&workspace_key_prefix=workspaces",
+			},
+			mocks: func(client *awstest.MockFakeS3) {
+				mockS3Listing(client, "project/terraform.tfstate", "project/terraform.tfstate")
+				mockS3Listing(client, "workspaces",
+					"workspaces/prod-eu/project/terraform.tfstate",
+					"workspaces/prod-legacy/project/terraform.tfstate",
+					"workspaces/staging/project/terraform.tfstate",
+				)
+			},
+			want: []string{
+				"bucket-name/project/terraform.tfstate",
+				"bucket-name/workspaces/prod-eu/project/terraform.tfstate",
+			},
+		},
+		{
+			name: "test glob matching workspaces is not enumerated twice",
+			config: config.SupplierConfig{
+				Path: "bucket-name/**/terraform.tfstate?workspaces=staging",
+			},
+			mocks: func(client *awstest.MockFakeS3) {
+				mockS3Listing(client, "",
+					"project/terraform.tfstate",
+					"env:/staging/project/terraform.tfstate",
+				)
+				mockS3Listing(client, "env:",
+					"env:/staging/project/terraform.tfstate",
+				)
+			},
+			want: []string{"bucket-name/env:/staging/project/terraform.tfstate"},
+		},
+		{
+			name: "test excluded workspaces",
+			config: config.SupplierConfig{
+				Path: "bucket-name/**/*.tfstate?exclude_workspaces=dev-*",
+			},
+			mocks: func(client *awstest.MockFakeS3) {
+				mockS3Listing(client, "",
+					"project/terraform.tfstate",
+					"env:/dev-john/project/terraform.tfstate",
+					"env:/prod/project/terraform.tfstate",
+				)
+			},
+			want: []string{
+				"bucket-name/project/terraform.tfstate",
+				"bucket-name/env:/prod/project/terraform.tfstate",
+			},
+		},
+		{
+			name: "test unsupported option",
+			config: config.SupplierConfig{
+				Path: "bucket-name/project/terraform.tfstate?workspace=prod",
+			},
+			mocks: func(client *awstest.MockFakeS3) {},
+			want:  nil,
+			err:   "unsupported option 'workspace', supported options are: exclude_workspaces,workspace_key_prefix,workspaces",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -554,3 +643,63 @@ func TestS3Enumerator_Enumerate(t *testing.T) {
 		})
 	}
 }
+
+func TestS3Enumerator_Workspace(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		key  string
+		want string
+	}{
+		{
+			name: "default workspace",
+			path: "bucket-name/project/terraform.tfstate?workspaces=*",
+			key:  "bucket-name/project/terraform.tfstate",
+			want: "default",
+		},
+		{
+			name: "workspace",
+			path: "bucket-name/project/terraform.tfstate?workspaces=*",
+			key:  "bucket-name/env:/prod/project/terraform.tfstate",
+			want: "prod",
+		},
+		{
+			name: "workspace with custom prefix",
+			path: "bucket-name/project/terraform.tfstate?workspaces=*&workspace_key_prefix=workspaces/",
+			key:  "bucket-name/workspaces/prod/project/terraform.tfstate",
+			want: "prod",
+		},
+		{
+			name: "workspace without options",
+			path: "bucket-name/**/*.tfstate",
+			key:  "bucket-name/env:/staging/project/terraform.tfstate",
+			want: "staging",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &S3Enumerator{config: config.SupplierConfig{Path: tt.path}}
+			if got := s.Workspace(tt.key); got != tt.want {
+				t.Errorf("Workspace() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func mockS3Listing(client *awstest.MockFakeS3, prefix string, keys ...string) {
+	contents := make([]*s3.Object, 0, len(keys))
+	for _, key := range keys {
+		contents = append(contents, &s3.Object{Key: awssdk.String(key), Size: awssdk.Int64(5)})
+	}
+	client.On(
+		"ListObjectsV2Pages",
+		&s3.ListObjectsV2Input{
+			Bucket: awssdk.String("bucket-name"),
+			Prefix: awssdk.String(prefix),
+		},
+		mock.MatchedBy(func(callback func(res *s3.ListObjectsV2Output, lastPage bool) bool) bool {
+			callback(&s3.ListObjectsV2Output{Contents: contents}, true)
+			return true
+		}),
+	).Return(nil)
+}
@@ -11,13 +11,30 @@ type StateEnumerator interface {
 	Enumerate() ([]string, error)
 }
 
-func GetEnumerator(config config.SupplierConfig) StateEnumerator {
+// WorkspaceEnumerator is implemented by enumerators knowing the terraform workspace of the states they enumerate
+type WorkspaceEnumerator interface {
+	Workspace(key string) string
+}
+
+func GetEnumerator(config config.SupplierConfig, opts *backend.Options) StateEnumerator {
 
 	switch config.Backend {
 	case backend.BackendKeyFile:
 		return NewFileEnumerator(config)
 	case backend.BackendKeyS3:
 		return NewS3Enumerator(config)
+	case backend.BackendKeyGS:
+		return NewGSEnumerator(config)
+	case backend.BackendKeyAzureRM:
+		return NewAzureRMEnumerator(config)
+	case backend.BackendKeyConsul:
+		return NewConsulEnumerator(config)
+	case backend.BackendKeyPg:
+		return NewPgEnumerator(config)
+	case backend.BackendKeyKubernetes:
+		return NewKubernetesEnumerator(config)
+	case backend.BackendKeyTFCloud:
+		return NewTFCloudEnumerator(config, opts)
 	}
 
 	logrus.WithFields(logrus.Fields{
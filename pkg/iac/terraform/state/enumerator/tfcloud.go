@@ -0,0 +1,184 @@
+package enumerator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	pkghttp "github.com/cloudskiff/driftctl/pkg/http"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+)
+
+// TFCloudOptionTags filters enumerated workspaces by tags, e.g. ORGANIZATION/prod-*?tags=aws,network
+const TFCloudOptionTags = "tags"
+
+const tfCloudPageSize = 100
+
+type tfCloudWorkspaces struct {
+	Data []struct {
+		ID         string `json:"id"`
+		Attributes struct {
+			Name     string   `json:"name"`
+			TagNames []string `json:"tag-names"`
+		} `json:"attributes"`
+	} `json:"data"`
+	Meta struct {
+		Pagination struct {
+			NextPage *int `json:"next-page"`
+		} `json:"pagination"`
+	} `json:"meta"`
+}
+
+// TFCloudEnumerator lists the workspaces of a Terraform Cloud organization matching a name glob and tags.
+// Paths without organization are workspace IDs and are returned as is.
+type TFCloudEnumerator struct {
+	config config.SupplierConfig
+	opts   *backend.Options
+	client pkghttp.HTTPClient
+}
+
+func NewTFCloudEnumerator(config config.SupplierConfig, opts *backend.Options) *TFCloudEnumerator {
+	return &TFCloudEnumerator{
+		config: config,
+		opts:   opts,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (s *TFCloudEnumerator) Origin() string {
+	return s.config.String()
+}
+
+func (s *TFCloudEnumerator) Enumerate() ([]string, error) {
+	path := s.config.Path
+	if !strings.Contains(path, "/") {
+		return []string{path}, nil
+	}
+
+	query := url.Values{}
+	if idx := strings.Index(path, "?"); idx >= 0 {
+		var err error
+		if query, err = url.ParseQuery(path[idx+1:]); err != nil {
+			return nil, errors.Wrapf(err, "Unable to parse options of '%s'", path)
+		}
+		path = path[:idx]
+	}
+	for key := range query {
+		if key != TFCloudOptionTags {
+			return nil, errors.Errorf("unsupported option '%s', supported options are: %s", key, TFCloudOptionTags)
+		}
+	}
+
+	orgWorkspace := strings.SplitN(path, "/", 2)
+	organization, pattern := orgWorkspace[0], orgWorkspace[1]
+	if organization == "" || pattern == "" {
+		return nil, errors.Errorf("Unable to parse tfcloud path: %s. Must be WORKSPACE_ID or ORGANIZATION/WORKSPACE_NAME_GLOB", s.config.Path)
+	}
+	var tags []string
+	if query.Get(TFCloudOptionTags) != "" {
+		tags = strings.Split(query.Get(TFCloudOptionTags), ",")
+	}
+
+	workspaces, err := s.listWorkspaces(organization, pattern, tags)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to list workspaces of terraform cloud organization '%s'", organization)
+	}
+
+	if len(workspaces) == 0 {
+		return workspaces, fmt.Errorf("no Terraform state was found in %s, exiting", s.config.Path)
+	}
+
+	return workspaces, nil
+}
+
+func (s *TFCloudEnumerator) listWorkspaces(organization, pattern string, tags []string) ([]string, error) {
+	endpoint, err := url.Parse(s.opts.TFCloudEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	token, err := backend.GetTFCloudToken(s.opts, endpoint.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{"page[size]": {strconv.Itoa(tfCloudPageSize)}}
+	if len(tags) > 0 {
+		query.Set("search[tags]", strings.Join(tags, ","))
+	}
+	// The API searches names by substring, the part of the pattern before any glob narrows the results
+	prefix := pattern
+	if idx := strings.IndexAny(pattern, "*[]"); idx >= 0 {
+		prefix = pattern[:idx]
+	}
+	if prefix != "" {
+		query.Set("search[name]", prefix)
+	}
+
+	workspaces := make([]string, 0)
+	page := 1
+	for {
+		query.Set("page[number]", strconv.Itoa(page))
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/organizations/%s/workspaces?%s", s.opts.TFCloudEndpoint, url.PathEscape(organization), query.Encode()), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Add("Content-Type", "application/vnd.api+json")
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
+
+		res, err := s.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if res.StatusCode < 200 || res.StatusCode >= 300 {
+			res.Body.Close()
+			return nil, errors.Errorf("error requesting terraform cloud workspaces: status code: %d", res.StatusCode)
+		}
+		body := tfCloudWorkspaces{}
+		err = json.NewDecoder(res.Body).Decode(&body)
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, workspace := range body.Data {
+			if match, _ := doublestar.Match(pattern, workspace.Attributes.Name); !match || !hasTags(workspace.Attributes.TagNames, tags) {
+				continue
+			}
+			logrus.WithFields(logrus.Fields{
+				"id":   workspace.ID,
+				"name": workspace.Attributes.Name,
+			}).Debug("Found terraform cloud workspace")
+			workspaces = append(workspaces, workspace.ID)
+		}
+
+		if body.Meta.Pagination.NextPage == nil {
+			return workspaces, nil
+		}
+		page = *body.Meta.Pagination.NextPage
+	}
+}
+
+func hasTags(tagNames, tags []string) bool {
+	for _, tag := range tags {
+		found := false
+		for _, name := range tagNames {
+			if name == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,162 @@
+package enumerator
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+)
+
+func TestTFCloudEnumerator_Enumerate(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	page1 := `{
+		"data": [
+			{"id": "ws-1", "attributes": {"name": "prod-network", "tag-names": ["aws", "network"]}},
+			{"id": "ws-2", "attributes": {"name": "prod-app", "tag-names": ["aws"]}}
+		],
+		"meta": {"pagination": {"current-page": 1, "next-page": 2}}
+	}`
+	page2 := `{
+		"data": [
+			{"id": "ws-3", "attributes": {"name": "staging-app", "tag-names": ["gcp"]}}
+		],
+		"meta": {"pagination": {"current-page": 2, "next-page": null}}
+	}`
+
+	tests := []struct {
+		name   string
+		config config.SupplierConfig
+		mock   func()
+		want   []string
+		err    string
+	}{
+		{
+			name: "single workspace",
+			config: config.SupplierConfig{
+				Path: "ws-1",
+			},
+			want: []string{"ws-1"},
+		},
+		{
+			name: "invalid path",
+			config: config.SupplierConfig{
+				Path: "/prod-*",
+			},
+			err: "Unable to parse tfcloud path: /prod-*. Must be WORKSPACE_ID or ORGANIZATION/WORKSPACE_NAME_GLOB",
+		},
+		{
+			name: "unsupported option",
+			config: config.SupplierConfig{
+				Path: "company/*?foo=bar",
+			},
+			err: "unsupported option 'foo', supported options are: tags",
+		},
+		{
+			name: "every workspace of an organization",
+			config: config.SupplierConfig{
+				Path: "company/*",
+			},
+			mock: func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"https://app.terraform.io/api/v2/organizations/company/workspaces?page%5Bnumber%5D=1&page%5Bsize%5D=100",
+					httpmock.NewStringResponder(http.StatusOK, page1),
+				)
+				httpmock.RegisterResponder(
+					"GET",
+					"https://app.terraform.io/api/v2/organizations/company/workspaces?page%5Bnumber%5D=2&page%5Bsize%5D=100",
+					httpmock.NewStringResponder(http.StatusOK, page2),
+				)
+			},
+			want: []string{"ws-1", "ws-2", "ws-3"},
+		},
+		{
+			name: "workspaces matching a name glob and tags",
+			config: config.SupplierConfig{
+				Path: "company/prod-*?tags=aws,network",
+			},
+			mock: func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"https://app.terraform.io/api/v2/organizations/company/workspaces?page%5Bnumber%5D=1&page%5Bsize%5D=100&search%5Bname%5D=prod-&search%5Btags%5D=aws%2Cnetwork",
+					httpmock.NewStringResponder(http.StatusOK, page1),
+				)
+				httpmock.RegisterResponder(
+					"GET",
+					"https://app.terraform.io/api/v2/organizations/company/workspaces?page%5Bnumber%5D=2&page%5Bsize%5D=100&search%5Bname%5D=prod-&search%5Btags%5D=aws%2Cnetwork",
+					httpmock.NewStringResponder(http.StatusOK, page2),
+				)
+			},
+			want: []string{"ws-1"},
+		},
+		{
+			name: "no matching workspace",
+			config: config.SupplierConfig{
+				Path: "company/dev-*",
+			},
+			mock: func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"https://app.terraform.io/api/v2/organizations/company/workspaces?page%5Bnumber%5D=1&page%5Bsize%5D=100&search%5Bname%5D=dev-",
+					httpmock.NewStringResponder(http.StatusOK, `{"data": [], "meta": {"pagination": {"next-page": null}}}`),
+				)
+			},
+			want: []string{},
+			err:  "no Terraform state was found in company/dev-*, exiting",
+		},
+		{
+			name: "unauthorized",
+			config: config.SupplierConfig{
+				Path: "company/*",
+			},
+			mock: func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"https://app.terraform.io/api/v2/organizations/company/workspaces?page%5Bnumber%5D=1&page%5Bsize%5D=100",
+					httpmock.NewStringResponder(http.StatusUnauthorized, ""),
+				)
+			},
+			err: "Unable to list workspaces of terraform cloud organization 'company': error requesting terraform cloud workspaces: status code: 401",
+		},
+		{
+			name: "not modified",
+			config: config.SupplierConfig{
+				Path: "company/*",
+			},
+			mock: func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"https://app.terraform.io/api/v2/organizations/company/workspaces?page%5Bnumber%5D=1&page%5Bsize%5D=100",
+					httpmock.NewStringResponder(http.StatusNotModified, ""),
+				)
+			},
+			err: "Unable to list workspaces of terraform cloud organization 'company': error requesting terraform cloud workspaces: status code: 304",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
+			if tt.mock != nil {
+				tt.mock()
+			}
+
+			s := NewTFCloudEnumerator(tt.config, &backend.Options{
+				TFCloudToken:    "TOKEN",
+				TFCloudEndpoint: "https://app.terraform.io/api/v2",
+			})
+			got, err := s.Enumerate()
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
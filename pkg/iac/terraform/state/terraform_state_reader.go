@@ -36,6 +36,7 @@ type TerraformStateReader struct {
 	config         config.SupplierConfig
 	backend        backend.Backend
 	enumerator     enumerator.StateEnumerator
+	workspace      string
 	deserializer   *resource.Deserializer
 	backendOptions *backend.Options
 	progress       output.Progress
@@ -44,7 +45,7 @@ type TerraformStateReader struct {
 }
 
 func (r *TerraformStateReader) initReader() error {
-	r.enumerator = enumerator.GetEnumerator(r.config)
+	r.enumerator = enumerator.GetEnumerator(r.config, r.backendOptions)
 	return nil
 }
 
@@ -140,8 +141,10 @@ func (r *TerraformStateReader) retrieve() (map[string][]decodedRes, error) {
 					}
 				}
 				_, exists := resMap[stateRes.Addr.Resource.Type]
+				source := resource.NewTerraformStateSource(r.config.String(), moduleName, resName)
+				source.Workspace = r.workspace
 				val := decodedRes{
-					source: resource.NewTerraformStateSource(r.config.String(), moduleName, resName),
+					source: source,
 					val:    decodedVal.Value,
 				}
 				if !exists {
@@ -244,7 +247,11 @@ func (r *TerraformStateReader) retrieveMultiplesStates() ([]*resource.Resource,
 	isSuccess := false
 	readingError := iac.NewStateReadingError()
 
+	workspaceEnumerator, hasWorkspaces := r.enumerator.(enumerator.WorkspaceEnumerator)
 	for _, key := range keys {
+		if hasWorkspaces {
+			r.workspace = workspaceEnumerator.Workspace(key)
+		}
 		resources, err := r.retrieveForState(key)
 		if err != nil {
 			readingError.Add(err)
@@ -0,0 +1,153 @@
+package terragrunt
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/pkg/errors"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+)
+
+// newEvalContext creates the context evaluating a configuration of the module located in terragruntDir,
+// includeDir being the directory of the configuration it includes, if any.
+// Locals that cannot be evaluated, e.g. because they depend on other modules outputs, are left undefined.
+func newEvalContext(body *hclsyntax.Body, terragruntDir, includeDir string) *hcl.EvalContext {
+	ctx := &hcl.EvalContext{
+		Functions: functions(terragruntDir, includeDir),
+		Variables: map[string]cty.Value{},
+	}
+
+	pending := make(map[string]hcl.Expression)
+	for _, block := range body.Blocks {
+		if block.Type != "locals" {
+			continue
+		}
+		for name, attr := range block.Body.Attributes {
+			pending[name] = attr.Expr
+		}
+	}
+
+	// Locals can reference each other, they are evaluated until no more local can be resolved
+	locals := make(map[string]cty.Value)
+	for resolved := true; resolved && len(pending) > 0; {
+		resolved = false
+		ctx.Variables["local"] = cty.ObjectVal(locals)
+		for name, expr := range pending {
+			value, diags := expr.Value(ctx)
+			if diags.HasErrors() {
+				continue
+			}
+			locals[name] = value
+			delete(pending, name)
+			resolved = true
+		}
+	}
+	ctx.Variables["local"] = cty.ObjectVal(locals)
+
+	return ctx
+}
+
+// functions returns the terragrunt built-in functions depending only on the filesystem and environment,
+// along with the most common terraform string functions
+func functions(terragruntDir, includeDir string) map[string]function.Function {
+	return map[string]function.Function{
+		"find_in_parent_folders":     findInParentFoldersFunc(terragruntDir),
+		"get_env":                    getEnvFunc,
+		"get_parent_terragrunt_dir":  stringFunc(includeDirOrSelf(terragruntDir, includeDir)),
+		"get_terragrunt_dir":         stringFunc(terragruntDir),
+		"path_relative_from_include": relativePathFunc(terragruntDir, includeDirOrSelf(terragruntDir, includeDir)),
+		"path_relative_to_include":   relativePathFunc(includeDirOrSelf(terragruntDir, includeDir), terragruntDir),
+		"format":                     stdlib.FormatFunc,
+		"join":                       stdlib.JoinFunc,
+		"lower":                      stdlib.LowerFunc,
+		"replace":                    stdlib.ReplaceFunc,
+		"split":                      stdlib.SplitFunc,
+		"trimspace":                  stdlib.TrimSpaceFunc,
+		"upper":                      stdlib.UpperFunc,
+	}
+}
+
+func includeDirOrSelf(terragruntDir, includeDir string) string {
+	if includeDir == "" {
+		return terragruntDir
+	}
+	return includeDir
+}
+
+func stringFunc(value string) function.Function {
+	return function.New(&function.Spec{
+		Type: function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			return cty.StringVal(value), nil
+		},
+	})
+}
+
+// relativePathFunc returns the path of target relative to base, using slashes
+func relativePathFunc(base, target string) function.Function {
+	return function.New(&function.Spec{
+		Type: function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			rel, err := filepath.Rel(base, target)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			return cty.StringVal(filepath.ToSlash(rel)), nil
+		},
+	})
+}
+
+// findInParentFoldersFunc returns the path of the closest file with the given name, terragrunt.hcl by default,
+// in the parent folders of the module. The optional fallback is returned when no file is found.
+func findInParentFoldersFunc(terragruntDir string) function.Function {
+	return function.New(&function.Spec{
+		VarParam: &function.Parameter{Name: "args", Type: cty.String},
+		Type:     function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			if len(args) > 2 {
+				return cty.NilVal, errors.New("find_in_parent_folders accepts at most two arguments")
+			}
+			name := ConfigFile
+			if len(args) > 0 {
+				name = args[0].AsString()
+			}
+
+			for dir := filepath.Dir(terragruntDir); ; dir = filepath.Dir(dir) {
+				path := filepath.Join(dir, name)
+				if _, err := os.Stat(path); err == nil {
+					return cty.StringVal(path), nil
+				}
+				if dir == filepath.Dir(dir) {
+					break
+				}
+			}
+
+			if len(args) == 2 {
+				return args[1], nil
+			}
+			return cty.NilVal, errors.Errorf("Could not find a %s file in any of the parent folders", name)
+		},
+	})
+}
+
+var getEnvFunc = function.New(&function.Spec{
+	Params:   []function.Parameter{{Name: "name", Type: cty.String}},
+	VarParam: &function.Parameter{Name: "default", Type: cty.String},
+	Type:     function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		if len(args) > 2 {
+			return cty.NilVal, errors.New("get_env accepts at most two arguments")
+		}
+		if value, exists := os.LookupEnv(args[0].AsString()); exists {
+			return cty.StringVal(value), nil
+		}
+		if len(args) == 2 {
+			return args[1], nil
+		}
+		return cty.NilVal, errors.Errorf("Environment variable %s is not set", args[0].AsString())
+	},
+})
@@ -0,0 +1,268 @@
+package terragrunt
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/tfdir"
+)
+
+const TerragruntSupplier = "terragrunt"
+
+// ConfigFile is the name of terragrunt configuration files
+const ConfigFile = "terragrunt.hcl"
+
+// Directories never containing terragrunt modules
+var skippedDirs = map[string]bool{
+	".terragrunt-cache": true,
+	".terraform":        true,
+}
+
+// Stack is a directory tree of terragrunt modules
+type Stack struct {
+	path   string
+	parser *hclparse.Parser
+}
+
+// module is a terragrunt configuration file, with the configuration it includes.
+// Its path is absolute to match included paths, dir is the directory as found in the stack.
+type module struct {
+	path     string
+	dir      string
+	body     *hclsyntax.Body
+	includes []string
+}
+
+func NewStack(path string) *Stack {
+	if path == "" {
+		path = "."
+	}
+	return &Stack{path: path, parser: hclparse.NewParser()}
+}
+
+// SupplierConfigs finds every terragrunt module of the stack and resolves their remote state
+// to the tfstate supplier configs reading it. Configurations only included by other modules are skipped.
+func (s *Stack) SupplierConfigs() ([]config.SupplierConfig, error) {
+	modules, err := s.modules()
+	if err != nil {
+		return nil, err
+	}
+	if len(modules) == 0 {
+		return nil, errors.Errorf("no %s file was found in %s", ConfigFile, s.path)
+	}
+
+	included := make(map[string]bool)
+	for _, m := range modules {
+		for _, include := range m.includes {
+			included[include] = true
+		}
+	}
+
+	configs := make([]config.SupplierConfig, 0, len(modules))
+	seen := make(map[string]bool)
+	for _, m := range modules {
+		if included[m.path] {
+			continue
+		}
+
+		backend, err := s.remoteState(m)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Unable to read remote state of %s", filepath.Join(m.dir, ConfigFile))
+		}
+		if backend == nil {
+			logrus.WithField("path", m.dir).Debug("Skipping terragrunt module without remote state")
+			continue
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"path":    m.dir,
+			"backend": backend.Type,
+		}).Debug("Found terragrunt remote state")
+
+		stateConfigs, err := backend.SupplierConfigs(m.dir, tfdir.DefaultWorkspace)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Unable to read remote state of %s", filepath.Join(m.dir, ConfigFile))
+		}
+		for _, c := range stateConfigs {
+			// Modules sharing the same state are only read once
+			if !seen[c.String()] {
+				seen[c.String()] = true
+				configs = append(configs, c)
+			}
+		}
+	}
+	return configs, nil
+}
+
+// modules walks the stack to parse every terragrunt configuration file
+func (s *Stack) modules() ([]*module, error) {
+	var modules []*module
+	err := filepath.Walk(s.path, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != s.path && (skippedDirs[info.Name()] || strings.HasPrefix(info.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.Name() != ConfigFile {
+			return nil
+		}
+
+		m, err := s.module(path)
+		if err != nil {
+			return err
+		}
+		modules = append(modules, m)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return modules, nil
+}
+
+func (s *Stack) module(file string) (*module, error) {
+	path, err := filepath.Abs(file)
+	if err != nil {
+		return nil, err
+	}
+	body, err := s.parse(file)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &module{path: path, dir: filepath.Dir(file), body: body}
+	ctx := newEvalContext(body, filepath.Dir(path), "")
+	for _, block := range body.Blocks {
+		if block.Type != "include" {
+			continue
+		}
+		attr, exists := block.Body.Attributes["path"]
+		if !exists {
+			return nil, errors.Errorf("Missing path attribute in include block in %s", block.DefRange())
+		}
+		value, diags := attr.Expr.Value(ctx)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		if value.IsNull() || !value.IsKnown() || value.Type() != cty.String {
+			return nil, errors.Errorf("Unsupported include path in %s", attr.SrcRange)
+		}
+		include := value.AsString()
+		if !filepath.IsAbs(include) {
+			include = filepath.Join(filepath.Dir(path), include)
+		}
+		m.includes = append(m.includes, filepath.Clean(include))
+	}
+	return m, nil
+}
+
+// remoteState evaluates the remote_state of a module, or of the configuration it includes.
+// Included configurations are evaluated in the context of the module, like terragrunt does.
+func (s *Stack) remoteState(m *module) (*tfdir.Backend, error) {
+	includeDir := ""
+	if len(m.includes) > 0 {
+		includeDir = filepath.Dir(m.includes[0])
+	}
+	if hasRemoteState(m.body) {
+		return evalRemoteState(m.body, filepath.Dir(m.path), includeDir)
+	}
+
+	for _, include := range m.includes {
+		body, err := s.parse(include)
+		if err != nil {
+			return nil, err
+		}
+		if hasRemoteState(body) {
+			return evalRemoteState(body, filepath.Dir(m.path), filepath.Dir(include))
+		}
+	}
+	return nil, nil
+}
+
+func (s *Stack) parse(path string) (*hclsyntax.Body, error) {
+	file, diags := s.parser.ParseHCLFile(path)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, errors.Errorf("Unable to parse %s", path)
+	}
+	return body, nil
+}
+
+func hasRemoteState(body *hclsyntax.Body) bool {
+	if _, exists := body.Attributes["remote_state"]; exists {
+		return true
+	}
+	for _, block := range body.Blocks {
+		if block.Type == "remote_state" {
+			return true
+		}
+	}
+	return false
+}
+
+// evalRemoteState evaluates the remote_state of a configuration, either set as a block or as an object attribute
+func evalRemoteState(body *hclsyntax.Body, terragruntDir, includeDir string) (*tfdir.Backend, error) {
+	ctx := newEvalContext(body, terragruntDir, includeDir)
+
+	if attr, exists := body.Attributes["remote_state"]; exists {
+		value, diags := attr.Expr.Value(ctx)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		if value.IsNull() || !value.Type().IsObjectType() || !value.Type().HasAttribute("backend") {
+			return nil, errors.Errorf("Missing backend in remote_state in %s", attr.SrcRange)
+		}
+		config := cty.NullVal(cty.DynamicPseudoType)
+		if value.Type().HasAttribute("config") {
+			config = value.GetAttr("config")
+		}
+		return newBackend(value.GetAttr("backend"), config, attr.SrcRange)
+	}
+
+	var block *hclsyntax.Block
+	for _, b := range body.Blocks {
+		if b.Type == "remote_state" {
+			block = b
+			break
+		}
+	}
+	backendAttr, exists := block.Body.Attributes["backend"]
+	if !exists {
+		return nil, errors.Errorf("Missing backend attribute in remote_state block in %s", block.DefRange())
+	}
+	backend, diags := backendAttr.Expr.Value(ctx)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	config := cty.NullVal(cty.DynamicPseudoType)
+	if configAttr, exists := block.Body.Attributes["config"]; exists {
+		config, diags = configAttr.Expr.Value(ctx)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+	}
+	return newBackend(backend, config, block.DefRange())
+}
+
+func newBackend(backendType, config cty.Value, rng hcl.Range) (*tfdir.Backend, error) {
+	if backendType.IsNull() || !backendType.IsKnown() || backendType.Type() != cty.String {
+		return nil, errors.Errorf("Unsupported backend in remote_state in %s", rng)
+	}
+	return tfdir.NewBackend(backendType.AsString(), config)
+}
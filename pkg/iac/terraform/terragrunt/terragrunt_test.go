@@ -0,0 +1,75 @@
+package terragrunt
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+)
+
+func TestStack_SupplierConfigs(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		env  map[string]string
+		want []config.SupplierConfig
+		err  string
+	}{
+		{
+			name: "stack with included and own remote states",
+			path: "testdata/stack",
+			want: []config.SupplierConfig{
+				{Key: "tfstate", Backend: "s3", Path: "my-prod-states/app/terraform.tfstate"},
+				{Key: "tfstate", Backend: "gs", Path: "legacy-states/terraform/legacy-app/default.tfstate"},
+				{Key: "tfstate", Path: "testdata/stack/local/states/local.tfstate"},
+				{Key: "tfstate", Backend: "s3", Path: "my-prod-states/network/vpc/terraform.tfstate"},
+				{Key: "tfstate", Backend: "s3", Path: "shared-states/shared/terraform.tfstate"},
+			},
+		},
+		{
+			name: "stack with environment variable",
+			path: "testdata/stack/network",
+			env:  map[string]string{"TG_STATE_BUCKET": "env-states"},
+			want: []config.SupplierConfig{
+				{Key: "tfstate", Backend: "s3", Path: "env-states/network/vpc/terraform.tfstate"},
+			},
+		},
+		{
+			name: "directory without terragrunt configuration",
+			path: "testdata/empty",
+			err:  "no terragrunt.hcl file was found in testdata/empty",
+		},
+		{
+			name: "missing included configuration",
+			path: "testdata/missing_include",
+			err:  "testdata/missing_include/app/terragrunt.hcl:2,10-33: Error in function call; Call to function \"find_in_parent_folders\" failed: Could not find a root.hcl file in any of the parent folders.",
+		},
+		{
+			name: "missing backend",
+			path: "testdata/missing_backend",
+			err:  "Unable to read remote state of testdata/missing_backend/terragrunt.hcl: Missing backend attribute in remote_state block in testdata/missing_backend/terragrunt.hcl:1,1-15",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				os.Setenv(key, value)
+			}
+			defer func() {
+				for key := range tt.env {
+					os.Unsetenv(key)
+				}
+			}()
+
+			got, err := NewStack(tt.path).SupplierConfigs()
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,5 @@
+remote_state {
+  config = {
+    bucket = "my-states"
+  }
+}
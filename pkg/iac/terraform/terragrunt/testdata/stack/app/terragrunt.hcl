@@ -0,0 +1,11 @@
+include {
+  path = find_in_parent_folders()
+}
+
+dependency "vpc" {
+  config_path = "../network/vpc"
+}
+
+inputs = {
+  vpc_id = dependency.vpc.outputs.vpc_id
+}
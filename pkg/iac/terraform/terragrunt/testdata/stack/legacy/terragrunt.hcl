@@ -0,0 +1,14 @@
+locals {
+  project = "legacy"
+  prefix  = "terraform/${lower(local.name)}"
+  name    = "Legacy-App"
+}
+
+remote_state = {
+  backend = "gcs"
+  config = {
+    bucket  = "${local.project}-states"
+    prefix  = local.prefix
+    project = local.project
+  }
+}
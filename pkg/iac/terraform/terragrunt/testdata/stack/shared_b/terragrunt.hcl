@@ -0,0 +1,8 @@
+remote_state {
+  backend = "s3"
+  config = {
+    bucket = "shared-states"
+    key    = "shared/terraform.tfstate"
+    region = "us-east-1"
+  }
+}
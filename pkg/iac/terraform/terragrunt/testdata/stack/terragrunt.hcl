@@ -0,0 +1,22 @@
+locals {
+  environment = "prod"
+  bucket      = "my-${local.environment}-states"
+}
+
+remote_state {
+  backend = "s3"
+  generate = {
+    path      = "backend.tf"
+    if_exists = "overwrite_terragrunt"
+  }
+  config = {
+    bucket         = get_env("TG_STATE_BUCKET", local.bucket)
+    key            = "${path_relative_to_include()}/terraform.tfstate"
+    region         = "eu-west-3"
+    encrypt        = true
+    dynamodb_table = "terraform-locks"
+    s3_bucket_tags = {
+      owner = "terragrunt"
+    }
+  }
+}
@@ -0,0 +1,241 @@
+package tfdir
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/enumerator"
+)
+
+// Terraform backend types
+const (
+	backendLocal      = "local"
+	backendS3         = "s3"
+	backendGCS        = "gcs"
+	backendAzureRM    = "azurerm"
+	backendConsul     = "consul"
+	backendPg         = "pg"
+	backendKubernetes = "kubernetes"
+	backendHTTP       = "http"
+	backendRemote     = "remote"
+	backendCloud      = "cloud"
+)
+
+// SupplierConfigs resolves the backend configuration of the working directory dir to the tfstate
+// supplier configs reading the given workspace, following where each backend stores workspaces
+func (b *Backend) SupplierConfigs(dir, workspace string) ([]config.SupplierConfig, error) {
+	switch b.Type {
+	case backendLocal:
+		return localConfigs(dir, b, workspace), nil
+	case backendS3:
+		return s3Configs(b, workspace)
+	case backendGCS:
+		return gcsConfigs(b, workspace)
+	case backendAzureRM:
+		return azurermConfigs(b, workspace)
+	case backendConsul:
+		return consulConfigs(b, workspace)
+	case backendPg:
+		return pgConfigs(b, workspace)
+	case backendKubernetes:
+		return kubernetesConfigs(b, workspace)
+	case backendHTTP:
+		return httpConfigs(b, workspace)
+	case backendRemote, backendCloud:
+		return remoteConfigs(b, workspace)
+	default:
+		return nil, errors.Errorf("Unsupported backend type '%s'", b.Type)
+	}
+}
+
+func stateConfig(backendKey, path string) config.SupplierConfig {
+	return config.SupplierConfig{
+		Key:     state.TerraformStateReaderSupplier,
+		Backend: backendKey,
+		Path:    path,
+	}
+}
+
+func localConfigs(dir string, b *Backend, workspace string) []config.SupplierConfig {
+	path := b.attribute("path", "terraform.tfstate")
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	workspaceDir := b.attribute("workspace_dir", "terraform.tfstate.d")
+	if !filepath.IsAbs(workspaceDir) {
+		workspaceDir = filepath.Join(dir, workspaceDir)
+	}
+
+	switch workspace {
+	case DefaultWorkspace:
+		return []config.SupplierConfig{stateConfig(backend.BackendKeyFile, path)}
+	case AllWorkspaces:
+		configs := make([]config.SupplierConfig, 0, 2)
+		// The default workspace state only exists once something was applied in it
+		if _, err := os.Stat(path); err == nil {
+			configs = append(configs, stateConfig(backend.BackendKeyFile, path))
+		}
+		return append(configs, stateConfig(backend.BackendKeyFile, filepath.Join(workspaceDir, "*", "terraform.tfstate")))
+	default:
+		return []config.SupplierConfig{stateConfig(backend.BackendKeyFile, filepath.Join(workspaceDir, workspace, "terraform.tfstate"))}
+	}
+}
+
+func s3Configs(b *Backend, workspace string) ([]config.SupplierConfig, error) {
+	values, err := b.requireAttributes("bucket", "key")
+	if err != nil {
+		return nil, err
+	}
+	path := strings.Join([]string{values[0], values[1]}, "/")
+	if workspace == DefaultWorkspace {
+		return []config.SupplierConfig{stateConfig(backend.BackendKeyS3, path)}, nil
+	}
+
+	// The s3 enumerator resolves where workspaces are stored, exposing the workspace of each state
+	options := []string{enumerator.S3OptionWorkspaces + "=" + workspace}
+	if prefix, exists := b.Config["workspace_key_prefix"]; exists {
+		options = append(options, enumerator.S3OptionWorkspaceKeyPrefix+"="+prefix)
+	}
+	return []config.SupplierConfig{stateConfig(backend.BackendKeyS3, path+"?"+strings.Join(options, "&"))}, nil
+}
+
+func gcsConfigs(b *Backend, workspace string) ([]config.SupplierConfig, error) {
+	values, err := b.requireAttributes("bucket")
+	if err != nil {
+		return nil, err
+	}
+	path := []string{values[0]}
+	if prefix := strings.Trim(b.attribute("prefix", ""), "/"); prefix != "" {
+		path = append(path, prefix)
+	}
+	// The gcs backend stores every workspace, including the default one, as PREFIX/WORKSPACE.tfstate
+	return []config.SupplierConfig{stateConfig(backend.BackendKeyGS, strings.Join(append(path, workspace+".tfstate"), "/"))}, nil
+}
+
+func azurermConfigs(b *Backend, workspace string) ([]config.SupplierConfig, error) {
+	values, err := b.requireAttributes("storage_account_name", "container_name", "key")
+	if err != nil {
+		return nil, err
+	}
+	key := values[2]
+
+	switch workspace {
+	case DefaultWorkspace:
+	case AllWorkspaces:
+		// Other workspaces are stored as KEYenv:WORKSPACE, next to the default one
+		key += "*"
+	default:
+		key += "env:" + workspace
+	}
+	return []config.SupplierConfig{stateConfig(backend.BackendKeyAzureRM, strings.Join([]string{values[0], values[1], key}, "/"))}, nil
+}
+
+func consulConfigs(b *Backend, workspace string) ([]config.SupplierConfig, error) {
+	values, err := b.requireAttributes("path")
+	if err != nil {
+		return nil, err
+	}
+	path := values[0]
+
+	// The consul enumerator lists every workspace of a path without workspace
+	if workspace != AllWorkspaces {
+		path += backend.ConsulWorkspaceSeparator + workspace
+	}
+	return []config.SupplierConfig{stateConfig(backend.BackendKeyConsul, path)}, nil
+}
+
+func pgConfigs(b *Backend, workspace string) ([]config.SupplierConfig, error) {
+	values, err := b.requireAttributes("conn_str")
+	if err != nil {
+		return nil, err
+	}
+	connStr, err := url.Parse(values[0])
+	if err != nil || (connStr.Scheme != "postgres" && connStr.Scheme != "postgresql") {
+		return nil, errors.New("Unsupported pg backend connection string, it must be a postgres:// URL")
+	}
+	query := connStr.Query()
+	if schemaName := b.attribute("schema_name", ""); schemaName != "" {
+		query.Set(backend.PgParamSchemaName, schemaName)
+	}
+	// The pg enumerator lists every workspace when none is selected
+	if workspace != AllWorkspaces {
+		query.Set(backend.PgParamWorkspace, workspace)
+	}
+	connStr.RawQuery = query.Encode()
+
+	return []config.SupplierConfig{stateConfig(backend.BackendKeyPg, strings.TrimPrefix(connStr.String(), connStr.Scheme+"://"))}, nil
+}
+
+func kubernetesConfigs(b *Backend, workspace string) ([]config.SupplierConfig, error) {
+	values, err := b.requireAttributes("secret_suffix")
+	if err != nil {
+		return nil, err
+	}
+	path := []string{b.attribute("namespace", "default"), values[0]}
+	// The kubernetes enumerator lists every workspace when none is selected
+	if workspace != AllWorkspaces {
+		path = append(path, workspace)
+	}
+	return []config.SupplierConfig{stateConfig(backend.BackendKeyKubernetes, strings.Join(path, "/"))}, nil
+}
+
+func httpConfigs(b *Backend, workspace string) ([]config.SupplierConfig, error) {
+	if workspace != DefaultWorkspace {
+		return nil, errors.New("The http backend does not support workspaces")
+	}
+	values, err := b.requireAttributes("address")
+	if err != nil {
+		return nil, err
+	}
+	address, err := url.Parse(values[0])
+	if err != nil || (address.Scheme != backend.BackendKeyHTTP && address.Scheme != backend.BackendKeyHTTPS) {
+		return nil, errors.Errorf("Unsupported http backend address '%s'", values[0])
+	}
+	return []config.SupplierConfig{stateConfig(address.Scheme, strings.TrimPrefix(address.String(), address.Scheme+"://"))}, nil
+}
+
+// remoteConfigs resolves the remote backend and the cloud block to the tfcloud enumerator path
+// ORGANIZATION/WORKSPACE_NAME_GLOB, using the --tfc-endpoint API whatever the configured hostname is
+func remoteConfigs(b *Backend, workspace string) ([]config.SupplierConfig, error) {
+	values, err := b.requireAttributes("organization")
+	if err != nil {
+		return nil, err
+	}
+	organization := values[0]
+	name, prefix, tags := b.Config["workspaces.name"], b.Config["workspaces.prefix"], b.Config["workspaces.tags"]
+	selected := workspace != DefaultWorkspace && workspace != AllWorkspaces
+
+	var path string
+	switch {
+	case name != "":
+		if selected && workspace != name {
+			return nil, errors.Errorf("The %s backend only uses the '%s' workspace", b.Type, name)
+		}
+		path = strings.Join([]string{organization, name}, "/")
+	case prefix != "":
+		// Without a selected workspace, every workspace using the prefix is read
+		pattern := prefix + "*"
+		if selected {
+			pattern = prefix + workspace
+		}
+		path = strings.Join([]string{organization, pattern}, "/")
+	case tags != "":
+		pattern := "*"
+		if selected {
+			pattern = workspace
+		}
+		path = fmt.Sprintf("%s/%s?%s=%s", organization, pattern, enumerator.TFCloudOptionTags, tags)
+	default:
+		return nil, errors.Errorf("Missing workspaces configuration in %s backend configuration", b.Type)
+	}
+
+	return []config.SupplierConfig{stateConfig(backend.BackendKeyTFCloud, path)}, nil
+}
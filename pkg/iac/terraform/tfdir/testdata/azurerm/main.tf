@@ -0,0 +1,8 @@
+terraform {
+  backend "azurerm" {
+    resource_group_name  = "terraform"
+    storage_account_name = "tfstates"
+    container_name       = "states"
+    key                  = "project.tfstate"
+  }
+}
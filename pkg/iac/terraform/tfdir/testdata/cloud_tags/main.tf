@@ -0,0 +1,9 @@
+terraform {
+  cloud {
+    organization = "company"
+
+    workspaces {
+      tags = ["aws", "network"]
+    }
+  }
+}
@@ -0,0 +1,6 @@
+terraform {
+  backend "s3" {
+    bucket = "my-bucket"
+    key    = "project/terraform.tfstate"
+  }
+}
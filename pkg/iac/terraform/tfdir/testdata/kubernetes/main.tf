@@ -0,0 +1,6 @@
+terraform {
+  backend "kubernetes" {
+    secret_suffix = "project"
+    namespace     = "terraform"
+  }
+}
@@ -0,0 +1,6 @@
+terraform {
+  backend "pg" {
+    conn_str    = "postgres://db.example.com/terraform?sslmode=disable"
+    schema_name = "project"
+  }
+}
@@ -0,0 +1,9 @@
+terraform {
+  backend "remote" {
+    organization = "company"
+
+    workspaces {
+      name = "project"
+    }
+  }
+}
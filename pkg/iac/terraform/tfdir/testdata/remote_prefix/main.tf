@@ -0,0 +1,10 @@
+terraform {
+  backend "remote" {
+    hostname     = "app.terraform.io"
+    organization = "company"
+
+    workspaces {
+      prefix = "project-"
+    }
+  }
+}
@@ -0,0 +1,9 @@
+terraform {
+  required_version = ">= 0.14"
+
+  backend "s3" {
+    bucket = "my-bucket"
+    key    = "project/terraform.tfstate"
+    region = "us-east-1"
+  }
+}
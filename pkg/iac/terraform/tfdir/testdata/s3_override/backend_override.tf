@@ -0,0 +1,7 @@
+terraform {
+  backend "s3" {
+    bucket               = "override-bucket"
+    key                  = "override/terraform.tfstate"
+    workspace_key_prefix = "workspaces"
+  }
+}
@@ -0,0 +1,6 @@
+terraform {
+  backend "s3" {
+    bucket = var.bucket
+    key    = "project/terraform.tfstate"
+  }
+}
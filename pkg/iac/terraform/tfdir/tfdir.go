@@ -0,0 +1,399 @@
+package tfdir
+
+import (
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+)
+
+const TerraformDirSupplier = "tfdir"
+
+// Options of the tfdir path, e.g. tfdir://path/to/project?backend-config=prod.tfbackend&workspace=prod
+const OptionBackendConfig = "backend-config"
+const OptionWorkspace = "workspace"
+
+// AllWorkspaces selects every workspace of the backend
+const AllWorkspaces = "*"
+
+// DefaultWorkspace is the workspace used when none is selected
+const DefaultWorkspace = "default"
+
+var supportedOptions = []string{OptionBackendConfig, OptionWorkspace}
+
+var rootSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{{Type: "terraform"}},
+}
+
+var terraformSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "backend", LabelNames: []string{"type"}},
+		{Type: backendCloud},
+	},
+}
+
+// remoteSchema is the subset of the remote backend and cloud block configuration locating workspaces
+var remoteSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{{Name: "hostname"}, {Name: "organization"}},
+	Blocks:     []hcl.BlockHeaderSchema{{Type: "workspaces"}},
+}
+
+// Backend is the backend configuration of a terraform working directory
+type Backend struct {
+	Type   string
+	Config map[string]string
+}
+
+// WorkingDir is a terraform working directory to read states from
+type WorkingDir struct {
+	path          string
+	backendConfig []string
+	workspace     string
+}
+
+// NewWorkingDir parses a tfdir path, made of the directory path and optional options
+func NewWorkingDir(path string) (*WorkingDir, error) {
+	dir := path
+	values := url.Values{}
+	if idx := strings.Index(path, "?"); idx >= 0 {
+		dir = path[:idx]
+		var err error
+		if values, err = url.ParseQuery(path[idx+1:]); err != nil {
+			return nil, errors.Wrapf(err, "Unable to parse options of '%s'", path)
+		}
+	}
+	for key := range values {
+		if key != OptionBackendConfig && key != OptionWorkspace {
+			return nil, errors.Errorf("unsupported option '%s', supported options are: %s", key, strings.Join(supportedOptions, ","))
+		}
+	}
+	if dir == "" {
+		dir = "."
+	}
+
+	return &WorkingDir{
+		path:          dir,
+		backendConfig: values[OptionBackendConfig],
+		workspace:     values.Get(OptionWorkspace),
+	}, nil
+}
+
+// SupplierConfigs resolves the backend configured in the working directory to the matching tfstate supplier configs
+func (w *WorkingDir) SupplierConfigs() ([]config.SupplierConfig, error) {
+	backend, err := w.Backend()
+	if err != nil {
+		return nil, err
+	}
+	workspace := w.Workspace()
+
+	logrus.WithFields(logrus.Fields{
+		"path":      w.path,
+		"backend":   backend.Type,
+		"workspace": workspace,
+	}).Debug("Found terraform backend configuration")
+
+	return backend.SupplierConfigs(w.path, workspace)
+}
+
+// Workspace returns the workspace to read, selected from the options, the TF_WORKSPACE
+// environment variable or the working directory, like terraform does
+func (w *WorkingDir) Workspace() string {
+	if w.workspace != "" {
+		return w.workspace
+	}
+	if workspace := os.Getenv("TF_WORKSPACE"); workspace != "" {
+		return workspace
+	}
+	if content, err := ioutil.ReadFile(filepath.Join(w.path, ".terraform", "environment")); err == nil {
+		if workspace := strings.TrimSpace(string(content)); workspace != "" {
+			return workspace
+		}
+	}
+	return DefaultWorkspace
+}
+
+// Backend reads the backend block of the terraform configuration files of the working directory,
+// override files replace the backend block and backend-config options are merged in it
+func (w *WorkingDir) Backend() (*Backend, error) {
+	files, err := ioutil.ReadDir(w.path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to read terraform working directory")
+	}
+
+	var primaries, overrides []string
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || strings.HasPrefix(name, ".") || (!strings.HasSuffix(name, ".tf") && !strings.HasSuffix(name, ".tf.json")) {
+			continue
+		}
+		base := strings.TrimSuffix(strings.TrimSuffix(name, ".json"), ".tf")
+		if base == "override" || strings.HasSuffix(base, "_override") {
+			overrides = append(overrides, name)
+			continue
+		}
+		primaries = append(primaries, name)
+	}
+	sort.Strings(primaries)
+	sort.Strings(overrides)
+
+	parser := hclparse.NewParser()
+	var backend *Backend
+	var backendRange *hcl.Range
+	for _, name := range primaries {
+		b, r, err := readBackendBlock(parser, filepath.Join(w.path, name))
+		if err != nil {
+			return nil, err
+		}
+		if b == nil {
+			continue
+		}
+		if backend != nil {
+			return nil, errors.Errorf("Duplicate backend configuration in %s, a backend was already configured in %s", r, backendRange)
+		}
+		backend, backendRange = b, r
+	}
+	for _, name := range overrides {
+		b, _, err := readBackendBlock(parser, filepath.Join(w.path, name))
+		if err != nil {
+			return nil, err
+		}
+		if b != nil {
+			backend = b
+		}
+	}
+
+	// Without backend block, terraform uses the local backend
+	if backend == nil {
+		backend = &Backend{Type: backendLocal, Config: map[string]string{}}
+	}
+
+	for _, backendConfig := range w.backendConfig {
+		if err := w.mergeBackendConfig(parser, backend, backendConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	return backend, nil
+}
+
+// mergeBackendConfig merges a -backend-config value, either a key=value pair or a file of attributes
+func (w *WorkingDir) mergeBackendConfig(parser *hclparse.Parser, backend *Backend, backendConfig string) error {
+	if keyValue := strings.SplitN(backendConfig, "=", 2); len(keyValue) == 2 {
+		backend.Config[keyValue[0]] = keyValue[1]
+		return nil
+	}
+
+	path := backendConfig
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(w.path, path)
+	}
+	file, diags := parseFile(parser, path)
+	if diags.HasErrors() {
+		return diags
+	}
+	attrs, err := readAttributes(file.Body)
+	if err != nil {
+		return err
+	}
+	for key, value := range attrs {
+		backend.Config[key] = value
+	}
+	return nil
+}
+
+func parseFile(parser *hclparse.Parser, path string) (*hcl.File, hcl.Diagnostics) {
+	if strings.HasSuffix(path, ".json") {
+		return parser.ParseJSONFile(path)
+	}
+	return parser.ParseHCLFile(path)
+}
+
+func readBackendBlock(parser *hclparse.Parser, path string) (*Backend, *hcl.Range, error) {
+	file, diags := parseFile(parser, path)
+	if diags.HasErrors() {
+		return nil, nil, diags
+	}
+
+	content, _, diags := file.Body.PartialContent(rootSchema)
+	if diags.HasErrors() {
+		return nil, nil, diags
+	}
+
+	var backend *Backend
+	var backendRange *hcl.Range
+	for _, terraformBlock := range content.Blocks {
+		terraformContent, _, diags := terraformBlock.Body.PartialContent(terraformSchema)
+		if diags.HasErrors() {
+			return nil, nil, diags
+		}
+		for _, block := range terraformContent.Blocks {
+			if backend != nil {
+				return nil, nil, errors.Errorf("Duplicate backend configuration in %s, a backend was already configured in %s", block.DefRange, backendRange)
+			}
+			backendRange = block.DefRange.Ptr()
+			backendType := backendCloud
+			if len(block.Labels) > 0 {
+				backendType = block.Labels[0]
+			}
+
+			var attrs map[string]string
+			var err error
+			if backendType == backendRemote || backendType == backendCloud {
+				attrs, err = readRemoteAttributes(block.Body)
+			} else {
+				attrs, err = readAttributes(block.Body)
+			}
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "Unable to read %s backend configuration", backendType)
+			}
+			backend = &Backend{Type: backendType, Config: attrs}
+		}
+	}
+
+	return backend, backendRange, nil
+}
+
+// readRemoteAttributes reads the remote backend configuration, attributes of the workspaces block are prefixed by "workspaces."
+func readRemoteAttributes(body hcl.Body) (map[string]string, error) {
+	content, _, diags := body.PartialContent(remoteSchema)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	values := make(map[string]string)
+	for name, attr := range content.Attributes {
+		value, ok, err := attributeValue(attr)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			values[name] = value
+		}
+	}
+	for _, block := range content.Blocks {
+		attrs, err := readAttributes(block.Body)
+		if err != nil {
+			return nil, err
+		}
+		for name, value := range attrs {
+			values["workspaces."+name] = value
+		}
+	}
+	return values, nil
+}
+
+func readAttributes(body hcl.Body) (map[string]string, error) {
+	attrs, diags := body.JustAttributes()
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	values := make(map[string]string, len(attrs))
+	for name, attr := range attrs {
+		value, ok, err := attributeValue(attr)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			values[name] = value
+		}
+	}
+	return values, nil
+}
+
+// attributeValue evaluates an attribute as a string, lists are joined with commas and null values are ignored
+func attributeValue(attr *hcl.Attribute) (string, bool, error) {
+	value, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() {
+		return "", false, diags
+	}
+	str, ok, err := stringValue(value)
+	if err != nil {
+		return "", false, errors.Errorf("Unsupported value for attribute '%s' in %s", attr.Name, attr.Range)
+	}
+	return str, ok, nil
+}
+
+func stringValue(value cty.Value) (string, bool, error) {
+	if value.IsNull() {
+		return "", false, nil
+	}
+
+	if value.Type().IsListType() || value.Type().IsTupleType() || value.Type().IsSetType() {
+		var elements []string
+		for it := value.ElementIterator(); it.Next(); {
+			_, element := it.Element()
+			str, err := convert.Convert(element, cty.String)
+			if err != nil || str.IsNull() {
+				return "", false, errors.New("Unsupported list element")
+			}
+			elements = append(elements, str.AsString())
+		}
+		return strings.Join(elements, ","), true, nil
+	}
+
+	str, err := convert.Convert(value, cty.String)
+	if err != nil {
+		return "", false, err
+	}
+	return str.AsString(), true, nil
+}
+
+// NewBackend creates a backend from an evaluated configuration object,
+// attributes that are not strings or lists (e.g. maps of tags) are ignored
+func NewBackend(backendType string, config cty.Value) (*Backend, error) {
+	backend := &Backend{Type: backendType, Config: map[string]string{}}
+	if config.IsNull() {
+		return backend, nil
+	}
+	if !config.IsKnown() || !(config.Type().IsObjectType() || config.Type().IsMapType()) {
+		return nil, errors.Errorf("Unsupported %s backend configuration, it must be an object", backendType)
+	}
+
+	for it := config.ElementIterator(); it.Next(); {
+		key, value := it.Element()
+		if value.Type().IsMapType() || value.Type().IsObjectType() {
+			continue
+		}
+		str, ok, err := stringValue(value)
+		if err != nil {
+			return nil, errors.Errorf("Unsupported value for attribute '%s' of %s backend configuration", key.AsString(), backendType)
+		}
+		if ok {
+			backend.Config[key.AsString()] = str
+		}
+	}
+	return backend, nil
+}
+
+// requireAttributes returns the values of the given attributes, failing if one of them is missing
+func (b *Backend) requireAttributes(names ...string) ([]string, error) {
+	values := make([]string, 0, len(names))
+	for _, name := range names {
+		value := b.Config[name]
+		if value == "" {
+			return nil, errors.Errorf("Missing '%s' attribute in %s backend configuration", name, b.Type)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// attribute returns the value of an attribute, or its default value when not set
+func (b *Backend) attribute(name, defaultValue string) string {
+	if value, exist := b.Config[name]; exist {
+		return value
+	}
+	return defaultValue
+}
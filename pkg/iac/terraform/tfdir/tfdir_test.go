@@ -0,0 +1,263 @@
+package tfdir
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+)
+
+func TestNewWorkingDir(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want *WorkingDir
+		err  string
+	}{
+		{
+			name: "directory only",
+			path: "path/to/project",
+			want: &WorkingDir{path: "path/to/project"},
+		},
+		{
+			name: "current directory with options",
+			path: "?workspace=prod&backend-config=prod.tfbackend&backend-config=key=prod.tfstate",
+			want: &WorkingDir{
+				path:          ".",
+				backendConfig: []string{"prod.tfbackend", "key=prod.tfstate"},
+				workspace:     "prod",
+			},
+		},
+		{
+			name: "unsupported option",
+			path: "path/to/project?foo=bar",
+			err:  "unsupported option 'foo', supported options are: backend-config,workspace",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewWorkingDir(tt.path)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWorkingDir_SupplierConfigs(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		env  map[string]string
+		want []config.SupplierConfig
+		err  string
+	}{
+		{
+			name: "local backend by default",
+			path: "testdata/local",
+			want: []config.SupplierConfig{
+				{Key: "tfstate", Path: "testdata/local/terraform.tfstate"},
+			},
+		},
+		{
+			name: "local backend workspace",
+			path: "testdata/local_workspaces?workspace=prod",
+			want: []config.SupplierConfig{
+				{Key: "tfstate", Path: "testdata/local_workspaces/terraform.tfstate.d/prod/terraform.tfstate"},
+			},
+		},
+		{
+			name: "local backend all workspaces without default state",
+			path: "testdata/local_workspaces?workspace=*",
+			want: []config.SupplierConfig{
+				{Key: "tfstate", Path: "testdata/local_workspaces/terraform.tfstate.d/*/terraform.tfstate"},
+			},
+		},
+		{
+			name: "s3 backend with workspace selected in the working directory",
+			path: "testdata/s3",
+			want: []config.SupplierConfig{
+				{Key: "tfstate", Backend: "s3", Path: "my-bucket/project/terraform.tfstate?workspaces=staging"},
+			},
+		},
+		{
+			name: "s3 backend with workspace from environment",
+			path: "testdata/s3",
+			env:  map[string]string{"TF_WORKSPACE": "default"},
+			want: []config.SupplierConfig{
+				{Key: "tfstate", Backend: "s3", Path: "my-bucket/project/terraform.tfstate"},
+			},
+		},
+		{
+			name: "s3 backend all workspaces",
+			path: "testdata/s3?workspace=*",
+			want: []config.SupplierConfig{
+				{Key: "tfstate", Backend: "s3", Path: "my-bucket/project/terraform.tfstate?workspaces=*"},
+			},
+		},
+		{
+			name: "s3 backend with override file",
+			path: "testdata/s3_override?workspace=prod",
+			want: []config.SupplierConfig{
+				{Key: "tfstate", Backend: "s3", Path: "override-bucket/override/terraform.tfstate?workspaces=prod&workspace_key_prefix=workspaces"},
+			},
+		},
+		{
+			name: "s3 backend with backend config file and value",
+			path: "testdata/s3_override?backend-config=prod.s3.tfbackend&backend-config=key=prod/terraform.tfstate",
+			want: []config.SupplierConfig{
+				{Key: "tfstate", Backend: "s3", Path: "prod-bucket/prod/terraform.tfstate"},
+			},
+		},
+		{
+			name: "gcs backend in json configuration",
+			path: "testdata/gcs_json?workspace=prod",
+			want: []config.SupplierConfig{
+				{Key: "tfstate", Backend: "gs", Path: "my-bucket/project/prod.tfstate"},
+			},
+		},
+		{
+			name: "azurerm backend",
+			path: "testdata/azurerm",
+			want: []config.SupplierConfig{
+				{Key: "tfstate", Backend: "azurerm", Path: "tfstates/states/project.tfstate"},
+			},
+		},
+		{
+			name: "azurerm backend workspace",
+			path: "testdata/azurerm?workspace=prod",
+			want: []config.SupplierConfig{
+				{Key: "tfstate", Backend: "azurerm", Path: "tfstates/states/project.tfstateenv:prod"},
+			},
+		},
+		{
+			name: "consul backend",
+			path: "testdata/consul",
+			want: []config.SupplierConfig{
+				{Key: "tfstate", Backend: "consul", Path: "states/project-env:default"},
+			},
+		},
+		{
+			name: "consul backend all workspaces",
+			path: "testdata/consul?workspace=*",
+			want: []config.SupplierConfig{
+				{Key: "tfstate", Backend: "consul", Path: "states/project"},
+			},
+		},
+		{
+			name: "pg backend",
+			path: "testdata/pg?workspace=prod",
+			want: []config.SupplierConfig{
+				{Key: "tfstate", Backend: "pg", Path: "db.example.com/terraform?schema_name=project&sslmode=disable&workspace=prod"},
+			},
+		},
+		{
+			name: "kubernetes backend",
+			path: "testdata/kubernetes",
+			want: []config.SupplierConfig{
+				{Key: "tfstate", Backend: "kubernetes", Path: "terraform/project/default"},
+			},
+		},
+		{
+			name: "kubernetes backend all workspaces",
+			path: "testdata/kubernetes?workspace=*",
+			want: []config.SupplierConfig{
+				{Key: "tfstate", Backend: "kubernetes", Path: "terraform/project"},
+			},
+		},
+		{
+			name: "http backend",
+			path: "testdata/http",
+			want: []config.SupplierConfig{
+				{Key: "tfstate", Backend: "https", Path: "states.example.com/project"},
+			},
+		},
+		{
+			name: "http backend workspace",
+			path: "testdata/http?workspace=prod",
+			err:  "The http backend does not support workspaces",
+		},
+		{
+			name: "remote backend with workspace name",
+			path: "testdata/remote",
+			want: []config.SupplierConfig{
+				{Key: "tfstate", Backend: "tfcloud", Path: "company/project"},
+			},
+		},
+		{
+			name: "remote backend with another workspace than its name",
+			path: "testdata/remote?workspace=prod",
+			err:  "The remote backend only uses the 'project' workspace",
+		},
+		{
+			name: "remote backend with workspace prefix",
+			path: "testdata/remote_prefix",
+			want: []config.SupplierConfig{
+				{Key: "tfstate", Backend: "tfcloud", Path: "company/project-*"},
+			},
+		},
+		{
+			name: "remote backend with workspace prefix and selected workspace",
+			path: "testdata/remote_prefix?workspace=prod",
+			want: []config.SupplierConfig{
+				{Key: "tfstate", Backend: "tfcloud", Path: "company/project-prod"},
+			},
+		},
+		{
+			name: "cloud block with workspace tags",
+			path: "testdata/cloud_tags",
+			want: []config.SupplierConfig{
+				{Key: "tfstate", Backend: "tfcloud", Path: "company/*?tags=aws,network"},
+			},
+		},
+		{
+			name: "duplicate backend",
+			path: "testdata/duplicate",
+			err:  "Duplicate backend configuration in testdata/duplicate/main.tf:2,3-16, a backend was already configured in testdata/duplicate/backend.tf:2,3-15",
+		},
+		{
+			name: "missing attribute",
+			path: "testdata/missing_attribute",
+			err:  "Missing 'key' attribute in s3 backend configuration",
+		},
+		{
+			name: "variable in backend",
+			path: "testdata/variable",
+			err:  "Unable to read s3 backend configuration: testdata/variable/main.tf:3,14-17: Variables not allowed; Variables may not be used here.",
+		},
+		{
+			name: "missing directory",
+			path: "testdata/missing",
+			err:  "Unable to read terraform working directory: open testdata/missing: no such file or directory",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				os.Setenv(key, value)
+			}
+			defer func() {
+				for key := range tt.env {
+					os.Unsetenv(key)
+				}
+			}()
+
+			dir, err := NewWorkingDir(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := dir.SupplierConfigs()
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
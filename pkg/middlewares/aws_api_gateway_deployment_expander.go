@@ -42,3 +42,10 @@ func (m AwsApiGatewayDeploymentExpander) Execute(_, resourcesFromState *[]*resou
 
 	return nil
 }
+
+func (m AwsApiGatewayDeploymentExpander) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsApiGatewayDeploymentResourceType,
+		aws.AwsApiGatewayStageResourceType,
+	}
+}
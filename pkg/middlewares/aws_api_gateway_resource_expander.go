@@ -56,3 +56,10 @@ func (m *AwsApiGatewayResourceExpander) handleResource(api *resource.Resource, r
 
 	return nil
 }
+
+func (m AwsApiGatewayResourceExpander) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsApiGatewayRestApiResourceType,
+		aws.AwsApiGatewayResourceResourceType,
+	}
+}
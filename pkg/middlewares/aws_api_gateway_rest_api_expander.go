@@ -278,3 +278,15 @@ func decodeMethodExtensions(extensions map[string]interface{}) (*OpenAPIAwsMetho
 	}
 	return decodedExtensions, nil
 }
+
+func (m AwsApiGatewayRestApiExpander) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsApiGatewayRestApiResourceType,
+		aws.AwsApiGatewayResourceResourceType,
+		aws.AwsApiGatewayMethodResourceType,
+		aws.AwsApiGatewayMethodResponseResourceType,
+		aws.AwsApiGatewayIntegrationResourceType,
+		aws.AwsApiGatewayIntegrationResponseResourceType,
+		aws.AwsApiGatewayGatewayResponseResourceType,
+	}
+}
@@ -78,3 +78,10 @@ func hasRestApiPolicyAttached(api string, resourcesFromState *[]*resource.Resour
 	}
 	return false
 }
+
+func (m AwsApiGatewayRestApiPolicyExpander) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsApiGatewayRestApiResourceType,
+		aws.AwsApiGatewayRestApiPolicyResourceType,
+	}
+}
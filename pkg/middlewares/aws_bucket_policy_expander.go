@@ -79,3 +79,10 @@ func hasPolicyAttached(bucket string, resourcesFromState *[]*resource.Resource)
 	}
 	return false
 }
+
+func (m AwsBucketPolicyExpander) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsS3BucketResourceType,
+		aws.AwsS3BucketPolicyResourceType,
+	}
+}
@@ -51,3 +51,9 @@ func (m AwsConsoleApiGatewayGatewayResponse) Execute(remoteResources, resourcesF
 
 	return nil
 }
+
+func (m AwsConsoleApiGatewayGatewayResponse) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsApiGatewayGatewayResponseResourceType,
+	}
+}
@@ -70,3 +70,10 @@ func isDefaultInternetGatewayRoute(route *resource.Resource, remoteResources *[]
 	}
 	return false
 }
+
+func (m AwsDefaultInternetGatewayRoute) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsInternetGatewayResourceType,
+		aws.AwsRouteResourceType,
+	}
+}
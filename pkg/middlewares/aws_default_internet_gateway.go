@@ -67,3 +67,10 @@ func isDefaultInternetGateway(internetGateway *resource.Resource, remoteResource
 	}
 	return false
 }
+
+func (m AwsDefaultInternetGateway) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsDefaultVpcResourceType,
+		aws.AwsInternetGatewayResourceType,
+	}
+}
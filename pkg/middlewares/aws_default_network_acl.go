@@ -51,3 +51,9 @@ func (m AwsDefaultNetworkACL) Execute(remoteResources, resourcesFromState *[]*re
 
 	return nil
 }
+
+func (m AwsDefaultNetworkACL) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsDefaultNetworkACLResourceType,
+	}
+}
@@ -91,3 +91,9 @@ func (m *AwsDefaultNetworkACLRule) isDefaultACLRule(res *resource.Resource) bool
 
 	return true
 }
+
+func (m AwsDefaultNetworkACLRule) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsNetworkACLRuleResourceType,
+	}
+}
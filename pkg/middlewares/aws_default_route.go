@@ -58,3 +58,9 @@ func (m AwsDefaultRoute) Execute(remoteResources, resourcesFromState *[]*resourc
 
 	return nil
 }
+
+func (m AwsDefaultRoute) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsRouteResourceType,
+	}
+}
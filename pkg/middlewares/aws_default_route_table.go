@@ -51,3 +51,9 @@ func (m AwsDefaultRouteTable) Execute(remoteResources, resourcesFromState *[]*re
 
 	return nil
 }
+
+func (m AwsDefaultRouteTable) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsDefaultRouteTableResourceType,
+	}
+}
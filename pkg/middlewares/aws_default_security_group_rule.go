@@ -130,3 +130,10 @@ func isFromDefaultSecurityGroup(sgId *string, remoteResources *[]*resource.Resou
 	}
 	return false
 }
+
+func (m AwsDefaultSecurityGroupRule) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsDefaultSecurityGroupResourceType,
+		aws.AwsSecurityGroupRuleResourceType,
+	}
+}
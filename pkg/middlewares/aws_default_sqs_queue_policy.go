@@ -59,3 +59,9 @@ func (m AwsDefaultSQSQueuePolicy) Execute(remoteResources, resourcesFromState *[
 	*remoteResources = newRemoteResources
 	return nil
 }
+
+func (m AwsDefaultSQSQueuePolicy) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsSqsQueuePolicyResourceType,
+	}
+}
@@ -51,3 +51,9 @@ func (m AwsDefaultSubnet) Execute(remoteResources, resourcesFromState *[]*resour
 
 	return nil
 }
+
+func (m AwsDefaultSubnet) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsDefaultSubnetResourceType,
+	}
+}
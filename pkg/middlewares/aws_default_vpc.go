@@ -51,3 +51,9 @@ func (m AwsDefaultVPC) Execute(remoteResources, resourcesFromState *[]*resource.
 
 	return nil
 }
+
+func (m AwsDefaultVPC) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsDefaultVpcResourceType,
+	}
+}
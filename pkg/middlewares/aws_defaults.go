@@ -127,3 +127,10 @@ func (m AwsDefaults) Execute(remoteResources, resourcesFromState *[]*resource.Re
 
 	return nil
 }
+
+func (m AwsDefaults) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsIamRoleResourceType,
+		aws.AwsIamRolePolicyResourceType,
+	}
+}
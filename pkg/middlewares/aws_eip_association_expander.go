@@ -68,3 +68,10 @@ func (m EipAssociationExpander) haveMatchingEipAssociation(cur *resource.Resourc
 	}
 	return false
 }
+
+func (m EipAssociationExpander) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsEipResourceType,
+		aws.AwsEipAssociationResourceType,
+	}
+}
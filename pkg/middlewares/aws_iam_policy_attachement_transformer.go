@@ -70,3 +70,11 @@ func (m IamPolicyAttachmentTransformer) transform(resources *[]*resource.Resourc
 	}
 	return newResources
 }
+
+func (m IamPolicyAttachmentTransformer) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsIamPolicyAttachmentResourceType,
+		aws.AwsIamRolePolicyAttachmentResourceType,
+		aws.AwsIamUserPolicyAttachmentResourceType,
+	}
+}
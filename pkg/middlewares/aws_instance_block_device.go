@@ -124,3 +124,10 @@ func (a AwsInstanceBlockDeviceResourceMapper) hasBlockDevice(blockDevice map[str
 	}
 	return false
 }
+
+func (a AwsInstanceBlockDeviceResourceMapper) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsInstanceResourceType,
+		aws.AwsEbsVolumeResourceType,
+	}
+}
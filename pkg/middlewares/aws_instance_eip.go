@@ -54,3 +54,11 @@ func (a AwsInstanceEIP) ignorePublicIpAndDns(instance *resource.Resource, resour
 		}
 	}
 }
+
+func (a AwsInstanceEIP) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsInstanceResourceType,
+		aws.AwsEipResourceType,
+		aws.AwsEipAssociationResourceType,
+	}
+}
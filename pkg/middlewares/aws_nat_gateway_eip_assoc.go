@@ -56,3 +56,10 @@ func (a AwsNatGatewayEipAssoc) Execute(remoteResources, resourcesFromState *[]*r
 
 	return nil
 }
+
+func (a AwsNatGatewayEipAssoc) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsNatGatewayResourceType,
+		aws.AwsEipAssociationResourceType,
+	}
+}
@@ -108,3 +108,11 @@ func (e *AwsNetworkACLExpander) expandBlock(resourcesFromState *[]*resource.Reso
 
 	return results
 }
+
+func (m AwsNetworkACLExpander) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsNetworkACLResourceType,
+		aws.AwsDefaultNetworkACLResourceType,
+		aws.AwsNetworkACLRuleResourceType,
+	}
+}
@@ -58,3 +58,10 @@ func (m AwsRDSClusterInstanceExpander) Execute(remoteResources, resourcesFromSta
 	*resourcesFromState = newResourcesFromState
 	return nil
 }
+
+func (m AwsRDSClusterInstanceExpander) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsDbInstanceResourceType,
+		aws.AwsRDSClusterInstanceResourceType,
+	}
+}
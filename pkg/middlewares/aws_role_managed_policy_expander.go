@@ -98,3 +98,10 @@ func (a AwsRoleManagedPolicyExpander) Execute(remoteResources, resourcesFromStat
 	*resourcesFromState = newList
 	return nil
 }
+
+func (a AwsRoleManagedPolicyExpander) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsIamRoleResourceType,
+		aws.AwsIamPolicyAttachmentResourceType,
+	}
+}
@@ -178,3 +178,11 @@ func (m *AwsRouteTableExpander) routeExists(routeId string, resourcesFromState [
 
 	return false
 }
+
+func (m AwsRouteTableExpander) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsRouteTableResourceType,
+		aws.AwsDefaultRouteTableResourceType,
+		aws.AwsRouteResourceType,
+	}
+}
@@ -91,3 +91,10 @@ func (m *AwsSNSTopicPolicyExpander) hasPolicyAttached(topic *resource.Resource,
 	}
 	return false
 }
+
+func (m AwsSNSTopicPolicyExpander) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsSnsTopicResourceType,
+		aws.AwsSnsTopicPolicyResourceType,
+	}
+}
@@ -93,3 +93,10 @@ func (m *AwsSQSQueuePolicyExpander) hasPolicyAttached(queue *resource.Resource,
 	}
 	return false
 }
+
+func (m AwsSQSQueuePolicyExpander) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsSqsQueueResourceType,
+		aws.AwsSqsQueuePolicyResourceType,
+	}
+}
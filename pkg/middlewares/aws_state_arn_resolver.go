@@ -0,0 +1,78 @@
+package middlewares
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/sirupsen/logrus"
+
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/cloudskiff/driftctl/pkg/resource/aws"
+)
+
+// Terraform states do not keep track of the account and the region a resource lives in.
+// This middleware resolves them from the resource ARN (or from the region attribute for S3 buckets)
+// so state resources are only matched against remote resources enumerated in the same account and region.
+// Accounts are only resolved when several accounts are scanned, remote resources having no account otherwise.
+type AwsStateArnResolver struct {
+	resolveAccounts bool
+}
+
+func NewAwsStateArnResolver(resolveAccounts bool) AwsStateArnResolver {
+	return AwsStateArnResolver{resolveAccounts: resolveAccounts}
+}
+
+func (m AwsStateArnResolver) Execute(_, resourcesFromState *[]*resource.Resource) error {
+	for _, res := range *resourcesFromState {
+		if !strings.HasPrefix(res.ResourceType(), "aws_") || res.Attributes() == nil {
+			continue
+		}
+
+		parsedArn := parseResourceArn(res)
+
+		if res.Region == "" {
+			res.Region = parsedArn.Region
+			// S3 bucket ARNs never contain the region
+			if res.ResourceType() == aws.AwsS3BucketResourceType {
+				if region := res.Attributes().GetString("region"); region != nil {
+					res.Region = *region
+				}
+			}
+		}
+		if m.resolveAccounts && res.Account == "" {
+			res.Account = parsedArn.AccountID
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"type":    res.ResourceType(),
+			"id":      res.ResourceId(),
+			"region":  res.Region,
+			"account": res.Account,
+		}).Trace("Resolved location of state resource")
+	}
+
+	return nil
+}
+
+// Global services (e.g. IAM) have an empty region in their ARN,
+// and S3 buckets do not even have an account
+func parseResourceArn(res *resource.Resource) arn.ARN {
+	rawArn, ok := res.Attributes().Get("arn")
+	if !ok {
+		return arn.ARN{}
+	}
+	strArn, ok := rawArn.(string)
+	if !ok {
+		return arn.ARN{}
+	}
+	parsedArn, err := arn.Parse(strArn)
+	if err != nil {
+		return arn.ARN{}
+	}
+	return parsedArn
+}
+
+// Types returns nil as every resource is handled independently
+func (m AwsStateArnResolver) Types() []resource.ResourceType {
+	return nil
+}
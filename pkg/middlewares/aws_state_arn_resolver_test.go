@@ -0,0 +1,133 @@
+package middlewares
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+func TestAwsStateArnResolver_Execute(t *testing.T) {
+	tests := []struct {
+		name               string
+		resolveAccounts    bool
+		resourcesFromState []*resource.Resource
+		expectedRegions    []string
+		expectedAccounts   []string
+	}{
+		{
+			name:            "resolve region from arn",
+			resolveAccounts: true,
+			resourcesFromState: []*resource.Resource{
+				{
+					Id:   "my-queue",
+					Type: "aws_sns_topic",
+					Attrs: &resource.Attributes{
+						"arn": "arn:aws:sns:eu-west-3:123456789012:my-queue",
+					},
+				},
+			},
+			expectedRegions:  []string{"eu-west-3"},
+			expectedAccounts: []string{"123456789012"},
+		},
+		{
+			name:            "resolve region of s3 bucket",
+			resolveAccounts: true,
+			resourcesFromState: []*resource.Resource{
+				{
+					Id:   "my-bucket",
+					Type: "aws_s3_bucket",
+					Attrs: &resource.Attributes{
+						"arn":    "arn:aws:s3:::my-bucket",
+						"region": "us-east-1",
+					},
+				},
+			},
+			expectedRegions:  []string{"us-east-1"},
+			expectedAccounts: []string{""},
+		},
+		{
+			name:            "global resources have no region",
+			resolveAccounts: true,
+			resourcesFromState: []*resource.Resource{
+				{
+					Id:   "my-role",
+					Type: "aws_iam_role",
+					Attrs: &resource.Attributes{
+						"arn": "arn:aws:iam::123456789012:role/my-role",
+					},
+				},
+				{
+					Id:    "without-arn",
+					Type:  "aws_route",
+					Attrs: &resource.Attributes{},
+				},
+				{
+					Id:   "invalid-arn",
+					Type: "aws_instance",
+					Attrs: &resource.Attributes{
+						"arn": "not-an-arn",
+					},
+				},
+			},
+			expectedRegions:  []string{"", "", ""},
+			expectedAccounts: []string{"123456789012", "", ""},
+		},
+		{
+			name:            "do not override known location and ignore other providers",
+			resolveAccounts: true,
+			resourcesFromState: []*resource.Resource{
+				{
+					Id:      "my-instance",
+					Type:    "aws_instance",
+					Region:  "us-east-1",
+					Account: "210987654321",
+					Attrs: &resource.Attributes{
+						"arn": "arn:aws:ec2:eu-west-3:123456789012:instance/my-instance",
+					},
+				},
+				{
+					Id:   "my-repo",
+					Type: "github_repository",
+					Attrs: &resource.Attributes{
+						"arn": "arn:aws:ec2:eu-west-3:123456789012:instance/my-instance",
+					},
+				},
+			},
+			expectedRegions:  []string{"us-east-1", ""},
+			expectedAccounts: []string{"210987654321", ""},
+		},
+		{
+			name: "do not resolve accounts when a single account is scanned",
+			resourcesFromState: []*resource.Resource{
+				{
+					Id:   "my-queue",
+					Type: "aws_sns_topic",
+					Attrs: &resource.Attributes{
+						"arn": "arn:aws:sns:eu-west-3:123456789012:my-queue",
+					},
+				},
+			},
+			expectedRegions:  []string{"eu-west-3"},
+			expectedAccounts: []string{""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewAwsStateArnResolver(tt.resolveAccounts)
+			remoteResources := []*resource.Resource{}
+			if err := m.Execute(&remoteResources, &tt.resourcesFromState); err != nil {
+				t.Fatal(err)
+			}
+			regions := make([]string, 0, len(tt.resourcesFromState))
+			accounts := make([]string, 0, len(tt.resourcesFromState))
+			for _, res := range tt.resourcesFromState {
+				regions = append(regions, res.Region)
+				accounts = append(accounts, res.Account)
+			}
+			assert.Equal(t, tt.expectedRegions, regions)
+			assert.Equal(t, tt.expectedAccounts, accounts)
+		})
+	}
+}
@@ -64,3 +64,10 @@ func (m AzurermRouteExpander) Execute(_, resourcesFromState *[]*resource.Resourc
 	*resourcesFromState = newList
 	return nil
 }
+
+func (m AzurermRouteExpander) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		azurerm.AzureRouteTableResourceType,
+		azurerm.AzureRouteResourceType,
+	}
+}
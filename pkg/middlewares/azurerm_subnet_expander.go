@@ -60,3 +60,10 @@ func (m AzurermSubnetExpander) Execute(_, resourcesFromState *[]*resource.Resour
 	*resourcesFromState = newList
 	return nil
 }
+
+func (m AzurermSubnetExpander) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		azurerm.AzureVirtualNetworkResourceType,
+		azurerm.AzureSubnetResourceType,
+	}
+}
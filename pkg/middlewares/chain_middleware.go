@@ -25,3 +25,70 @@ func (c Chain) Execute(remoteResources, resourcesFromState *[]*resource.Resource
 	}
 	return nil
 }
+
+// TypeGroups partitions resource types so that each middleware of a chain handles types of a single group,
+// resources of a group can then be processed without the ones of other groups
+type TypeGroups struct {
+	chain  Chain
+	groups map[resource.ResourceType]resource.ResourceType
+}
+
+// TypeGroups returns the type groups of the chain, or false when a middleware does not declare its types
+func (c Chain) TypeGroups() (*TypeGroups, bool) {
+	parents := make(map[resource.ResourceType]resource.ResourceType)
+	var find func(ty resource.ResourceType) resource.ResourceType
+	find = func(ty resource.ResourceType) resource.ResourceType {
+		parent, exists := parents[ty]
+		if !exists || parent == ty {
+			return ty
+		}
+		root := find(parent)
+		parents[ty] = root
+		return root
+	}
+
+	for _, middleware := range c {
+		typed, ok := middleware.(TypedMiddleware)
+		if !ok {
+			return nil, false
+		}
+		types := typed.Types()
+		for _, ty := range types {
+			if _, exists := parents[ty]; !exists {
+				parents[ty] = ty
+			}
+			// The smallest type is kept as root for groups to be named the same way on every run
+			first, root := find(types[0]), find(ty)
+			if root < first {
+				first, root = root, first
+			}
+			parents[root] = first
+		}
+	}
+
+	groups := make(map[resource.ResourceType]resource.ResourceType, len(parents))
+	for ty := range parents {
+		groups[ty] = find(ty)
+	}
+	return &TypeGroups{chain: c, groups: groups}, true
+}
+
+// Group returns the type naming the group of the given type
+func (g *TypeGroups) Group(ty resource.ResourceType) resource.ResourceType {
+	if group, exists := g.groups[ty]; exists {
+		return group
+	}
+	return ty
+}
+
+// Chain returns the middlewares handling resources of the given group
+func (g *TypeGroups) Chain(group resource.ResourceType) Chain {
+	chain := make(Chain, 0)
+	for _, middleware := range g.chain {
+		types := middleware.(TypedMiddleware).Types()
+		if len(types) == 0 || g.Group(types[0]) == group {
+			chain = append(chain, middleware)
+		}
+	}
+	return chain
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/cloudskiff/driftctl/pkg/resource"
 )
 
@@ -19,6 +21,15 @@ func (m FakeMiddleware) Execute(remoteResources, resourcesFromState *[]*resource
 	return m.Err
 }
 
+type FakeTypedMiddleware struct {
+	FakeMiddleware
+	types []resource.ResourceType
+}
+
+func (m FakeTypedMiddleware) Types() []resource.ResourceType {
+	return m.types
+}
+
 func TestChainMiddleware(t *testing.T) {
 
 	callCounters = make(map[string]int)
@@ -83,3 +94,31 @@ func TestChainMiddlewareErrorShouldStopExecution(t *testing.T) {
 	}
 
 }
+
+func TestChainMiddleware_TypeGroups(t *testing.T) {
+	vpc := FakeTypedMiddleware{FakeMiddleware{Name: "vpc"}, []resource.ResourceType{"aws_vpc"}}
+	route := FakeTypedMiddleware{FakeMiddleware{Name: "route"}, []resource.ResourceType{"aws_route_table", "aws_route"}}
+	igw := FakeTypedMiddleware{FakeMiddleware{Name: "igw"}, []resource.ResourceType{"aws_internet_gateway", "aws_route"}}
+	tags := FakeTypedMiddleware{FakeMiddleware{Name: "tags"}, nil}
+
+	groups, ok := NewChain(vpc, route, tags, igw).TypeGroups()
+	assert.True(t, ok)
+
+	assert.Equal(t, resource.ResourceType("aws_vpc"), groups.Group("aws_vpc"))
+	assert.Equal(t, resource.ResourceType("aws_internet_gateway"), groups.Group("aws_route_table"))
+	assert.Equal(t, resource.ResourceType("aws_internet_gateway"), groups.Group("aws_route"))
+	assert.Equal(t, resource.ResourceType("aws_internet_gateway"), groups.Group("aws_internet_gateway"))
+	assert.Equal(t, resource.ResourceType("aws_s3_bucket"), groups.Group("aws_s3_bucket"))
+
+	assert.Equal(t, Chain{vpc, tags}, groups.Chain("aws_vpc"))
+	assert.Equal(t, Chain{route, tags, igw}, groups.Chain("aws_internet_gateway"))
+	assert.Equal(t, Chain{tags}, groups.Chain("aws_s3_bucket"))
+}
+
+func TestChainMiddleware_TypeGroupsWithUntypedMiddleware(t *testing.T) {
+	vpc := FakeTypedMiddleware{FakeMiddleware{Name: "vpc"}, []resource.ResourceType{"aws_vpc"}}
+
+	groups, ok := NewChain(vpc, FakeMiddleware{Name: "untyped"}).TypeGroups()
+	assert.False(t, ok)
+	assert.Nil(t, groups)
+}
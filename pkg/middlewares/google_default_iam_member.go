@@ -68,3 +68,9 @@ func (m *GoogleDefaultIAMMember) Execute(remoteResources, resourcesFromState *[]
 
 	return nil
 }
+
+func (m *GoogleDefaultIAMMember) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		google.GoogleProjectIamMemberResourceType,
+	}
+}
@@ -63,3 +63,12 @@ func (m *GoogleIAMBindingTransformer) Execute(_, resourcesFromState *[]*resource
 
 	return nil
 }
+
+func (m *GoogleIAMBindingTransformer) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		google.GoogleProjectIamBindingResourceType,
+		google.GoogleStorageBucketIamBindingResourceType,
+		google.GoogleProjectIamMemberResourceType,
+		google.GoogleStorageBucketIamMemberResourceType,
+	}
+}
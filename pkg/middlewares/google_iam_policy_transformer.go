@@ -76,3 +76,12 @@ func (m *GoogleStorageBucketIAMPolicyTransformer) Execute(_, resourcesFromState
 type policyDataType struct {
 	Bindings []map[string]interface{}
 }
+
+func (m *GoogleStorageBucketIAMPolicyTransformer) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		google.GoogleProjectIamPolicyResourceType,
+		google.GoogleStorageBucketIamPolicyResourceType,
+		google.GoogleProjectIamMemberResourceType,
+		google.GoogleStorageBucketIamMemberResourceType,
+	}
+}
@@ -58,3 +58,9 @@ func (m *GoogleLegacyBucketIAMMember) Execute(remoteResources, resourcesFromStat
 
 	return nil
 }
+
+func (m *GoogleLegacyBucketIAMMember) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		google.GoogleStorageBucketIamMemberResourceType,
+	}
+}
@@ -53,3 +53,10 @@ func (m *GoogleStorageBucketIAMBindingTransformer) Execute(_, resourcesFromState
 
 	return nil
 }
+
+func (m *GoogleStorageBucketIAMBindingTransformer) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		google.GoogleStorageBucketIamBindingResourceType,
+		google.GoogleStorageBucketIamMemberResourceType,
+	}
+}
@@ -82,3 +82,9 @@ func (m IamPolicyAttachmentExpander) expand(policyAttachment *resource.Resource)
 	}
 	return newResources
 }
+
+func (m IamPolicyAttachmentExpander) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		resourceaws.AwsIamPolicyAttachmentResourceType,
+	}
+}
@@ -5,3 +5,11 @@ import "github.com/cloudskiff/driftctl/pkg/resource"
 type Middleware interface {
 	Execute(remoteResources, resourcesFromState *[]*resource.Resource) error
 }
+
+// TypedMiddleware is a middleware declaring the resource types it reads, updates or creates,
+// so it can be executed on resources of these types only.
+// A middleware handling every resource independently returns no types.
+type TypedMiddleware interface {
+	Middleware
+	Types() []resource.ResourceType
+}
@@ -63,3 +63,9 @@ func isDefaultRecord(record *resource.Resource) bool {
 	ty, _ := record.Attrs.Get("type")
 	return ty == "NS" || ty == "SOA"
 }
+
+func (m Route53DefaultZoneRecordSanitizer) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsRoute53RecordResourceType,
+	}
+}
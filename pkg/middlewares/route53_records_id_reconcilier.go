@@ -45,3 +45,9 @@ func (m Route53RecordIDReconcilier) Execute(_, resourcesFromState *[]*resource.R
 
 	return nil
 }
+
+func (m Route53RecordIDReconcilier) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsRoute53RecordResourceType,
+	}
+}
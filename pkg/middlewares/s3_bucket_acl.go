@@ -44,3 +44,9 @@ func (m S3BucketAcl) Execute(remoteResources, resourcesFromState *[]*resource.Re
 
 	return nil
 }
+
+func (m S3BucketAcl) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsS3BucketResourceType,
+	}
+}
@@ -29,3 +29,8 @@ func (a TagsAllManager) Execute(remoteResources, resourcesFromState *[]*resource
 	}
 	return nil
 }
+
+// Types returns nil as every resource is handled independently
+func (a TagsAllManager) Types() []resource.ResourceType {
+	return nil
+}
@@ -48,3 +48,9 @@ func (m VPCDefaultSecurityGroupSanitizer) Execute(remoteResources, resourcesFrom
 
 	return nil
 }
+
+func (m VPCDefaultSecurityGroupSanitizer) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		aws.AwsDefaultSecurityGroupResourceType,
+	}
+}
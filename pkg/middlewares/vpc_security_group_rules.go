@@ -154,3 +154,9 @@ func shouldBeSplit(r *resource.Resource) bool {
 	}
 	return i > 1
 }
+
+func (m VPCSecurityGroupRuleSanitizer) Types() []resource.ResourceType {
+	return []resource.ResourceType{
+		resourceaws.AwsSecurityGroupRuleResourceType,
+	}
+}
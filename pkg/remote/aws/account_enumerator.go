@@ -0,0 +1,39 @@
+package aws
+
+import (
+	"github.com/cloudskiff/driftctl/pkg/remote/common"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+// AccountEnumerator decorates an enumerator bound to a single account and
+// tags every enumerated resource with that account id
+type AccountEnumerator struct {
+	account    string
+	enumerator common.Enumerator
+}
+
+func NewAccountEnumerator(account string, enumerator common.Enumerator) *AccountEnumerator {
+	return &AccountEnumerator{
+		account:    account,
+		enumerator: enumerator,
+	}
+}
+
+func (e *AccountEnumerator) SupportedType() resource.ResourceType {
+	return e.enumerator.SupportedType()
+}
+
+func (e *AccountEnumerator) Enumerate() ([]*resource.Resource, error) {
+	resources, err := e.enumerator.Enumerate()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, res := range resources {
+		if res != nil {
+			res.Account = e.account
+		}
+	}
+
+	return resources, nil
+}
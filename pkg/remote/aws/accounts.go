@@ -0,0 +1,77 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
+	"github.com/pkg/errors"
+)
+
+const assumeRoleSessionName = "driftctl"
+
+// Account is an AWS account scanned by assuming a role
+type Account struct {
+	ID      string
+	RoleARN string
+}
+
+// ResolveAccounts returns the list of accounts to scan.
+// Accounts are deduced from the given role ARNs, and when an organization role name is given,
+// the same role is assumed in every active account of the AWS Organization.
+func ResolveAccounts(client organizationsiface.OrganizationsAPI, roleARNs []string, organizationRoleName string) ([]Account, error) {
+	results := make([]Account, 0, len(roleARNs))
+	seen := make(map[string]struct{}, len(roleARNs))
+
+	for _, roleARN := range roleARNs {
+		parsedArn, err := arn.Parse(roleARN)
+		if err != nil || parsedArn.Service != "iam" || parsedArn.AccountID == "" {
+			return nil, errors.Errorf("invalid role ARN '%s'", roleARN)
+		}
+		if _, exist := seen[parsedArn.AccountID]; exist {
+			continue
+		}
+		seen[parsedArn.AccountID] = struct{}{}
+		results = append(results, Account{
+			ID:      parsedArn.AccountID,
+			RoleARN: roleARN,
+		})
+	}
+
+	if organizationRoleName == "" {
+		return results, nil
+	}
+
+	var organizationAccounts []*organizations.Account
+	err := client.ListAccountsPages(&organizations.ListAccountsInput{}, func(output *organizations.ListAccountsOutput, lastPage bool) bool {
+		organizationAccounts = append(organizationAccounts, output.Accounts...)
+		return !lastPage
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list accounts of the organization")
+	}
+
+	for _, account := range organizationAccounts {
+		if aws.StringValue(account.Status) != organizations.AccountStatusActive {
+			continue
+		}
+		id := aws.StringValue(account.Id)
+		if _, exist := seen[id]; exist {
+			continue
+		}
+		seen[id] = struct{}{}
+
+		partition := "aws"
+		if accountArn, err := arn.Parse(aws.StringValue(account.Arn)); err == nil {
+			partition = accountArn.Partition
+		}
+		results = append(results, Account{
+			ID:      id,
+			RoleARN: fmt.Sprintf("arn:%s:iam::%s:role/%s", partition, id, organizationRoleName),
+		})
+	}
+
+	return results, nil
+}
@@ -0,0 +1,109 @@
+package aws
+
+import (
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	awstest "github.com/cloudskiff/driftctl/test/aws"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestResolveAccounts(t *testing.T) {
+	tests := []struct {
+		name                 string
+		roleARNs             []string
+		organizationRoleName string
+		mocks                func(client *awstest.MockFakeOrganizations)
+		want                 []Account
+		wantErr              string
+	}{
+		{
+			name:     "no roles",
+			roleARNs: []string{},
+			mocks:    func(client *awstest.MockFakeOrganizations) {},
+			want:     []Account{},
+		},
+		{
+			name: "explicit roles are deduplicated by account",
+			roleARNs: []string{
+				"arn:aws:iam::111111111111:role/driftctl",
+				"arn:aws:iam::222222222222:role/driftctl",
+				"arn:aws:iam::111111111111:role/other",
+			},
+			mocks: func(client *awstest.MockFakeOrganizations) {},
+			want: []Account{
+				{ID: "111111111111", RoleARN: "arn:aws:iam::111111111111:role/driftctl"},
+				{ID: "222222222222", RoleARN: "arn:aws:iam::222222222222:role/driftctl"},
+			},
+		},
+		{
+			name:     "invalid role",
+			roleARNs: []string{"driftctl"},
+			mocks:    func(client *awstest.MockFakeOrganizations) {},
+			wantErr:  "invalid role ARN 'driftctl'",
+		},
+		{
+			name:                 "organization accounts",
+			roleARNs:             []string{"arn:aws:iam::111111111111:role/custom"},
+			organizationRoleName: "OrganizationAccountAccessRole",
+			mocks: func(client *awstest.MockFakeOrganizations) {
+				client.On("ListAccountsPages", &organizations.ListAccountsInput{}, mock.MatchedBy(func(callback func(res *organizations.ListAccountsOutput, lastPage bool) bool) bool {
+					callback(&organizations.ListAccountsOutput{
+						Accounts: []*organizations.Account{
+							{
+								Id:     awssdk.String("111111111111"),
+								Arn:    awssdk.String("arn:aws:organizations::000000000000:account/o-test/111111111111"),
+								Status: awssdk.String(organizations.AccountStatusActive),
+							},
+							{
+								Id:     awssdk.String("222222222222"),
+								Arn:    awssdk.String("arn:aws:organizations::000000000000:account/o-test/222222222222"),
+								Status: awssdk.String(organizations.AccountStatusSuspended),
+							},
+						},
+					}, false)
+					callback(&organizations.ListAccountsOutput{
+						Accounts: []*organizations.Account{
+							{
+								Id:     awssdk.String("333333333333"),
+								Arn:    awssdk.String("arn:aws-us-gov:organizations::000000000000:account/o-test/333333333333"),
+								Status: awssdk.String(organizations.AccountStatusActive),
+							},
+						},
+					}, true)
+					return true
+				})).Return(nil).Once()
+			},
+			want: []Account{
+				{ID: "111111111111", RoleARN: "arn:aws:iam::111111111111:role/custom"},
+				{ID: "333333333333", RoleARN: "arn:aws-us-gov:iam::333333333333:role/OrganizationAccountAccessRole"},
+			},
+		},
+		{
+			name:                 "cannot list organization accounts",
+			organizationRoleName: "OrganizationAccountAccessRole",
+			mocks: func(client *awstest.MockFakeOrganizations) {
+				client.On("ListAccountsPages", &organizations.ListAccountsInput{}, mock.Anything).Return(errors.New("access denied")).Once()
+			},
+			wantErr: "unable to list accounts of the organization: access denied",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &awstest.MockFakeOrganizations{}
+			tt.mocks(client)
+
+			got, err := ResolveAccounts(client, tt.roleARNs, tt.organizationRoleName)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			client.AssertExpectations(t)
+		})
+	}
+}
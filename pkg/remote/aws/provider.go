@@ -16,10 +16,7 @@ type awsConfig struct {
 	Region        string `cty:"region"`
 	MaxRetries    int
 
-	AssumeRoleARN         string
-	AssumeRoleExternalID  string
-	AssumeRoleSessionName string
-	AssumeRolePolicy      string
+	AssumeRole []assumeRoleConfig `cty:"assume_role"`
 
 	AllowedAccountIds   []string
 	ForbiddenAccountIds []string
@@ -36,11 +33,19 @@ type awsConfig struct {
 	S3ForcePathStyle        bool
 }
 
+type assumeRoleConfig struct {
+	RoleARN     string `cty:"role_arn"`
+	ExternalID  string `cty:"external_id"`
+	SessionName string `cty:"session_name"`
+}
+
 type AWSTerraformProvider struct {
 	*terraform.TerraformProvider
 	session *session.Session
 	name    string
 	version string
+	// Roles to assume to read resources of an account, indexed by account id
+	assumeRoles map[string]assumeRoleConfig
 }
 
 func NewAWSTerraformProvider(version string, progress output.Progress, configDir string) (*AWSTerraformProvider, error) {
@@ -48,8 +53,9 @@ func NewAWSTerraformProvider(version string, progress output.Progress, configDir
 		version = "3.19.0"
 	}
 	p := &AWSTerraformProvider{
-		version: version,
-		name:    "aws",
+		version:     version,
+		name:        "aws",
+		assumeRoles: make(map[string]assumeRoleConfig),
 	}
 	installer, err := tf.NewProviderInstaller(tf.ProviderConfig{
 		Key:       p.name,
@@ -66,10 +72,18 @@ func NewAWSTerraformProvider(version string, progress output.Progress, configDir
 		Name:         p.name,
 		DefaultAlias: *p.session.Config.Region,
 		GetProviderConfig: func(alias string) interface{} {
-			return awsConfig{
-				Region:     alias,
+			account, region := terraform.ParseProviderAlias(alias)
+			if region == "" {
+				region = *p.session.Config.Region
+			}
+			config := awsConfig{
+				Region:     region,
 				MaxRetries: 10, // TODO make this configurable
 			}
+			if role, exist := p.assumeRoles[account]; exist {
+				config.AssumeRole = []assumeRoleConfig{role}
+			}
+			return config
 		},
 	}, progress)
 	if err != nil {
@@ -86,3 +100,12 @@ func (a *AWSTerraformProvider) Name() string {
 func (p *AWSTerraformProvider) Version() string {
 	return p.version
 }
+
+// AddAssumeRole registers the role to assume when reading resources of the given account
+func (p *AWSTerraformProvider) AddAssumeRole(account Account, externalID string) {
+	p.assumeRoles[account.ID] = assumeRoleConfig{
+		RoleARN:     account.RoleARN,
+		ExternalID:  externalID,
+		SessionName: assumeRoleSessionName,
+	}
+}
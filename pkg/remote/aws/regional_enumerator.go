@@ -0,0 +1,39 @@
+package aws
+
+import (
+	"github.com/cloudskiff/driftctl/pkg/remote/common"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+// RegionalEnumerator decorates an enumerator bound to a single region and
+// tags every enumerated resource with that region
+type RegionalEnumerator struct {
+	region     string
+	enumerator common.Enumerator
+}
+
+func NewRegionalEnumerator(region string, enumerator common.Enumerator) *RegionalEnumerator {
+	return &RegionalEnumerator{
+		region:     region,
+		enumerator: enumerator,
+	}
+}
+
+func (e *RegionalEnumerator) SupportedType() resource.ResourceType {
+	return e.enumerator.SupportedType()
+}
+
+func (e *RegionalEnumerator) Enumerate() ([]*resource.Resource, error) {
+	resources, err := e.enumerator.Enumerate()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, res := range resources {
+		if res != nil {
+			res.Region = e.region
+		}
+	}
+
+	return resources, nil
+}
@@ -0,0 +1,58 @@
+package aws
+
+import (
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/pkg/errors"
+)
+
+// RegionsAll is the special value used to scan every region enabled on the account
+const RegionsAll = "all"
+
+// ResolveRegions returns the list of regions to scan.
+// When no region is given, we only scan the region configured in the AWS session.
+// When RegionsAll is given, every region enabled on the account is discovered using the EC2 API.
+func ResolveRegions(client ec2iface.EC2API, defaultRegion string, regions []string) ([]string, error) {
+	if len(regions) == 0 {
+		return []string{defaultRegion}, nil
+	}
+
+	for _, region := range regions {
+		if region == RegionsAll {
+			return listEnabledRegions(client)
+		}
+	}
+
+	results := make([]string, 0, len(regions))
+	seen := make(map[string]struct{}, len(regions))
+	for _, region := range regions {
+		if region == "" {
+			continue
+		}
+		if _, exist := seen[region]; exist {
+			continue
+		}
+		seen[region] = struct{}{}
+		results = append(results, region)
+	}
+
+	return results, nil
+}
+
+func listEnabledRegions(client ec2iface.EC2API) ([]string, error) {
+	// Regions that are not opted in are not returned when AllRegions is false
+	output, err := client.DescribeRegions(&ec2.DescribeRegionsInput{})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list enabled regions")
+	}
+
+	results := make([]string, 0, len(output.Regions))
+	for _, region := range output.Regions {
+		if region.RegionName == nil {
+			continue
+		}
+		results = append(results, *region.RegionName)
+	}
+
+	return results, nil
+}
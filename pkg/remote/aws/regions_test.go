@@ -0,0 +1,71 @@
+package aws
+
+import (
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	awstest "github.com/cloudskiff/driftctl/test/aws"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveRegions(t *testing.T) {
+	tests := []struct {
+		name    string
+		regions []string
+		mocks   func(client *awstest.MockFakeEC2)
+		want    []string
+		wantErr string
+	}{
+		{
+			name:    "default region",
+			regions: []string{},
+			mocks:   func(client *awstest.MockFakeEC2) {},
+			want:    []string{"us-east-1"},
+		},
+		{
+			name:    "explicit regions are deduplicated",
+			regions: []string{"eu-west-3", "us-east-1", "eu-west-3", ""},
+			mocks:   func(client *awstest.MockFakeEC2) {},
+			want:    []string{"eu-west-3", "us-east-1"},
+		},
+		{
+			name:    "all enabled regions",
+			regions: []string{"eu-west-3", RegionsAll},
+			mocks: func(client *awstest.MockFakeEC2) {
+				client.On("DescribeRegions", &ec2.DescribeRegionsInput{}).Return(&ec2.DescribeRegionsOutput{
+					Regions: []*ec2.Region{
+						{RegionName: awssdk.String("eu-west-1")},
+						{RegionName: awssdk.String("eu-west-3")},
+						{RegionName: awssdk.String("us-east-1")},
+					},
+				}, nil).Once()
+			},
+			want: []string{"eu-west-1", "eu-west-3", "us-east-1"},
+		},
+		{
+			name:    "cannot list enabled regions",
+			regions: []string{RegionsAll},
+			mocks: func(client *awstest.MockFakeEC2) {
+				client.On("DescribeRegions", &ec2.DescribeRegionsInput{}).Return(nil, errors.New("access denied")).Once()
+			},
+			wantErr: "unable to list enabled regions: access denied",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &awstest.MockFakeEC2{}
+			tt.mocks(client)
+
+			got, err := ResolveRegions(client, "us-east-1", tt.regions)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			client.AssertExpectations(t)
+		})
+	}
+}
@@ -1,14 +1,19 @@
 package repository
 
 import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/aws/aws-sdk-go/service/cloudformation/cloudformationiface"
 	"github.com/cloudskiff/driftctl/pkg/remote/cache"
+	"github.com/pkg/errors"
 )
 
 type CloudformationRepository interface {
 	ListAllStacks() ([]*cloudformation.Stack, error)
+	ListAllStackResources(stack string) ([]*cloudformation.StackResource, error)
 }
 
 type cloudformationRepository struct {
@@ -45,3 +50,62 @@ func (r *cloudformationRepository) ListAllStacks() ([]*cloudformation.Stack, err
 	r.cache.Put("cloudformationListAllStacks", stacks)
 	return stacks, nil
 }
+
+// ListAllStackResources lists the resources of a stack, given its name or id.
+// Resources are listed page by page, as DescribeStackResources only returns the first 100 resources of a stack.
+func (r *cloudformationRepository) ListAllStackResources(stack string) ([]*cloudformation.StackResource, error) {
+	cacheKey := fmt.Sprintf("cloudformationListAllStackResources_stack_%s", stack)
+	if v := r.cache.Get(cacheKey); v != nil {
+		return v.([]*cloudformation.StackResource), nil
+	}
+
+	// Resource summaries do not tell which stack they belong to
+	stackId, err := r.stackId(stack)
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []*cloudformation.StackResource
+	input := cloudformation.ListStackResourcesInput{
+		StackName: &stack,
+	}
+	err = r.client.ListStackResourcesPages(&input,
+		func(resp *cloudformation.ListStackResourcesOutput, lastPage bool) bool {
+			for _, summary := range resp.StackResourceSummaries {
+				resources = append(resources, &cloudformation.StackResource{
+					StackId:              stackId,
+					LogicalResourceId:    summary.LogicalResourceId,
+					PhysicalResourceId:   summary.PhysicalResourceId,
+					ResourceType:         summary.ResourceType,
+					ResourceStatus:       summary.ResourceStatus,
+					ResourceStatusReason: summary.ResourceStatusReason,
+					Timestamp:            summary.LastUpdatedTimestamp,
+				})
+			}
+			return !lastPage
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.Put(cacheKey, resources)
+	return resources, nil
+}
+
+// stackId returns the id of a stack given its name, nested stacks being already known by their id
+func (r *cloudformationRepository) stackId(stack string) (*string, error) {
+	if arn.IsARN(stack) {
+		return &stack, nil
+	}
+	output, err := r.client.DescribeStacks(&cloudformation.DescribeStacksInput{
+		StackName: &stack,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.Stacks) == 0 {
+		return nil, errors.Errorf("Stack with id %s does not exist", stack)
+	}
+	return output.Stacks[0].StackId, nil
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -84,3 +86,124 @@ func Test_cloudformationRepository_ListAllStacks(t *testing.T) {
 		})
 	}
 }
+
+func Test_cloudformationRepository_ListAllStackResources(t *testing.T) {
+	stackId := "arn:aws:cloudformation:us-east-1:123456789012:stack/my-stack/8c0e2a70-2f7b-11ec-8d3d-0242ac130003"
+
+	// Stacks can have up to 500 resources, more than the 100 resources DescribeStackResources returns
+	summaries := make([]*cloudformation.StackResourceSummary, 0, 150)
+	resources := make([]*cloudformation.StackResource, 0, 150)
+	for i := 0; i < 150; i++ {
+		summaries = append(summaries, &cloudformation.StackResourceSummary{
+			LogicalResourceId:  aws.String(fmt.Sprintf("Queue%d", i)),
+			PhysicalResourceId: aws.String(fmt.Sprintf("https://sqs.us-east-1.amazonaws.com/123456789012/queue-%d", i)),
+			ResourceType:       aws.String("AWS::SQS::Queue"),
+			ResourceStatus:     aws.String(cloudformation.ResourceStatusCreateComplete),
+		})
+		resources = append(resources, &cloudformation.StackResource{
+			StackId:            aws.String(stackId),
+			LogicalResourceId:  aws.String(fmt.Sprintf("Queue%d", i)),
+			PhysicalResourceId: aws.String(fmt.Sprintf("https://sqs.us-east-1.amazonaws.com/123456789012/queue-%d", i)),
+			ResourceType:       aws.String("AWS::SQS::Queue"),
+			ResourceStatus:     aws.String(cloudformation.ResourceStatusCreateComplete),
+		})
+	}
+
+	listPages := func(client *awstest.MockFakeCloudformation, stack string) {
+		client.On("ListStackResourcesPages",
+			&cloudformation.ListStackResourcesInput{
+				StackName: aws.String(stack),
+			},
+			mock.MatchedBy(func(callback func(res *cloudformation.ListStackResourcesOutput, lastPage bool) bool) bool {
+				callback(&cloudformation.ListStackResourcesOutput{
+					StackResourceSummaries: summaries[:100],
+				}, false)
+				callback(&cloudformation.ListStackResourcesOutput{
+					StackResourceSummaries: summaries[100:],
+				}, true)
+				return true
+			})).Return(nil).Once()
+	}
+
+	tests := []struct {
+		name    string
+		stack   string
+		mocks   func(client *awstest.MockFakeCloudformation, store *cache.MockCache)
+		want    []*cloudformation.StackResource
+		wantErr error
+	}{
+		{
+			name:  "list stack resources of every page",
+			stack: "my-stack",
+			mocks: func(client *awstest.MockFakeCloudformation, store *cache.MockCache) {
+				client.On("DescribeStacks",
+					&cloudformation.DescribeStacksInput{
+						StackName: aws.String("my-stack"),
+					}).Return(&cloudformation.DescribeStacksOutput{
+					Stacks: []*cloudformation.Stack{{StackId: aws.String(stackId)}},
+				}, nil).Once()
+				listPages(client, "my-stack")
+
+				store.On("Get", "cloudformationListAllStackResources_stack_my-stack").Return(nil).Times(1)
+				store.On("Put", "cloudformationListAllStackResources_stack_my-stack", resources).Return(false).Times(1)
+			},
+			want: resources,
+		},
+		{
+			name:  "list resources of a stack given its id",
+			stack: stackId,
+			mocks: func(client *awstest.MockFakeCloudformation, store *cache.MockCache) {
+				listPages(client, stackId)
+
+				store.On("Get", "cloudformationListAllStackResources_stack_"+stackId).Return(nil).Times(1)
+				store.On("Put", "cloudformationListAllStackResources_stack_"+stackId, resources).Return(false).Times(1)
+			},
+			want: resources,
+		},
+		{
+			name:  "should hit cache",
+			stack: "my-stack",
+			mocks: func(client *awstest.MockFakeCloudformation, store *cache.MockCache) {
+				store.On("Get", "cloudformationListAllStackResources_stack_my-stack").Return(resources).Times(1)
+			},
+			want: resources,
+		},
+		{
+			name:  "should return an error",
+			stack: "my-stack",
+			mocks: func(client *awstest.MockFakeCloudformation, store *cache.MockCache) {
+				client.On("DescribeStacks",
+					&cloudformation.DescribeStacksInput{
+						StackName: aws.String("my-stack"),
+					}).Return(nil, errors.New("Stack with id my-stack does not exist")).Once()
+
+				store.On("Get", "cloudformationListAllStackResources_stack_my-stack").Return(nil).Times(1)
+			},
+			wantErr: errors.New("Stack with id my-stack does not exist"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &cache.MockCache{}
+			client := &awstest.MockFakeCloudformation{}
+			tt.mocks(client, store)
+			r := &cloudformationRepository{
+				client: client,
+				cache:  store,
+			}
+			got, err := r.ListAllStackResources(tt.stack)
+			assert.Equal(t, tt.wantErr, err)
+
+			changelog, err := diff.Diff(got, tt.want)
+			assert.Nil(t, err)
+			if len(changelog) > 0 {
+				for _, change := range changelog {
+					t.Errorf("%s: %s -> %s", strings.Join(change.Path, "."), change.From, change.To)
+				}
+				t.Fail()
+			}
+			store.AssertExpectations(t)
+			client.AssertExpectations(t)
+		})
+	}
+}
@@ -34,3 +34,26 @@ func (_m *MockCloudformationRepository) ListAllStacks() ([]*cloudformation.Stack
 
 	return r0, r1
 }
+
+// ListAllStackResources provides a mock function with given fields: stack
+func (_m *MockCloudformationRepository) ListAllStackResources(stack string) ([]*cloudformation.StackResource, error) {
+	ret := _m.Called(stack)
+
+	var r0 []*cloudformation.StackResource
+	if rf, ok := ret.Get(0).(func(string) []*cloudformation.StackResource); ok {
+		r0 = rf(stack)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*cloudformation.StackResource)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(stack)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
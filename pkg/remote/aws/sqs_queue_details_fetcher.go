@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	remoteerror "github.com/cloudskiff/driftctl/pkg/remote/error"
+	tf "github.com/cloudskiff/driftctl/pkg/remote/terraform"
 	"github.com/cloudskiff/driftctl/pkg/resource"
 	"github.com/cloudskiff/driftctl/pkg/resource/aws"
 	"github.com/cloudskiff/driftctl/pkg/terraform"
@@ -23,9 +24,14 @@ func NewSQSQueueDetailsFetcher(provider terraform.ResourceReader, deserializer *
 }
 
 func (r *SQSQueueDetailsFetcher) ReadDetails(res *resource.Resource) (*resource.Resource, error) {
+	attributes := map[string]string{}
+	if alias := tf.ProviderAlias(res.Account, res.Region); alias != "" {
+		attributes["alias"] = alias
+	}
 	ctyVal, err := r.reader.ReadResource(terraform.ReadResourceArgs{
-		ID: res.ResourceId(),
-		Ty: aws.AwsSqsQueueResourceType,
+		ID:         res.ResourceId(),
+		Ty:         aws.AwsSqsQueueResourceType,
+		Attributes: attributes,
 	})
 	if err != nil {
 		if strings.Contains(err.Error(), "NonExistentQueue") {
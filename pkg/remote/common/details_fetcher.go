@@ -2,6 +2,7 @@ package common
 
 import (
 	remoteerror "github.com/cloudskiff/driftctl/pkg/remote/error"
+	tf "github.com/cloudskiff/driftctl/pkg/remote/terraform"
 	"github.com/cloudskiff/driftctl/pkg/resource"
 	"github.com/cloudskiff/driftctl/pkg/terraform"
 	"github.com/sirupsen/logrus"
@@ -30,6 +31,18 @@ func (f *GenericDetailsFetcher) ReadDetails(res *resource.Resource) (*resource.R
 	if res.Schema().ResolveReadAttributesFunc != nil {
 		attributes = res.Schema().ResolveReadAttributesFunc(res)
 	}
+	// Provider aliases are named after the account and the region,
+	// so we read the resource from the location it was enumerated in
+	region := attributes["alias"]
+	if region == "" {
+		region = res.Region
+	}
+	if alias := tf.ProviderAlias(res.Account, region); alias != "" {
+		if attributes == nil {
+			attributes = map[string]string{}
+		}
+		attributes["alias"] = alias
+	}
 	ctyVal, err := f.reader.ReadResource(terraform.ReadResourceArgs{
 		Ty:         f.resType,
 		ID:         res.ResourceId(),
@@ -0,0 +1,13 @@
+package common
+
+// RemoteOptions holds the settings that are specific to a remote and that are given at activation time
+type RemoteOptions struct {
+	// Regions is the list of AWS regions to enumerate
+	Regions []string
+	// AssumeRoleARNs is the list of AWS roles to assume, one per account to scan
+	AssumeRoleARNs []string
+	// AssumeRoleExternalID is the external id given when assuming AWS roles
+	AssumeRoleExternalID string
+	// OrganizationRoleName is the name of the AWS role to assume in every account of the organization
+	OrganizationRoleName string
+}
@@ -58,7 +58,9 @@ loop:
 	return results, runner.Err()
 }
 
-func (s *Scanner) scan() ([]*resource.Resource, error) {
+// enumerators returns the enumerators of types that are not ignored
+func (s *Scanner) enumerators() []common.Enumerator {
+	enumerators := make([]common.Enumerator, 0)
 	for _, enumerator := range s.remoteLibrary.Enumerators() {
 		if s.filter.IsTypeIgnored(enumerator.SupportedType()) {
 			logrus.WithFields(logrus.Fields{
@@ -66,41 +68,37 @@ func (s *Scanner) scan() ([]*resource.Resource, error) {
 			}).Debug("Ignored enumeration of resources since it is ignored in filter")
 			continue
 		}
-		enumerator := enumerator
-		s.enumeratorRunner.Run(func() (interface{}, error) {
-			resources, err := enumerator.Enumerate()
-			if err != nil {
-				err := HandleResourceEnumerationError(err, s.alerter)
-				if err == nil {
-					return []*resource.Resource{}, nil
-				}
-				return nil, err
-			}
-			for _, res := range resources {
-				if res == nil {
-					continue
-				}
-				logrus.WithFields(logrus.Fields{
-					"id":   res.ResourceId(),
-					"type": res.ResourceType(),
-				}).Debug("Found cloud resource")
-			}
-			return resources, nil
-		})
+		enumerators = append(enumerators, enumerator)
 	}
+	return enumerators
+}
 
-	enumerationResult, err := s.retrieveRunnerResults(s.enumeratorRunner)
+func (s *Scanner) enumerate(enumerator common.Enumerator) ([]*resource.Resource, error) {
+	resources, err := enumerator.Enumerate()
 	if err != nil {
+		err := HandleResourceEnumerationError(err, s.alerter)
+		if err == nil {
+			return []*resource.Resource{}, nil
+		}
 		return nil, err
 	}
-
-	if !s.options.Deep {
-		return enumerationResult, nil
+	for _, res := range resources {
+		if res == nil {
+			continue
+		}
+		logrus.WithFields(logrus.Fields{
+			"id":   res.ResourceId(),
+			"type": res.ResourceType(),
+		}).Debug("Found cloud resource")
 	}
+	return resources, nil
+}
 
-	for _, res := range enumerationResult {
+// fetchDetails reads details of the given resources using the runner
+func (s *Scanner) fetchDetails(runner *parallel.ParallelRunner, resources []*resource.Resource) ([]*resource.Resource, error) {
+	for _, res := range resources {
 		res := res
-		s.detailsFetcherRunner.Run(func() (interface{}, error) {
+		runner.Run(func() (interface{}, error) {
 			fetcher := s.remoteLibrary.GetDetailsFetcher(resource.ResourceType(res.ResourceType()))
 			if fetcher == nil {
 				return []*resource.Resource{res}, nil
@@ -113,11 +111,103 @@ func (s *Scanner) scan() ([]*resource.Resource, error) {
 				}
 				return []*resource.Resource{}, nil
 			}
+			if resourceWithDetails != nil {
+				resourceWithDetails.Region = res.Region
+				resourceWithDetails.Account = res.Account
+			}
 			return []*resource.Resource{resourceWithDetails}, nil
 		})
 	}
 
-	return s.retrieveRunnerResults(s.detailsFetcherRunner)
+	return s.retrieveRunnerResults(runner)
+}
+
+func (s *Scanner) scan() ([]*resource.Resource, error) {
+	for _, enumerator := range s.enumerators() {
+		enumerator := enumerator
+		s.enumeratorRunner.Run(func() (interface{}, error) {
+			return s.enumerate(enumerator)
+		})
+	}
+
+	enumerationResult, err := s.retrieveRunnerResults(s.enumeratorRunner)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.options.Deep {
+		return enumerationResult, nil
+	}
+
+	return s.fetchDetails(s.detailsFetcherRunner, enumerationResult)
+}
+
+// Types returns the resource types enumerated by the scanner
+func (s *Scanner) Types() []resource.ResourceType {
+	types := make([]resource.ResourceType, 0)
+	seen := make(map[resource.ResourceType]bool)
+	for _, enumerator := range s.remoteLibrary.Enumerators() {
+		ty := enumerator.SupportedType()
+		if seen[ty] || s.filter.IsTypeIgnored(ty) {
+			continue
+		}
+		seen[ty] = true
+		types = append(types, ty)
+	}
+	return types
+}
+
+// Stream enumerates resources like Resources, the resources of a type being sent as soon as
+// every enumerator of this type is done. In deep mode, details are fetched right after the enumeration.
+func (s *Scanner) Stream(out chan<- resource.TypedResources) error {
+	defer close(out)
+
+	enumerators := s.enumerators()
+	pending := make(map[resource.ResourceType]int)
+	for _, enumerator := range enumerators {
+		pending[enumerator.SupportedType()]++
+		enumerator := enumerator
+		s.enumeratorRunner.Run(func() (interface{}, error) {
+			resources, err := s.enumerate(enumerator)
+			if err != nil {
+				return nil, err
+			}
+			if s.options.Deep {
+				// Runners of details share the same limit of parallel fetches
+				resources, err = s.fetchDetails(s.detailsFetcherRunner.SubRunner(), resources)
+				if err != nil {
+					return nil, err
+				}
+			}
+			return resource.TypedResources{Type: enumerator.SupportedType(), Resources: resources}, nil
+		})
+	}
+
+	enumerated := make(map[resource.ResourceType][]*resource.Resource)
+loop:
+	for {
+		select {
+		case result, ok := <-s.enumeratorRunner.Read():
+			if !ok || result == nil {
+				break loop
+			}
+
+			typed := result.(resource.TypedResources)
+			for _, res := range typed.Resources {
+				if res != nil {
+					enumerated[typed.Type] = append(enumerated[typed.Type], res)
+				}
+			}
+			pending[typed.Type]--
+			if pending[typed.Type] == 0 {
+				out <- resource.TypedResources{Type: typed.Type, Resources: enumerated[typed.Type]}
+				delete(enumerated, typed.Type)
+			}
+		case <-s.enumeratorRunner.DoneChan():
+			break loop
+		}
+	}
+	return s.enumeratorRunner.Err()
 }
 
 func (s *Scanner) Resources() ([]*resource.Resource, error) {
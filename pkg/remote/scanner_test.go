@@ -5,9 +5,11 @@ import (
 
 	"github.com/cloudskiff/driftctl/pkg/alerter"
 	"github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/cloudskiff/driftctl/pkg/remote/aws"
 	"github.com/cloudskiff/driftctl/pkg/remote/common"
 	"github.com/cloudskiff/driftctl/pkg/resource"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestScannerShouldIgnoreType(t *testing.T) {
@@ -29,3 +31,137 @@ func TestScannerShouldIgnoreType(t *testing.T) {
 	assert.Nil(t, err)
 	fakeEnumerator.AssertExpectations(t)
 }
+
+func TestScannerShouldTagResourcesWithRegion(t *testing.T) {
+	alerter := alerter.NewAlerter()
+
+	usEnumerator := &common.MockEnumerator{}
+	usEnumerator.On("SupportedType").Return(resource.ResourceType("FakeType"))
+	usEnumerator.On("Enumerate").Return([]*resource.Resource{{Id: "foo", Type: "FakeType"}}, nil)
+
+	euEnumerator := &common.MockEnumerator{}
+	euEnumerator.On("SupportedType").Return(resource.ResourceType("FakeType"))
+	euEnumerator.On("Enumerate").Return([]*resource.Resource{{Id: "foo", Type: "FakeType"}}, nil)
+
+	fakeDetailsFetcher := &common.MockDetailsFetcher{}
+	fakeDetailsFetcher.On("ReadDetails", mock.Anything).Return(func(res *resource.Resource) *resource.Resource {
+		return &resource.Resource{Id: res.Id, Type: res.Type, Attrs: &resource.Attributes{}}
+	}, nil)
+
+	remoteLibrary := common.NewRemoteLibrary()
+	remoteLibrary.AddEnumerator(aws.NewRegionalEnumerator("us-east-1", usEnumerator))
+	remoteLibrary.AddEnumerator(aws.NewRegionalEnumerator("eu-west-3", euEnumerator))
+	remoteLibrary.AddDetailsFetcher("FakeType", fakeDetailsFetcher)
+
+	testFilter := &filter.MockFilter{}
+	testFilter.On("IsTypeIgnored", mock.Anything).Return(false)
+
+	s := NewScanner(remoteLibrary, alerter, ScannerOptions{Deep: true}, testFilter)
+	resources, err := s.Resources()
+	assert.Nil(t, err)
+
+	regions := make([]string, 0, len(resources))
+	for _, res := range resources {
+		assert.NotNil(t, res.Attrs)
+		regions = append(regions, res.Region)
+	}
+	assert.ElementsMatch(t, []string{"us-east-1", "eu-west-3"}, regions)
+}
+
+func TestScannerShouldTagResourcesWithAccount(t *testing.T) {
+	alerter := alerter.NewAlerter()
+
+	firstEnumerator := &common.MockEnumerator{}
+	firstEnumerator.On("SupportedType").Return(resource.ResourceType("FakeType"))
+	firstEnumerator.On("Enumerate").Return([]*resource.Resource{{Id: "foo", Type: "FakeType"}}, nil)
+
+	secondEnumerator := &common.MockEnumerator{}
+	secondEnumerator.On("SupportedType").Return(resource.ResourceType("FakeType"))
+	secondEnumerator.On("Enumerate").Return([]*resource.Resource{{Id: "foo", Type: "FakeType"}}, nil)
+
+	fakeDetailsFetcher := &common.MockDetailsFetcher{}
+	fakeDetailsFetcher.On("ReadDetails", mock.Anything).Return(func(res *resource.Resource) *resource.Resource {
+		return &resource.Resource{Id: res.Id, Type: res.Type, Attrs: &resource.Attributes{}}
+	}, nil)
+
+	remoteLibrary := common.NewRemoteLibrary()
+	remoteLibrary.AddEnumerator(aws.NewAccountEnumerator("111111111111", aws.NewRegionalEnumerator("us-east-1", firstEnumerator)))
+	remoteLibrary.AddEnumerator(aws.NewAccountEnumerator("222222222222", aws.NewRegionalEnumerator("us-east-1", secondEnumerator)))
+	remoteLibrary.AddDetailsFetcher("FakeType", fakeDetailsFetcher)
+
+	testFilter := &filter.MockFilter{}
+	testFilter.On("IsTypeIgnored", mock.Anything).Return(false)
+
+	s := NewScanner(remoteLibrary, alerter, ScannerOptions{Deep: true}, testFilter)
+	resources, err := s.Resources()
+	assert.Nil(t, err)
+
+	accounts := make([]string, 0, len(resources))
+	for _, res := range resources {
+		assert.Equal(t, "us-east-1", res.Region)
+		accounts = append(accounts, res.Account)
+	}
+	assert.ElementsMatch(t, []string{"111111111111", "222222222222"}, accounts)
+}
+
+func TestScannerStream(t *testing.T) {
+	alerter := alerter.NewAlerter()
+
+	usEnumerator := &common.MockEnumerator{}
+	usEnumerator.On("SupportedType").Return(resource.ResourceType("FakeType"))
+	usEnumerator.On("Enumerate").Return([]*resource.Resource{{Id: "foo", Type: "FakeType"}}, nil)
+
+	euEnumerator := &common.MockEnumerator{}
+	euEnumerator.On("SupportedType").Return(resource.ResourceType("FakeType"))
+	euEnumerator.On("Enumerate").Return([]*resource.Resource{{Id: "foo", Type: "FakeType"}}, nil)
+
+	otherEnumerator := &common.MockEnumerator{}
+	otherEnumerator.On("SupportedType").Return(resource.ResourceType("OtherType"))
+	otherEnumerator.On("Enumerate").Return([]*resource.Resource{{Id: "bar", Type: "OtherType"}}, nil)
+
+	ignoredEnumerator := &common.MockEnumerator{}
+	ignoredEnumerator.On("SupportedType").Return(resource.ResourceType("IgnoredType"))
+
+	fakeDetailsFetcher := &common.MockDetailsFetcher{}
+	fakeDetailsFetcher.On("ReadDetails", mock.Anything).Return(func(res *resource.Resource) *resource.Resource {
+		return &resource.Resource{Id: res.Id, Type: res.Type, Attrs: &resource.Attributes{}}
+	}, nil)
+
+	remoteLibrary := common.NewRemoteLibrary()
+	remoteLibrary.AddEnumerator(aws.NewRegionalEnumerator("us-east-1", usEnumerator))
+	remoteLibrary.AddEnumerator(aws.NewRegionalEnumerator("eu-west-3", euEnumerator))
+	remoteLibrary.AddEnumerator(otherEnumerator)
+	remoteLibrary.AddEnumerator(ignoredEnumerator)
+	remoteLibrary.AddDetailsFetcher("FakeType", fakeDetailsFetcher)
+
+	testFilter := &filter.MockFilter{}
+	testFilter.On("IsTypeIgnored", resource.ResourceType("IgnoredType")).Return(true)
+	testFilter.On("IsTypeIgnored", mock.Anything).Return(false)
+
+	s := NewScanner(remoteLibrary, alerter, ScannerOptions{Deep: true}, testFilter)
+	assert.ElementsMatch(t, []resource.ResourceType{"FakeType", "OtherType"}, s.Types())
+
+	out := make(chan resource.TypedResources)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- s.Stream(out)
+	}()
+
+	streamed := make(map[resource.ResourceType][]*resource.Resource)
+	for typed := range out {
+		assert.NotContains(t, streamed, typed.Type, "type %s was streamed twice", typed.Type)
+		streamed[typed.Type] = typed.Resources
+	}
+	assert.Nil(t, <-errs)
+
+	assert.Len(t, streamed, 2)
+	regions := make([]string, 0, len(streamed["FakeType"]))
+	for _, res := range streamed["FakeType"] {
+		assert.NotNil(t, res.Attrs)
+		regions = append(regions, res.Region)
+	}
+	assert.ElementsMatch(t, []string{"us-east-1", "eu-west-3"}, regions)
+	assert.Len(t, streamed["OtherType"], 1)
+	assert.Nil(t, streamed["OtherType"][0].Attrs)
+	ignoredEnumerator.AssertNotCalled(t, "Enumerate")
+}
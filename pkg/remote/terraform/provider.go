@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"os/signal"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -23,6 +24,23 @@ import (
 	tf "github.com/cloudskiff/driftctl/pkg/terraform"
 )
 
+// ProviderAlias builds the alias used to read a resource located in the given account and region.
+// When scanning multiple accounts, the alias is prefixed by the account, e.g. "123456789012/eu-west-3"
+func ProviderAlias(account, region string) string {
+	if account == "" {
+		return region
+	}
+	return account + "/" + region
+}
+
+// ParseProviderAlias splits an alias built with ProviderAlias into its account and region
+func ParseProviderAlias(alias string) (account, region string) {
+	if i := strings.Index(alias, "/"); i >= 0 {
+		return alias[:i], alias[i+1:]
+	}
+	return "", alias
+}
+
 // "alias" in these struct are a way to namespace gRPC clients.
 // For example, if we need to read S3 bucket from multiple AWS region,
 // we'll have an alias per region, and the alias IS the region itself.
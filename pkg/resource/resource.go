@@ -16,20 +16,37 @@ type Source interface {
 	InternalName() string
 }
 
+// SourceKind tells which kind of IaC source manages a resource
+type SourceKind string
+
+const (
+	SourceKindTerraform      SourceKind = "terraform"
+	SourceKindCloudformation SourceKind = "cloudformation"
+	SourceKindPulumi         SourceKind = "pulumi"
+)
+
+// SourceKinds lists the supported kinds of sources
+var SourceKinds = []SourceKind{SourceKindTerraform, SourceKindCloudformation, SourceKindPulumi}
+
 type SerializableSource struct {
-	S    string `json:"source"`
-	Ns   string `json:"namespace"`
-	Name string `json:"internal_name"`
+	// Kind is empty in results written before other sources than Terraform states were supported
+	Kind      SourceKind `json:"kind,omitempty"`
+	S         string     `json:"source"`
+	Ns        string     `json:"namespace"`
+	Name      string     `json:"internal_name"`
+	Workspace string     `json:"workspace,omitempty"`
 }
 
 type TerraformStateSource struct {
 	State  string
 	Module string
 	Name   string
+	// Workspace is the terraform workspace of the state, when known
+	Workspace string
 }
 
 func NewTerraformStateSource(state, module, name string) *TerraformStateSource {
-	return &TerraformStateSource{state, module, name}
+	return &TerraformStateSource{State: state, Module: module, Name: name}
 }
 
 func (s *TerraformStateSource) Source() string {
@@ -44,12 +61,61 @@ func (s *TerraformStateSource) InternalName() string {
 	return s.Name
 }
 
+// CloudformationSource locates a resource managed by a CloudFormation stack,
+// the namespace is the path of logical ids of the nested stacks containing it
+type CloudformationSource struct {
+	Stack       string
+	NestedStack string
+	LogicalId   string
+}
+
+func NewCloudformationSource(stack, nestedStack, logicalId string) *CloudformationSource {
+	return &CloudformationSource{stack, nestedStack, logicalId}
+}
+
+func (s *CloudformationSource) Source() string {
+	return s.Stack
+}
+
+func (s *CloudformationSource) Namespace() string {
+	return s.NestedStack
+}
+
+func (s *CloudformationSource) InternalName() string {
+	return s.LogicalId
+}
+
+// PulumiSource locates a resource managed by a Pulumi stack, identified by its URN
+type PulumiSource struct {
+	Export string
+	Stack  string
+	Urn    string
+}
+
+func NewPulumiSource(export, stack, urn string) *PulumiSource {
+	return &PulumiSource{export, stack, urn}
+}
+
+func (s *PulumiSource) Source() string {
+	return s.Export
+}
+
+func (s *PulumiSource) Namespace() string {
+	return s.Stack
+}
+
+func (s *PulumiSource) InternalName() string {
+	return s.Urn
+}
+
 type Resource struct {
-	Id     string
-	Type   string
-	Attrs  *Attributes
-	Sch    *Schema `json:"-" diff:"-"`
-	Source Source  `json:"-"`
+	Id      string
+	Type    string
+	Attrs   *Attributes
+	Sch     *Schema `json:"-" diff:"-"`
+	Source  Source  `json:"-"`
+	Region  string  `json:"-"`
+	Account string  `json:"-"`
 }
 
 func (r *Resource) Schema() *Schema {
@@ -87,6 +153,14 @@ func (r *Resource) Equal(res *Resource) bool {
 		return false
 	}
 
+	// Resources located in different regions or accounts are never the same, even if they share an id
+	if r.Region != "" && res.Region != "" && r.Region != res.Region {
+		return false
+	}
+	if r.Account != "" && res.Account != "" && r.Account != res.Account {
+		return false
+	}
+
 	if r.Schema() != nil && r.Schema().DiscriminantFunc != nil {
 		return r.Schema().DiscriminantFunc(r, res)
 	}
@@ -99,11 +173,18 @@ type ResourceFactory interface {
 }
 
 type SerializableResource struct {
-	Id     string              `json:"id"`
-	Type   string              `json:"type"`
-	Source *SerializableSource `json:"source,omitempty"`
+	Id         string              `json:"id"`
+	Type       string              `json:"type"`
+	Source     *SerializableSource `json:"source,omitempty"`
+	Region     string              `json:"region,omitempty"`
+	Account    string              `json:"account,omitempty"`
+	Attributes *Attributes         `json:"attributes,omitempty"`
 }
 
+// SensitiveValue replaces the values of the attributes marked as sensitive in the provider schema
+const SensitiveValue = "(sensitive value)"
+
+// NewSerializableResource serializes a resource without its attributes, as they may hold secrets
 func NewSerializableResource(res *Resource) *SerializableResource {
 	var src *SerializableSource
 	if res.Src() != nil {
@@ -112,12 +193,89 @@ func NewSerializableResource(res *Resource) *SerializableResource {
 			Ns:   res.Src().Namespace(),
 			Name: res.Src().InternalName(),
 		}
+		switch source := res.Src().(type) {
+		case *TerraformStateSource:
+			src.Kind = SourceKindTerraform
+			src.Workspace = source.Workspace
+		case *CloudformationSource:
+			src.Kind = SourceKindCloudformation
+		case *PulumiSource:
+			src.Kind = SourceKindPulumi
+		}
 	}
 	return &SerializableResource{
-		Id:     res.ResourceId(),
-		Type:   res.ResourceType(),
-		Source: src,
+		Id:      res.ResourceId(),
+		Type:    res.ResourceType(),
+		Source:  src,
+		Region:  res.Region,
+		Account: res.Account,
+	}
+}
+
+// NewSerializableResourceWithAttributes serializes a resource with its attributes,
+// masking the values of attributes marked as sensitive in its schema
+func NewSerializableResourceWithAttributes(res *Resource) *SerializableResource {
+	serializable := NewSerializableResource(res)
+	if res.Attrs != nil {
+		attributes := maskSensitiveValues(res.Schema(), nil, *res.Attrs).(Attributes)
+		serializable.Attributes = &attributes
+	}
+	return serializable
+}
+
+// maskSensitiveValues returns a copy of an attribute value where sensitive values are masked.
+// Elements of lists and sets share the path of their attribute in the schema.
+func maskSensitiveValues(schema *Schema, path []string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case Attributes:
+		return Attributes(maskSensitiveValues(schema, path, map[string]interface{}(v)).(map[string]interface{}))
+	case map[string]interface{}:
+		masked := make(map[string]interface{}, len(v))
+		for key, field := range v {
+			fieldPath := append(append([]string{}, path...), key)
+			if field != nil && schema != nil && schema.IsSensitiveField(fieldPath) {
+				masked[key] = SensitiveValue
+				continue
+			}
+			masked[key] = maskSensitiveValues(schema, fieldPath, field)
+		}
+		return masked
+	case []interface{}:
+		masked := make([]interface{}, 0, len(v))
+		for _, element := range v {
+			masked = append(masked, maskSensitiveValues(schema, path, element))
+		}
+		return masked
+	}
+	return value
+}
+
+// Resource rebuilds the resource that was serialized
+func (r *SerializableResource) Resource() *Resource {
+	res := &Resource{
+		Id:      r.Id,
+		Type:    r.Type,
+		Attrs:   r.Attributes,
+		Region:  r.Region,
+		Account: r.Account,
+	}
+	if r.Source != nil {
+		res.Source = r.Source.source()
+	}
+	return res
+}
+
+// source rebuilds the source that was serialized, according to its kind
+func (s *SerializableSource) source() Source {
+	switch s.Kind {
+	case SourceKindCloudformation:
+		return NewCloudformationSource(s.S, s.Ns, s.Name)
+	case SourceKindPulumi:
+		return NewPulumiSource(s.S, s.Ns, s.Name)
 	}
+	source := NewTerraformStateSource(s.S, s.Ns, s.Name)
+	source.Workspace = s.Workspace
+	return source
 }
 
 type NormalizedResource interface {
@@ -47,6 +47,14 @@ func (s *Schema) IsComputedField(path []string) bool {
 	return metadata.ConfigSchema.Computed
 }
 
+func (s *Schema) IsSensitiveField(path []string) bool {
+	metadata, exist := s.Attributes[strings.Join(path, ".")]
+	if !exist {
+		return false
+	}
+	return metadata.ConfigSchema.Sensitive
+}
+
 func (s *Schema) IsJsonStringField(path []string) bool {
 	metadata, exist := s.Attributes[strings.Join(path, ".")]
 	if !exist {
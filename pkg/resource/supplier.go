@@ -9,3 +9,18 @@ type StoppableSupplier interface {
 	Supplier
 	Stop()
 }
+
+// TypedResources are all the resources of a type
+type TypedResources struct {
+	Type      ResourceType
+	Resources []*Resource
+}
+
+// StreamingSupplier supplies resources type by type, as soon as every resource of a type is retrieved
+type StreamingSupplier interface {
+	Supplier
+	// Types returns the resource types that will be streamed
+	Types() []ResourceType
+	// Stream sends resources of each type to the channel and closes it once done
+	Stream(out chan<- TypedResources) error
+}
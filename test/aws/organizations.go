@@ -0,0 +1,9 @@
+package aws
+
+import (
+	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
+)
+
+type FakeOrganizations interface {
+	organizationsiface.OrganizationsAPI
+}
@@ -0,0 +1,179 @@
+package azure
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FakeBlobAccount is the storage account served by FakeBlobServer, named like the Azurite one
+const FakeBlobAccount = "devstoreaccount1"
+
+// FakeBlobAccountKey is the well known Azurite account key
+const FakeBlobAccountKey = "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw=="
+
+// fakeBlobPageSize is kept small so listing blobs is paginated
+const fakeBlobPageSize = 2
+
+// FakeBlobServer is a minimal Azurite-style Blob Storage server supporting blob listing and download.
+// Blobs are indexed by CONTAINER/PATH/TO/BLOB and served under the /devstoreaccount1 path.
+// Only read requests are accepted so any attempt to lease or write a blob fails.
+// Requests must either carry a SAS token or be signed with FakeBlobAccountKey.
+type FakeBlobServer struct {
+	Blobs map[string]string
+}
+
+type fakeBlob struct {
+	Name          string `xml:"Name"`
+	ContentLength int    `xml:"Properties>Content-Length"`
+}
+
+type fakeBlobList struct {
+	XMLName    xml.Name   `xml:"EnumerationResults"`
+	Blobs      []fakeBlob `xml:"Blobs>Blob"`
+	NextMarker string     `xml:"NextMarker"`
+}
+
+func (s *FakeBlobServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet || r.URL.Query().Get("comp") == "lease" {
+		s.error(w, http.StatusMethodNotAllowed, "UnsupportedHttpVerb", "The resource doesn't support the specified HTTP verb.")
+		return
+	}
+	if r.URL.Query().Get("sig") == "" && r.Header.Get("Authorization") != fmt.Sprintf("SharedKey %s:%s", FakeBlobAccount, sharedKeySignature(r)) {
+		s.error(w, http.StatusForbidden, "AuthorizationFailure", "This request is not authorized to perform this operation.")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/"+FakeBlobAccount+"/")
+	if r.URL.Query().Get("comp") == "list" {
+		s.list(w, path, r.URL.Query().Get("prefix"), r.URL.Query().Get("marker"))
+		return
+	}
+
+	content, exist := s.Blobs[path]
+	if !exist {
+		s.error(w, http.StatusNotFound, "BlobNotFound", "The specified blob does not exist.\nRequestId:00000000-0000-0000-0000-000000000000")
+		return
+	}
+	_, _ = w.Write([]byte(content))
+}
+
+// sharedKeySignature signs the request with FakeBlobAccountKey the way the storage service does, see
+// https://docs.microsoft.com/en-us/rest/api/storageservices/authorize-with-shared-key
+func sharedKeySignature(r *http.Request) string {
+	standardHeaders := []string{
+		"Content-Encoding",
+		"Content-Language",
+		"Content-Length",
+		"Content-MD5",
+		"Content-Type",
+		"Date",
+		"If-Modified-Since",
+		"If-Match",
+		"If-None-Match",
+		"If-Unmodified-Since",
+		"Range",
+	}
+	var stringToSign strings.Builder
+	stringToSign.WriteString(r.Method + "\n")
+	for _, name := range standardHeaders {
+		value := r.Header.Get(name)
+		if name == "Content-Length" && value == "0" {
+			value = ""
+		}
+		stringToSign.WriteString(value + "\n")
+	}
+
+	var msHeaders []string
+	for name := range r.Header {
+		if strings.HasPrefix(strings.ToLower(name), "x-ms-") {
+			msHeaders = append(msHeaders, name)
+		}
+	}
+	sort.Slice(msHeaders, func(i, j int) bool {
+		return strings.ToLower(msHeaders[i]) < strings.ToLower(msHeaders[j])
+	})
+	for _, name := range msHeaders {
+		_, _ = fmt.Fprintf(&stringToSign, "%s:%s\n", strings.ToLower(name), strings.TrimSpace(r.Header.Get(name)))
+	}
+
+	stringToSign.WriteString("/" + FakeBlobAccount + r.URL.EscapedPath())
+	query := r.URL.Query()
+	params := make([]string, 0, len(query))
+	for key := range query {
+		params = append(params, key)
+	}
+	sort.Strings(params)
+	for _, key := range params {
+		values := query[key]
+		sort.Strings(values)
+		_, _ = fmt.Fprintf(&stringToSign, "\n%s:%s", strings.ToLower(key), strings.Join(values, ","))
+	}
+
+	key, _ := base64.StdEncoding.DecodeString(FakeBlobAccountKey)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(stringToSign.String()))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (s *FakeBlobServer) list(w http.ResponseWriter, container, prefix, marker string) {
+	var names []string
+	containerExists := false
+	for key := range s.Blobs {
+		if strings.HasPrefix(key, container+"/") {
+			containerExists = true
+		}
+		if strings.HasPrefix(key, container+"/"+prefix) {
+			names = append(names, strings.TrimPrefix(key, container+"/"))
+		}
+	}
+	if !containerExists {
+		s.error(w, http.StatusNotFound, "ContainerNotFound", "The specified container does not exist.")
+		return
+	}
+	sort.Strings(names)
+
+	start, _ := strconv.Atoi(marker)
+	end := start + fakeBlobPageSize
+	result := fakeBlobList{}
+	if end < len(names) {
+		result.NextMarker = strconv.Itoa(end)
+	} else {
+		end = len(names)
+	}
+	for _, name := range names[start:end] {
+		result.Blobs = append(result.Blobs, fakeBlob{
+			Name:          name,
+			ContentLength: len(s.Blobs[container+"/"+name]),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(result)
+}
+
+func (s *FakeBlobServer) error(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_, _ = fmt.Fprintf(w, "<?xml version=\"1.0\" encoding=\"utf-8\"?><Error><Code>%s</Code><Message>%s</Message></Error>", code, message)
+}
+
+// NewFakeBlobServer starts a fake blob server and returns the connection string targeting it
+func NewFakeBlobServer(blobs map[string]string) (*httptest.Server, string) {
+	server := httptest.NewServer(&FakeBlobServer{Blobs: blobs})
+	connectionString := fmt.Sprintf(
+		"DefaultEndpointsProtocol=http;AccountName=%s;AccountKey=%s;BlobEndpoint=%s/%s;",
+		FakeBlobAccount,
+		FakeBlobAccountKey,
+		server.URL,
+		FakeBlobAccount,
+	)
+	return server, connectionString
+}
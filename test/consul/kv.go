@@ -0,0 +1,72 @@
+package consul
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+)
+
+// FakeKVServer is a minimal Consul server supporting raw key reads and key listing
+type FakeKVServer struct {
+	Keys  map[string]string
+	Token string
+}
+
+func (s *FakeKVServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.Token != "" && r.Header.Get("X-Consul-Token") != s.Token {
+		http.Error(w, "ACL not found", http.StatusForbidden)
+		return
+	}
+	if r.Method != http.MethodGet || !strings.HasPrefix(r.URL.Path, "/v1/kv/") {
+		http.Error(w, "Unsupported request", http.StatusMethodNotAllowed)
+		return
+	}
+
+	key := strings.TrimPrefix(r.URL.Path, "/v1/kv/")
+	if _, exist := r.URL.Query()["keys"]; exist {
+		s.list(w, key, r.URL.Query().Get("separator"))
+		return
+	}
+
+	value, exist := s.Keys[key]
+	if !exist {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	_, _ = w.Write([]byte(value))
+}
+
+func (s *FakeKVServer) list(w http.ResponseWriter, prefix, separator string) {
+	found := make(map[string]struct{})
+	for key := range s.Keys {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		// Keys are folded up to the first separator following the prefix, like consul does
+		if separator != "" {
+			if idx := strings.Index(strings.TrimPrefix(key, prefix), separator); idx >= 0 {
+				key = key[:len(prefix)+idx+len(separator)]
+			}
+		}
+		found[key] = struct{}{}
+	}
+	if len(found) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	keys := make([]string, 0, len(found))
+	for key := range found {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(keys)
+}
+
+// NewFakeKVServer starts a fake consul server, its address is meant to be used as CONSUL_HTTP_ADDR
+func NewFakeKVServer(keys map[string]string, token string) *httptest.Server {
+	return httptest.NewServer(&FakeKVServer{Keys: keys, Token: token})
+}
@@ -0,0 +1,89 @@
+package google
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/option"
+)
+
+// fakeStoragePageSize is kept small so listing objects is paginated
+const fakeStoragePageSize = 2
+
+// FakeStorageServer is a minimal Google Cloud Storage server supporting object listing and download.
+// Objects are indexed by BUCKET/PATH/TO/OBJECT.
+type FakeStorageServer struct {
+	Objects map[string]string
+}
+
+type fakeStorageObject struct {
+	Bucket string `json:"bucket"`
+	Name   string `json:"name"`
+	Size   string `json:"size"`
+}
+
+type fakeStorageObjects struct {
+	Kind          string              `json:"kind"`
+	Items         []fakeStorageObject `json:"items"`
+	NextPageToken string              `json:"nextPageToken,omitempty"`
+}
+
+func (s *FakeStorageServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, "/storage/v1/b/") && strings.HasSuffix(r.URL.Path, "/o") {
+		bucket := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/storage/v1/b/"), "/o")
+		s.list(w, bucket, r.URL.Query().Get("prefix"), r.URL.Query().Get("pageToken"))
+		return
+	}
+
+	content, exist := s.Objects[strings.TrimPrefix(r.URL.Path, "/")]
+	if !exist {
+		http.Error(w, "No such object", http.StatusNotFound)
+		return
+	}
+	_, _ = w.Write([]byte(content))
+}
+
+func (s *FakeStorageServer) list(w http.ResponseWriter, bucket, prefix, pageToken string) {
+	var names []string
+	for key := range s.Objects {
+		if strings.HasPrefix(key, bucket+"/"+prefix) {
+			names = append(names, strings.TrimPrefix(key, bucket+"/"))
+		}
+	}
+	sort.Strings(names)
+
+	start, _ := strconv.Atoi(pageToken)
+	end := start + fakeStoragePageSize
+	result := fakeStorageObjects{Kind: "storage#objects", Items: []fakeStorageObject{}}
+	if end < len(names) {
+		result.NextPageToken = strconv.Itoa(end)
+	} else {
+		end = len(names)
+	}
+	for _, name := range names[start:end] {
+		result.Items = append(result.Items, fakeStorageObject{
+			Bucket: bucket,
+			Name:   name,
+			Size:   strconv.Itoa(len(s.Objects[bucket+"/"+name])),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+// NewFakeStorageServer starts a fake storage server and returns a client targeting it
+func NewFakeStorageServer(objects map[string]string) (*storage.Client, error) {
+	server := httptest.NewTLSServer(&FakeStorageServer{Objects: objects})
+	return storage.NewClient(
+		context.Background(),
+		option.WithEndpoint(server.URL+"/storage/v1/"),
+		option.WithHTTPClient(server.Client()),
+	)
+}
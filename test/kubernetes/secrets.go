@@ -0,0 +1,143 @@
+package kubernetes
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+)
+
+// FakeSecret is a secret served by FakeSecretServer
+type FakeSecret struct {
+	Namespace string
+	Name      string
+	Labels    map[string]string
+	Data      map[string][]byte
+}
+
+type fakeSecretMetadata struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+type fakeSecret struct {
+	Kind     string             `json:"kind"`
+	Metadata fakeSecretMetadata `json:"metadata"`
+	Data     map[string][]byte  `json:"data,omitempty"`
+}
+
+type fakeSecretList struct {
+	Kind     string       `json:"kind"`
+	Items    []fakeSecret `json:"items"`
+	Metadata struct {
+		Continue string `json:"continue,omitempty"`
+	} `json:"metadata"`
+}
+
+// FakeSecretServer is a minimal kubernetes API server supporting secret reads and listing with equality label selectors.
+// Lists are paginated with one secret per page.
+type FakeSecretServer struct {
+	Secrets []FakeSecret
+	Token   string
+}
+
+func (s *FakeSecretServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+s.Token {
+		s.status(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/v1/namespaces/"), "/")
+	if r.Method != http.MethodGet || len(parts) < 2 || parts[1] != "secrets" {
+		s.status(w, http.StatusNotFound, "the server could not find the requested resource")
+		return
+	}
+
+	var secrets []fakeSecret
+	for _, secret := range s.Secrets {
+		if secret.Namespace != parts[0] || (len(parts) == 3 && secret.Name != parts[2]) || !matchLabels(secret.Labels, r.URL.Query().Get("labelSelector")) {
+			continue
+		}
+		secrets = append(secrets, fakeSecret{
+			Kind:     "Secret",
+			Metadata: fakeSecretMetadata{Name: secret.Name, Namespace: secret.Namespace, Labels: secret.Labels},
+			Data:     secret.Data,
+		})
+	}
+	sort.Slice(secrets, func(i, j int) bool {
+		return secrets[i].Metadata.Name < secrets[j].Metadata.Name
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	if len(parts) == 3 {
+		if len(secrets) == 0 {
+			s.status(w, http.StatusNotFound, "secrets \""+parts[2]+"\" not found")
+			return
+		}
+		_ = json.NewEncoder(w).Encode(secrets[0])
+		return
+	}
+
+	list := fakeSecretList{Kind: "SecretList", Items: []fakeSecret{}}
+	start := 0
+	for i, secret := range secrets {
+		if secret.Metadata.Name == r.URL.Query().Get("continue") {
+			start = i
+		}
+	}
+	if start < len(secrets) {
+		list.Items = secrets[start : start+1]
+	}
+	if start+1 < len(secrets) {
+		list.Metadata.Continue = secrets[start+1].Metadata.Name
+	}
+	_ = json.NewEncoder(w).Encode(list)
+}
+
+func (s *FakeSecretServer) status(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"kind": "Status", "status": "Failure", "message": message, "code": code})
+}
+
+func matchLabels(labels map[string]string, selector string) bool {
+	if selector == "" {
+		return true
+	}
+	for _, requirement := range strings.Split(selector, ",") {
+		keyValue := strings.SplitN(requirement, "=", 2)
+		if len(keyValue) != 2 || labels[keyValue[0]] != keyValue[1] {
+			return false
+		}
+	}
+	return true
+}
+
+// NewFakeSecretServer starts a fake kubernetes API server, its URL is meant to be used as KUBE_HOST
+func NewFakeSecretServer(secrets []FakeSecret, token string) *httptest.Server {
+	return httptest.NewServer(&FakeSecretServer{Secrets: secrets, Token: token})
+}
+
+// StateSecret builds a secret storing a state like the terraform kubernetes backend
+func StateSecret(namespace, workspace, suffix, state string) FakeSecret {
+	var data bytes.Buffer
+	gz := gzip.NewWriter(&data)
+	_, _ = gz.Write([]byte(state))
+	_ = gz.Close()
+
+	return FakeSecret{
+		Namespace: namespace,
+		Name:      strings.Join([]string{"tfstate", workspace, suffix}, "-"),
+		Labels: map[string]string{
+			"app.kubernetes.io/managed-by": "terraform",
+			"tfstate":                      "true",
+			"tfstateSecretSuffix":          suffix,
+			"tfstateWorkspace":             workspace,
+		},
+		Data: map[string][]byte{"tfstate": data.Bytes()},
+	}
+}